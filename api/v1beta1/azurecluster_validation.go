@@ -23,6 +23,7 @@ import (
 	"regexp"
 
 	valid "github.com/asaskevich/govalidator"
+	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -60,6 +61,12 @@ const (
 	serviceEndpointLocationRegexPattern = `^([a-z]{1,42}\d{0,5}|[*])$`
 	// described in https://learn.microsoft.com/azure/azure-resource-manager/management/resource-name-rules.
 	privateEndpointRegex = `^[-\w\._]+$`
+	// described in https://learn.microsoft.com/azure/azure-resource-manager/management/resource-name-rules.
+	applicationSecurityGroupRegex = `^[-\w\._]+$`
+	// described in https://learn.microsoft.com/azure/azure-resource-manager/management/resource-name-rules.
+	subnetDelegationRegex = `^[-\w\._]+$`
+	// Must be a resource provider namespace followed by one or more resource types, e.g. Microsoft.ContainerInstance/containerGroups.
+	subnetDelegationServiceRegex = `^[a-zA-Z][a-zA-Z0-9]*(\.[a-zA-Z][a-zA-Z0-9]*)+(/[a-zA-Z][a-zA-Z0-9]*)+$`
 	// resource ID Pattern.
 	resourceIDPattern = `(?i)subscriptions/(.+)/resourceGroups/(.+)/providers/(.+?)/(.+?)/(.+)`
 )
@@ -197,6 +204,8 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 
 	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec.PrivateDNSZoneName, networkSpec.APIServerLB.Type, fldPath.Child("privateDNSZoneName"))...)
 
+	allErrs = append(allErrs, validateApplicationSecurityGroups(networkSpec, fldPath)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -216,6 +225,7 @@ func validateResourceGroup(resourceGroup string, fldPath *field.Path) *field.Err
 func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	subnetNames := make(map[string]bool, len(subnets))
+	routeTableRoutes := make(map[string]Routes, len(subnets))
 	requiredSubnetRoles := map[string]bool{
 		"control-plane": false,
 		"node":          false,
@@ -244,10 +254,35 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 		}
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
 
+		if len(subnet.RouteTable.Routes) > 0 {
+			allErrs = append(allErrs, validateRoutes(subnet.RouteTable.Routes, fldPath.Index(i).Child("routeTable").Child("routes"))...)
+		}
+		if subnet.RouteTable.Name != "" {
+			// The routes applied by CAPZ are tracked by route table name, so subnets sharing a route table must agree on them.
+			if routes, ok := routeTableRoutes[subnet.RouteTable.Name]; ok && !routesEqual(routes, subnet.RouteTable.Routes) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("routeTable").Child("routes"), subnet.RouteTable.Routes,
+					fmt.Sprintf("route table %s is shared with another subnet which specifies different routes", subnet.RouteTable.Name)))
+			} else if !ok {
+				routeTableRoutes[subnet.RouteTable.Name] = subnet.RouteTable.Routes
+			}
+		}
+
+		if subnet.SecurityGroup.FlowLogs != nil {
+			allErrs = append(allErrs, validateFlowLogs(subnet.SecurityGroup.FlowLogs, fldPath.Index(i).Child("securityGroup").Child("flowLogs"))...)
+		}
+
 		if len(subnet.ServiceEndpoints) > 0 {
 			allErrs = append(allErrs, validateServiceEndpoints(subnet.ServiceEndpoints, fldPath.Index(i).Child("serviceEndpoints"))...)
 		}
 
+		if len(subnet.Delegations) > 0 {
+			allErrs = append(allErrs, validateSubnetDelegations(subnet.Delegations, subnet.Role, fldPath.Index(i).Child("delegations"))...)
+		}
+
+		if len(subnet.ServiceEndpointPolicies) > 0 {
+			allErrs = append(allErrs, validateServiceEndpointPolicies(subnet.ServiceEndpointPolicies, fldPath.Index(i).Child("serviceEndpointPolicies"))...)
+		}
+
 		if len(subnet.PrivateEndpoints) > 0 {
 			allErrs = append(allErrs, validatePrivateEndpoints(subnet.PrivateEndpoints, subnet.CIDRBlocks, fldPath.Index(i).Child("privateEndpoints"))...)
 		}
@@ -364,6 +399,116 @@ func validateSecurityRule(rule SecurityRule, fldPath *field.Path) *field.Error {
 	return nil
 }
 
+// validateFlowLogs validates the flow logs of a security group.
+func validateFlowLogs(flowLogs *FlowLogs, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if success, _ := regexp.MatchString(resourceIDPattern, flowLogs.StorageAccountID); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("storageAccountID"), flowLogs.StorageAccountID,
+			fmt.Sprintf("flow logs storage account ID doesn't match regex %s", resourceIDPattern)))
+	}
+
+	if (flowLogs.NetworkWatcherName == "") != (flowLogs.NetworkWatcherResourceGroup == "") {
+		allErrs = append(allErrs, field.Invalid(fldPath, flowLogs,
+			"networkWatcherName and networkWatcherResourceGroup must be set together"))
+	}
+
+	if ta := flowLogs.TrafficAnalytics; ta != nil {
+		taPath := fldPath.Child("trafficAnalytics")
+		if success, _ := regexp.MatchString(resourceIDPattern, ta.WorkspaceResourceID); !success {
+			allErrs = append(allErrs, field.Invalid(taPath.Child("workspaceResourceID"), ta.WorkspaceResourceID,
+				fmt.Sprintf("traffic analytics workspace resource ID doesn't match regex %s", resourceIDPattern)))
+		}
+		if _, err := uuid.Parse(ta.WorkspaceID); err != nil {
+			allErrs = append(allErrs, field.Invalid(taPath.Child("workspaceID"), ta.WorkspaceID,
+				"traffic analytics workspace ID must be a GUID"))
+		}
+		if ta.WorkspaceRegion == "" {
+			allErrs = append(allErrs, field.Required(taPath.Child("workspaceRegion"), "traffic analytics workspace region must be set"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateApplicationSecurityGroups validates the application security groups of a NetworkSpec
+// and their references from the security rules of each subnet.
+func validateApplicationSecurityGroups(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	asgPath := fldPath.Child("applicationSecurityGroups")
+	asgNames := make(map[string]bool, len(networkSpec.ApplicationSecurityGroups))
+	for i, asg := range networkSpec.ApplicationSecurityGroups {
+		if success, _ := regexp.MatchString(applicationSecurityGroupRegex, asg.Name); !success {
+			allErrs = append(allErrs, field.Invalid(asgPath.Index(i).Child("name"), asg.Name,
+				fmt.Sprintf("name of application security group doesn't match regex %s", applicationSecurityGroupRegex)))
+		}
+		if asgNames[asg.Name] {
+			allErrs = append(allErrs, field.Duplicate(asgPath.Index(i).Child("name"), asg.Name))
+		}
+		asgNames[asg.Name] = true
+	}
+
+	for i, subnet := range networkSpec.Subnets {
+		rulesPath := fldPath.Child("subnets").Index(i).Child("securityGroup").Child("securityRules")
+		for j, rule := range subnet.SecurityGroup.SecurityRules {
+			if rule.Source != nil && len(rule.SourceApplicationSecurityGroups) > 0 {
+				allErrs = append(allErrs, field.Forbidden(rulesPath.Index(j).Child("sourceApplicationSecurityGroups"),
+					"source and sourceApplicationSecurityGroups are mutually exclusive"))
+			}
+			if rule.Destination != nil && len(rule.DestinationApplicationSecurityGroups) > 0 {
+				allErrs = append(allErrs, field.Forbidden(rulesPath.Index(j).Child("destinationApplicationSecurityGroups"),
+					"destination and destinationApplicationSecurityGroups are mutually exclusive"))
+			}
+			for k, name := range rule.SourceApplicationSecurityGroups {
+				if !asgNames[name] {
+					allErrs = append(allErrs, field.NotFound(rulesPath.Index(j).Child("sourceApplicationSecurityGroups").Index(k), name))
+				}
+			}
+			for k, name := range rule.DestinationApplicationSecurityGroups {
+				if !asgNames[name] {
+					allErrs = append(allErrs, field.NotFound(rulesPath.Index(j).Child("destinationApplicationSecurityGroups").Index(k), name))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+// routesEqual returns true if both lists hold the same routes, in any order.
+func routesEqual(a, b Routes) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	routes := make(map[string]Route, len(a))
+	for _, route := range a {
+		routes[route.Name] = route
+	}
+	for _, route := range b {
+		if existing, ok := routes[route.Name]; !ok || existing != route {
+			return false
+		}
+	}
+	return true
+}
+
+// validateRoutes validates the routes of a RouteTable.
+func validateRoutes(routes Routes, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, route := range routes {
+		if route.AddressPrefix == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("addressPrefix"), "address prefix is required"))
+		}
+		switch {
+		case route.NextHopType == RouteNextHopTypeVirtualAppliance && net.ParseIP(route.NextHopIPAddress) == nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("nextHopIPAddress"), route.NextHopIPAddress,
+				"next hop IP address must be a valid IP address for the VirtualAppliance next hop type"))
+		case route.NextHopType != RouteNextHopTypeVirtualAppliance && route.NextHopIPAddress != "":
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("nextHopIPAddress"),
+				"next hop IP address can only be set for the VirtualAppliance next hop type"))
+		}
+	}
+	return allErrs
+}
+
 func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -606,6 +751,56 @@ func validateClassSpecForControlPlaneOutboundLB(lb *LoadBalancerClassSpec, apise
 	return allErrs
 }
 
+// validateSubnetDelegations validates the delegations of a subnet.
+func validateSubnetDelegations(delegations SubnetDelegations, role SubnetRole, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if role == SubnetControlPlane {
+		return append(allErrs, field.Forbidden(fldPath, "control plane subnets can't be delegated"))
+	}
+	if role == SubnetNode {
+		return append(allErrs, field.Forbidden(fldPath, "node subnets can't be delegated"))
+	}
+
+	names := make(map[string]bool, len(delegations))
+	for i, delegation := range delegations {
+		if success, _ := regexp.MatchString(subnetDelegationRegex, delegation.Name); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), delegation.Name,
+				fmt.Sprintf("name of subnet delegation doesn't match regex %s", subnetDelegationRegex)))
+		}
+		if names[delegation.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), delegation.Name))
+		}
+		names[delegation.Name] = true
+
+		if success, _ := regexp.MatchString(subnetDelegationServiceRegex, delegation.ServiceName); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("serviceName"), delegation.ServiceName,
+				fmt.Sprintf("service name of subnet delegation doesn't match regex %s", subnetDelegationServiceRegex)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateServiceEndpointPolicies validates the service endpoint policies of a subnet.
+func validateServiceEndpointPolicies(policies []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	ids := make(map[string]bool, len(policies))
+	for i, id := range policies {
+		if success, _ := regexp.MatchString(resourceIDPattern, id); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), id,
+				fmt.Sprintf("service endpoint policy ID doesn't match regex %s", resourceIDPattern)))
+		}
+		if ids[id] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), id))
+		}
+		ids[id] = true
+	}
+
+	return allErrs
+}
+
 func validateServiceEndpoints(serviceEndpoints []ServiceEndpointSpec, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -549,6 +549,56 @@ func TestSubnetNamesNotUnique(t *testing.T) {
 	})
 }
 
+func TestSubnetsSharedRouteTable(t *testing.T) {
+	g := NewWithT(t)
+
+	firewallRoute := Route{Name: "to-firewall", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.0.0.4"}
+	internetRoute := Route{Name: "to-internet", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet}
+
+	tests := []struct {
+		name               string
+		controlPlaneRoutes Routes
+		nodeRoutes         Routes
+		wantErr            bool
+	}{
+		{
+			name:               "subnets - shared route table with the same routes",
+			controlPlaneRoutes: Routes{firewallRoute, internetRoute},
+			nodeRoutes:         Routes{internetRoute, firewallRoute},
+			wantErr:            false,
+		},
+		{
+			name:               "subnets - shared route table with different routes",
+			controlPlaneRoutes: Routes{firewallRoute},
+			nodeRoutes:         Routes{internetRoute},
+			wantErr:            true,
+		},
+		{
+			name:               "subnets - shared route table with routes on a single subnet",
+			controlPlaneRoutes: Routes{firewallRoute},
+			nodeRoutes:         nil,
+			wantErr:            true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			subnets := createValidSubnets()
+			subnets[0].RouteTable = RouteTable{Name: "shared-rt", Routes: testCase.controlPlaneRoutes}
+			subnets[1].RouteTable = RouteTable{Name: "shared-rt", Routes: testCase.nodeRoutes}
+			errs := validateSubnets(subnets, createValidVnet(),
+				field.NewPath("spec").Child("networkSpec").Child("subnets"))
+			if testCase.wantErr {
+				g.Expect(errs).To(HaveLen(1))
+				g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets[1].routeTable.routes"))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestSubnetNameValid(t *testing.T) {
 	g := NewWithT(t)
 
@@ -705,6 +755,237 @@ func TestValidateSecurityRule(t *testing.T) {
 	}
 }
 
+func TestValidateRoutes(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		routes  Routes
+		wantErr bool
+	}{
+		{
+			name: "routes - valid",
+			routes: Routes{
+				{Name: "to-firewall", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.0.0.4"},
+				{Name: "to-azure", AddressPrefix: "AzureCloud", NextHopType: RouteNextHopTypeInternet},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "routes - missing address prefix",
+			routes:  Routes{{Name: "to-internet", NextHopType: RouteNextHopTypeInternet}},
+			wantErr: true,
+		},
+		{
+			name:    "routes - invalid next hop IP address for a virtual appliance",
+			routes:  Routes{{Name: "to-firewall", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.0.0"}},
+			wantErr: true,
+		},
+		{
+			name:    "routes - next hop IP address without a virtual appliance",
+			routes:  Routes{{Name: "to-gateway", AddressPrefix: "10.1.0.0/16", NextHopType: RouteNextHopTypeVirtualNetworkGateway, NextHopIPAddress: "10.0.0.4"}},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			errs := validateRoutes(
+				testCase.routes,
+				field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("routeTable").Child("routes"),
+			)
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateFlowLogs(t *testing.T) {
+	g := NewWithT(t)
+
+	storageAccountID := "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs"
+	workspaceResourceID := "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace"
+
+	tests := []struct {
+		name     string
+		flowLogs FlowLogs
+		wantErr  bool
+	}{
+		{
+			name: "flow logs - valid",
+			flowLogs: FlowLogs{
+				StorageAccountID: storageAccountID,
+				RetentionDays:    30,
+				TrafficAnalytics: &TrafficAnalytics{
+					WorkspaceResourceID: workspaceResourceID,
+					WorkspaceID:         "00000000-0000-0000-0000-000000000000",
+					WorkspaceRegion:     "westus2",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "flow logs - valid custom network watcher",
+			flowLogs: FlowLogs{
+				StorageAccountID:            storageAccountID,
+				NetworkWatcherName:          "my-watcher",
+				NetworkWatcherResourceGroup: "my-watcher-rg",
+			},
+			wantErr: false,
+		},
+		{
+			name:     "flow logs - invalid storage account ID",
+			flowLogs: FlowLogs{StorageAccountID: "flowlogs"},
+			wantErr:  true,
+		},
+		{
+			name: "flow logs - network watcher name without resource group",
+			flowLogs: FlowLogs{
+				StorageAccountID:   storageAccountID,
+				NetworkWatcherName: "my-watcher",
+			},
+			wantErr: true,
+		},
+		{
+			name: "flow logs - traffic analytics with invalid workspace ID",
+			flowLogs: FlowLogs{
+				StorageAccountID: storageAccountID,
+				TrafficAnalytics: &TrafficAnalytics{
+					WorkspaceResourceID: workspaceResourceID,
+					WorkspaceID:         "my-workspace",
+					WorkspaceRegion:     "westus2",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "flow logs - traffic analytics without workspace region",
+			flowLogs: FlowLogs{
+				StorageAccountID: storageAccountID,
+				TrafficAnalytics: &TrafficAnalytics{
+					WorkspaceResourceID: workspaceResourceID,
+					WorkspaceID:         "00000000-0000-0000-0000-000000000000",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			errs := validateFlowLogs(
+				&testCase.flowLogs,
+				field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("securityGroup").Child("flowLogs"),
+			)
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateApplicationSecurityGroups(t *testing.T) {
+	g := NewWithT(t)
+
+	withRule := func(rule SecurityRule) Subnets {
+		return Subnets{
+			{
+				SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, Name: "node"},
+				SecurityGroup: SecurityGroup{
+					Name:               "node-nsg",
+					SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{rule}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		networkSpec NetworkSpec
+		wantErr     bool
+	}{
+		{
+			name: "application security groups - valid references",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					ApplicationSecurityGroups: ApplicationSecurityGroups{{Name: "web"}, {Name: "db"}},
+				},
+				Subnets: withRule(SecurityRule{
+					Name:                                 "web_to_db",
+					SourceApplicationSecurityGroups:      []string{"web"},
+					DestinationApplicationSecurityGroups: []string{"db"},
+				}),
+			},
+			wantErr: false,
+		},
+		{
+			name: "application security groups - duplicate name",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					ApplicationSecurityGroups: ApplicationSecurityGroups{{Name: "web"}, {Name: "web"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "application security groups - invalid name",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					ApplicationSecurityGroups: ApplicationSecurityGroups{{Name: "web/frontend"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "application security groups - undeclared reference",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					ApplicationSecurityGroups: ApplicationSecurityGroups{{Name: "web"}},
+				},
+				Subnets: withRule(SecurityRule{
+					Name:                                 "web_to_db",
+					SourceApplicationSecurityGroups:      []string{"web"},
+					DestinationApplicationSecurityGroups: []string{"db"},
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "application security groups - source set together with source address prefix",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					ApplicationSecurityGroups: ApplicationSecurityGroups{{Name: "web"}},
+				},
+				Subnets: withRule(SecurityRule{
+					Name:                            "from_web",
+					Source:                          ptr.To("*"),
+					SourceApplicationSecurityGroups: []string{"web"},
+				}),
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			errs := validateApplicationSecurityGroups(testCase.networkSpec, field.NewPath("spec").Child("networkSpec"))
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateAPIServerLB(t *testing.T) {
 	g := NewWithT(t)
 
@@ -1508,6 +1789,151 @@ func TestValidateServiceEndpoints(t *testing.T) {
 	}
 }
 
+func TestValidateSubnetDelegations(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		delegations SubnetDelegations
+		role        SubnetRole
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid subnet delegation",
+			delegations: SubnetDelegations{{
+				Name:        "aci",
+				ServiceName: "Microsoft.ContainerInstance/containerGroups",
+			}},
+			role:    SubnetBastion,
+			wantErr: false,
+		},
+		{
+			name: "invalid subnet delegation on control plane subnet",
+			delegations: SubnetDelegations{{
+				Name:        "aci",
+				ServiceName: "Microsoft.ContainerInstance/containerGroups",
+			}},
+			role:    SubnetControlPlane,
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "subnets[0].delegations",
+				BadValue: "",
+				Detail:   "control plane subnets can't be delegated",
+			},
+		},
+		{
+			name: "invalid subnet delegation on node subnet",
+			delegations: SubnetDelegations{{
+				Name:        "aci",
+				ServiceName: "Microsoft.ContainerInstance/containerGroups",
+			}},
+			role:    SubnetNode,
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "subnets[0].delegations",
+				BadValue: "",
+				Detail:   "node subnets can't be delegated",
+			},
+		},
+		{
+			name: "invalid subnet delegation service name without resource type",
+			delegations: SubnetDelegations{{
+				Name:        "aci",
+				ServiceName: "Microsoft.ContainerInstance",
+			}},
+			role:    SubnetBastion,
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].delegations[0].serviceName",
+				BadValue: "Microsoft.ContainerInstance",
+				Detail:   "service name of subnet delegation doesn't match regex ^[a-zA-Z][a-zA-Z0-9]*(\\.[a-zA-Z][a-zA-Z0-9]*)+(/[a-zA-Z][a-zA-Z0-9]*)+$",
+			},
+		},
+		{
+			name: "invalid duplicate subnet delegation name",
+			delegations: SubnetDelegations{{
+				Name:        "delegation",
+				ServiceName: "Microsoft.ContainerInstance/containerGroups",
+			}, {
+				Name:        "delegation",
+				ServiceName: "Microsoft.Netapp/volumes",
+			}},
+			role:    SubnetBastion,
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "subnets[0].delegations[1].name",
+				BadValue: "delegation",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateSubnetDelegations(testCase.delegations, testCase.role, field.NewPath("subnets[0].delegations"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateServiceEndpointPolicies(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		policies    []string
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:     "valid service endpoint policy",
+			policies: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/my-policy"},
+			wantErr:  false,
+		},
+		{
+			name:     "invalid service endpoint policy ID",
+			policies: []string{"my-policy"},
+			wantErr:  true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].serviceEndpointPolicies[0]",
+				BadValue: "my-policy",
+				Detail:   "service endpoint policy ID doesn't match regex (?i)subscriptions/(.+)/resourceGroups/(.+)/providers/(.+?)/(.+?)/(.+)",
+			},
+		},
+		{
+			name: "invalid duplicate service endpoint policy ID",
+			policies: []string{
+				"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/my-policy",
+				"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/my-policy",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "subnets[0].serviceEndpointPolicies[1]",
+				BadValue: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/my-policy",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateServiceEndpointPolicies(testCase.policies, field.NewPath("subnets[0].serviceEndpointPolicies"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestServiceEndpointsLackRequiredFieldService(t *testing.T) {
 	g := NewWithT(t)
 
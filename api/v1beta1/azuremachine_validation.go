@@ -17,14 +17,18 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
+	"regexp"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/ssh"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ValidateAzureMachineSpec checks an AzureMachineSpec and returns any validation errors.
@@ -80,15 +84,103 @@ func ValidateNetwork(subnetName string, acceleratedNetworking *bool, networkInte
 		return field.ErrorList{field.Invalid(fldPath, networkInterfaces, "cannot set both networkInterfaces and machine acceleratedNetworking")}
 	}
 
-	for _, nic := range networkInterfaces {
+	for i, nic := range networkInterfaces {
 		if nic.PrivateIPConfigs < 1 {
 			return field.ErrorList{field.Invalid(fldPath, networkInterfaces, "number of privateIPConfigs per interface must be at least 1")}
 		}
+		if errs := ValidateNetworkInterfaceSecurityGroup(nic.SecurityGroup, fldPath.Index(i).Child("securityGroup")); len(errs) > 0 {
+			return errs
+		}
 	}
 
 	return field.ErrorList{}
 }
 
+// ValidateNetworkInterfaceApplicationSecurityGroups checks that the application security groups joined by the network
+// interfaces are declared on the AzureCluster of the cluster with the given name. It is skipped when the cluster is not
+// found or its infrastructure is not an AzureCluster.
+func ValidateNetworkInterfaceApplicationSecurityGroups(ctx context.Context, cli client.Client, namespace, clusterName string, nics []NetworkInterface, fldPath *field.Path) field.ErrorList {
+	hasASGs := false
+	for _, nic := range nics {
+		hasASGs = hasASGs || len(nic.ApplicationSecurityGroups) > 0
+	}
+	if !hasASGs || cli == nil || clusterName == "" {
+		return nil
+	}
+
+	ownerCluster := &clusterv1.Cluster{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, ownerCluster); err != nil {
+		if err := client.IgnoreNotFound(err); err != nil {
+			return field.ErrorList{field.InternalError(fldPath, err)}
+		}
+		return nil
+	}
+	infraRef := ownerCluster.Spec.InfrastructureRef
+	if infraRef == nil || infraRef.Kind != "AzureCluster" {
+		return nil
+	}
+	azureCluster := &AzureCluster{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: ownerCluster.Namespace, Name: infraRef.Name}, azureCluster); err != nil {
+		if err := client.IgnoreNotFound(err); err != nil {
+			return field.ErrorList{field.InternalError(fldPath, err)}
+		}
+		return nil
+	}
+
+	declared := make(map[string]bool, len(azureCluster.Spec.NetworkSpec.ApplicationSecurityGroups))
+	for _, asg := range azureCluster.Spec.NetworkSpec.ApplicationSecurityGroups {
+		declared[asg.Name] = true
+	}
+	var allErrs field.ErrorList
+	for i, nic := range nics {
+		for j, name := range nic.ApplicationSecurityGroups {
+			if !declared[name] {
+				allErrs = append(allErrs, field.NotFound(fldPath.Index(i).Child("applicationSecurityGroups").Index(j), name))
+			}
+		}
+	}
+	return allErrs
+}
+
+// ValidateNetworkInterfaceSecurityGroup validates the security group of a network interface.
+func ValidateNetworkInterfaceSecurityGroup(securityGroup *NetworkInterfaceSecurityGroup, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if securityGroup == nil {
+		return allErrs
+	}
+
+	if securityGroup.ID != "" && len(securityGroup.SecurityRules) > 0 {
+		return append(allErrs, field.Forbidden(fldPath, "id and securityRules are mutually exclusive"))
+	}
+	if securityGroup.ID == "" && len(securityGroup.SecurityRules) == 0 {
+		return append(allErrs, field.Required(fldPath, "one of id or securityRules must be set"))
+	}
+
+	if securityGroup.ID != "" {
+		if success, _ := regexp.MatchString(resourceIDPattern, securityGroup.ID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), securityGroup.ID,
+				fmt.Sprintf("security group ID doesn't match regex %s", resourceIDPattern)))
+		}
+	}
+
+	rulesPath := fldPath.Child("securityRules")
+	for i, rule := range securityGroup.SecurityRules {
+		if err := validateSecurityRule(rule, rulesPath.Index(i)); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if rule.Source != nil && len(rule.SourceApplicationSecurityGroups) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulesPath.Index(i).Child("sourceApplicationSecurityGroups"),
+				"source and sourceApplicationSecurityGroups are mutually exclusive"))
+		}
+		if rule.Destination != nil && len(rule.DestinationApplicationSecurityGroups) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulesPath.Index(i).Child("destinationApplicationSecurityGroups"),
+				"destination and destinationApplicationSecurityGroups are mutually exclusive"))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateSSHKey validates an SSHKey.
 func ValidateSSHKey(sshKey string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
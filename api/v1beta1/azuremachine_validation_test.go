@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/base64"
@@ -27,8 +28,13 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAzureMachine_ValidateSSHKey(t *testing.T) {
@@ -938,6 +944,104 @@ func TestAzureMachine_ValidateNetwork(t *testing.T) {
 	}
 }
 
+func TestValidateNetworkInterfaceSecurityGroup(t *testing.T) {
+	g := NewWithT(t)
+
+	validRule := SecurityRule{
+		Name:             "allow_ssh",
+		Protocol:         SecurityGroupProtocolTCP,
+		Direction:        SecurityRuleDirectionInbound,
+		Priority:         2200,
+		SourcePorts:      ptr.To("*"),
+		DestinationPorts: ptr.To("22"),
+		Source:           ptr.To("*"),
+		Destination:      ptr.To("*"),
+	}
+
+	tests := []struct {
+		name          string
+		securityGroup *NetworkInterfaceSecurityGroup
+		wantErr       bool
+	}{
+		{
+			name:          "no security group",
+			securityGroup: nil,
+			wantErr:       false,
+		},
+		{
+			name: "valid security group ID",
+			securityGroup: &NetworkInterfaceSecurityGroup{
+				ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-nsg",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid security rules",
+			securityGroup: &NetworkInterfaceSecurityGroup{
+				SecurityRules: SecurityRules{validRule},
+			},
+			wantErr: false,
+		},
+		{
+			name:          "neither ID nor security rules",
+			securityGroup: &NetworkInterfaceSecurityGroup{},
+			wantErr:       true,
+		},
+		{
+			name: "both ID and security rules",
+			securityGroup: &NetworkInterfaceSecurityGroup{
+				ID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-nsg",
+				SecurityRules: SecurityRules{validRule},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid security group ID",
+			securityGroup: &NetworkInterfaceSecurityGroup{
+				ID: "my-nsg",
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule with invalid priority",
+			securityGroup: &NetworkInterfaceSecurityGroup{
+				SecurityRules: SecurityRules{
+					func() SecurityRule {
+						rule := validRule
+						rule.Priority = 50
+						return rule
+					}(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule with both source and source application security groups",
+			securityGroup: &NetworkInterfaceSecurityGroup{
+				SecurityRules: SecurityRules{
+					func() SecurityRule {
+						rule := validRule
+						rule.SourceApplicationSecurityGroups = []string{"web"}
+						return rule
+					}(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateNetworkInterfaceSecurityGroup(test.securityGroup, field.NewPath("securityGroup"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestAzureMachine_ValidateConfidentialCompute(t *testing.T) {
 	g := NewWithT(t)
 
@@ -1120,3 +1224,102 @@ func TestAzureMachine_ValidateConfidentialCompute(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNetworkInterfaceApplicationSecurityGroups(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				Kind: "AzureCluster",
+				Name: "test-azure-cluster",
+			},
+		},
+	}
+	azureCluster := &AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-azure-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: AzureClusterSpec{
+			NetworkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					ApplicationSecurityGroups: []ApplicationSecurityGroup{
+						{Name: "web"},
+						{Name: "db"},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		clusterName string
+		objects     []runtime.Object
+		nics        []NetworkInterface
+		wantErr     bool
+	}{
+		{
+			name:        "network interfaces without application security groups",
+			clusterName: "test-cluster",
+			nics:        []NetworkInterface{{SubnetName: "subnet"}},
+			wantErr:     false,
+		},
+		{
+			name:        "application security groups declared on the cluster",
+			clusterName: "test-cluster",
+			objects:     []runtime.Object{cluster, azureCluster},
+			nics: []NetworkInterface{
+				{SubnetName: "subnet", ApplicationSecurityGroups: []string{"web"}},
+				{SubnetName: "subnet2", ApplicationSecurityGroups: []string{"web", "db"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "application security group not declared on the cluster",
+			clusterName: "test-cluster",
+			objects:     []runtime.Object{cluster, azureCluster},
+			nics: []NetworkInterface{
+				{SubnetName: "subnet", ApplicationSecurityGroups: []string{"web", "cache"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:        "machine without a cluster name",
+			clusterName: "",
+			objects:     []runtime.Object{cluster, azureCluster},
+			nics: []NetworkInterface{
+				{SubnetName: "subnet", ApplicationSecurityGroups: []string{"cache"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "cluster not found",
+			clusterName: "test-cluster",
+			nics: []NetworkInterface{
+				{SubnetName: "subnet", ApplicationSecurityGroups: []string{"cache"}},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.objects...).Build()
+			errs := ValidateNetworkInterfaceApplicationSecurityGroups(context.Background(), fakeClient, metav1.NamespaceDefault,
+				tc.clusterName, tc.nics, field.NewPath("spec", "networkInterfaces"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
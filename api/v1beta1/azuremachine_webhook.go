@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -66,6 +67,11 @@ func (mw *azureMachineWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateNetworkInterfaceApplicationSecurityGroups(ctx, mw.Client, m.Namespace, m.Labels[clusterv1.ClusterNameLabel],
+		spec.NetworkInterfaces, field.NewPath("spec", "networkInterfaces")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
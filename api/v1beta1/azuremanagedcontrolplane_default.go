@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/ssh"
 	"k8s.io/utils/ptr"
@@ -36,6 +37,39 @@ const (
 	defaultAKSNodeSubnetCIDRForOverlay = "10.224.0.0/16"
 )
 
+// setDefaultNetworkPlugin sets the default NetworkPlugin for an AzureManagedControlPlane.
+func (m *AzureManagedControlPlane) setDefaultNetworkPlugin() {
+	if m.Spec.NetworkPlugin == nil {
+		networkPlugin := "azure"
+		m.Spec.NetworkPlugin = &networkPlugin
+	}
+}
+
+// setDefaultLoadBalancerSKU sets the default LoadBalancerSKU for an AzureManagedControlPlane.
+func (m *AzureManagedControlPlane) setDefaultLoadBalancerSKU() {
+	if m.Spec.LoadBalancerSKU == nil {
+		loadBalancerSKU := "Standard"
+		m.Spec.LoadBalancerSKU = &loadBalancerSKU
+	}
+}
+
+// setDefaultVersion normalizes the Version of an AzureManagedControlPlane to start with "v".
+func (m *AzureManagedControlPlane) setDefaultVersion() {
+	if m.Spec.Version != "" && !strings.HasPrefix(m.Spec.Version, "v") {
+		normalizedVersion := "v" + m.Spec.Version
+		m.Spec.Version = normalizedVersion
+	}
+}
+
+// setDefaultIdentity sets the default Identity for an AzureManagedControlPlane.
+func (m *AzureManagedControlPlane) setDefaultIdentity() {
+	if m.Spec.Identity == nil {
+		m.Spec.Identity = &Identity{
+			Type: ManagedControlPlaneIdentityTypeSystemAssigned,
+		}
+	}
+}
+
 // setDefaultSSHPublicKey sets the default SSHPublicKey for an AzureManagedControlPlane.
 func (m *AzureManagedControlPlane) setDefaultSSHPublicKey() error {
 	if sshKey := m.Spec.SSHPublicKey; sshKey != nil && *sshKey == "" {
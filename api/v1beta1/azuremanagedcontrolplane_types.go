@@ -74,6 +74,30 @@ const (
 	NetworkPluginModeOverlay NetworkPluginMode = "overlay"
 )
 
+// NetworkDataplaneType is the network dataplane used by the AKS cluster.
+type NetworkDataplaneType string
+
+const (
+	// NetworkDataplaneTypeAzure uses the Azure network dataplane.
+	NetworkDataplaneTypeAzure NetworkDataplaneType = "azure"
+	// NetworkDataplaneTypeCilium uses the Cilium network dataplane.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://aka.ms/aks/azure-cni-powered-by-cilium
+	NetworkDataplaneTypeCilium NetworkDataplaneType = "cilium"
+)
+
+// IPFamily is an IP family used by the AKS cluster.
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type IPFamily string
+
+const (
+	// IPFamilyIPv4 is the IPv4 IP family.
+	IPFamilyIPv4 IPFamily = "IPv4"
+	// IPFamilyIPv6 is the IPv6 IP family.
+	IPFamilyIPv6 IPFamily = "IPv6"
+)
+
 // AzureManagedControlPlaneSpec defines the desired state of AzureManagedControlPlane.
 type AzureManagedControlPlaneSpec struct {
 	// Version defines the desired Kubernetes version.
@@ -129,12 +153,43 @@ type AzureManagedControlPlaneSpec struct {
 	NetworkPluginMode *NetworkPluginMode `json:"networkPluginMode,omitempty"`
 
 	// NetworkPolicy used for building Kubernetes network.
-	// Allowed values are "azure", "calico".
+	// Allowed values are "azure", "calico", "cilium".
+	// "cilium" requires NetworkDataplane to be "cilium".
 	// Immutable.
-	// +kubebuilder:validation:Enum=azure;calico
+	// +kubebuilder:validation:Enum=azure;calico;cilium
 	// +optional
 	NetworkPolicy *string `json:"networkPolicy,omitempty"`
 
+	// NetworkDataplane is the dataplane used for building the Kubernetes network.
+	// Allowed values are "azure", "cilium".
+	// "cilium" requires the "azure" NetworkPlugin.
+	// Immutable.
+	// +kubebuilder:validation:Enum=azure;cilium
+	// +optional
+	NetworkDataplane *NetworkDataplaneType `json:"networkDataplane,omitempty"`
+
+	// PodCIDR is the CIDR block from which pod IPs are assigned when using kubenet or the "overlay" NetworkPluginMode.
+	// Takes precedence over the pod CIDR block of the Cluster's clusterNetwork, and must not overlap with the
+	// virtual network CIDR block.
+	// Immutable.
+	// +optional
+	PodCIDR *string `json:"podCIDR,omitempty"`
+
+	// ServiceCIDR is the CIDR block from which Kubernetes service cluster IPs are assigned.
+	// Takes precedence over the service CIDR block of the Cluster's clusterNetwork, and must not overlap with the
+	// virtual network CIDR block.
+	// Immutable.
+	// +optional
+	ServiceCIDR *string `json:"serviceCIDR,omitempty"`
+
+	// IPFamilies are the IP families used by the cluster. Use ["IPv4"] for single-stack or ["IPv4", "IPv6"]
+	// for dual-stack networking. Defaults to single-stack IPv4 when not set.
+	// Immutable.
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=set
+	// +optional
+	IPFamilies []IPFamily `json:"ipFamilies,omitempty"`
+
 	// Outbound configuration used by Nodes.
 	// Immutable.
 	// +kubebuilder:validation:Enum=loadBalancer;managedNATGateway;userAssignedNATGateway;userDefinedRouting
@@ -167,6 +222,12 @@ type AzureManagedControlPlaneSpec struct {
 	// +optional
 	AADProfile *AADProfile `json:"aadProfile,omitempty"`
 
+	// DisableLocalAccounts disables the static local accounts of the cluster when set to true, so the cluster can
+	// only be accessed with AAD credentials. It requires AADProfile to be set.
+	// The kubeconfig secret of the cluster then authenticates with a token of the cluster identity.
+	// +optional
+	DisableLocalAccounts *bool `json:"disableLocalAccounts,omitempty"`
+
 	// AddonProfiles are the profiles of managed cluster add-on.
 	// +optional
 	AddonProfiles []AddonProfile `json:"addonProfiles,omitempty"`
@@ -213,6 +274,62 @@ type AzureManagedControlPlaneSpec struct {
 	// OIDCIssuerProfile is the OIDC issuer profile of the Managed Cluster.
 	// +optional
 	OIDCIssuerProfile *OIDCIssuerProfile `json:"oidcIssuerProfile,omitempty"`
+
+	// SecurityProfile defines the security profile of the Managed Cluster.
+	// +optional
+	SecurityProfile *ManagedClusterSecurityProfile `json:"securityProfile,omitempty"`
+
+	// Monitoring configures the Azure Monitor integration of the Managed Cluster.
+	// +optional
+	Monitoring *ManagedClusterMonitoring `json:"monitoring,omitempty"`
+
+	// PowerState is the desired power state of the Managed Cluster. A "Stopped" cluster is deallocated and its
+	// agent pools are not reconciled until it is "Running" again.
+	// When not set, the power state of the Managed Cluster is left unchanged, so it can be stopped and started
+	// outside of CAPZ.
+	// Allowed values are "Running", "Stopped".
+	// +kubebuilder:validation:Enum=Running;Stopped
+	// +optional
+	PowerState *PowerState `json:"powerState,omitempty"`
+
+	// MaintenanceConfigurations are the planned maintenance windows of the Managed Cluster.
+	// A maintenance configuration which is removed from this list is deleted from the Managed Cluster.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	MaintenanceConfigurations []MaintenanceConfiguration `json:"maintenanceConfigurations,omitempty"`
+
+	// AutoUpgradeProfile defines the auto-upgrade configuration of the Managed Cluster.
+	// Channels which are not set are left unchanged on the Managed Cluster.
+	// +optional
+	AutoUpgradeProfile *ManagedClusterAutoUpgradeProfile `json:"autoUpgradeProfile,omitempty"`
+
+	// Extensions are the cluster extensions, such as Flux or Dapr, installed on the Managed Cluster.
+	// An extension which is removed from this list is uninstalled from the Managed Cluster.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []AKSExtension `json:"extensions,omitempty"`
+
+	// AdoptionMode controls how an AKS cluster which already exists in Azure is taken over by CAPZ.
+	// "None" updates the existing cluster to match this spec.
+	// "Adopt" imports the existing cluster and its agent pools: AzureManagedMachinePools and MachinePools are
+	// generated from the live agent pools, and neither the cluster nor its agent pools are updated until their
+	// spec matches the live state. See the Adopted condition for the progress of the adoption.
+	// When not set, it defaults to "None". Immutable.
+	// +kubebuilder:validation:Enum=None;Adopt
+	// +optional
+	AdoptionMode *AdoptionMode `json:"adoptionMode,omitempty"`
+
+	// DriftMode controls what happens when the AKS cluster or one of its agent pools differs from its spec, for
+	// example after it was changed outside of CAPZ.
+	// "Correct" updates the cluster and its agent pools to match their spec.
+	// "Report" leaves them as they are. In both modes, the fields which differ are listed by the DriftDetected
+	// condition of the AzureManagedControlPlane and AzureManagedMachinePools.
+	// When not set, it defaults to "Correct".
+	// +kubebuilder:validation:Enum=Correct;Report
+	// +optional
+	DriftMode *DriftMode `json:"driftMode,omitempty"`
 }
 
 // HTTPProxyConfig is the HTTP proxy configuration for the cluster.
@@ -332,11 +449,23 @@ type ManagedControlPlaneVirtualNetwork struct {
 	// Immutable except for `serviceEndpoints`.
 	// +optional
 	Subnet ManagedControlPlaneSubnet `json:"subnet,omitempty"`
+	// PodSubnets are additional subnets of the VNet from which Azure CNI dynamically allocates pod IPs to the
+	// agent pools which reference them in `podSubnetName`. They are created along with a managed VNet, and
+	// require NetworkPlugin `azure` without the `overlay` NetworkPluginMode.
+	// The CIDRBlock of a pod subnet is immutable.
+	// +optional
+	PodSubnets []ManagedControlPlanePodSubnet `json:"podSubnets,omitempty"`
 	// ResourceGroup is the name of the Azure resource group for the VNet and Subnet.
 	// +optional
 	ResourceGroup string `json:"resourceGroup,omitempty"`
 }
 
+// ManagedControlPlanePodSubnet describes a subnet from which pod IPs are allocated to agent pools.
+type ManagedControlPlanePodSubnet struct {
+	Name      string `json:"name"`
+	CIDRBlock string `json:"cidrBlock"`
+}
+
 // ManagedControlPlaneSubnet describes a subnet for an AKS cluster.
 type ManagedControlPlaneSubnet struct {
 	Name      string `json:"name"`
@@ -375,6 +504,25 @@ type AzureManagedControlPlaneStatus struct {
 	// OIDCIssuerProfile is the OIDC issuer profile of the Managed Cluster.
 	// +optional
 	OIDCIssuerProfile *OIDCIssuerProfileStatus `json:"oidcIssuerProfile,omitempty"`
+
+	// PowerState is the power state of the Managed Cluster as reported by AKS.
+	// +optional
+	PowerState PowerState `json:"powerState,omitempty"`
+
+	// AutoUpgradeVersion is the Kubernetes version the Managed Cluster was upgraded to by AKS.
+	// It is only set while it is newer than the version in the spec.
+	// +optional
+	AutoUpgradeVersion string `json:"autoUpgradeVersion,omitempty"`
+
+	// Extensions reports the provisioning state of the cluster extensions of the Managed Cluster.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []AKSExtensionStatus `json:"extensions,omitempty"`
+
+	// Monitoring reports the Azure Monitor integration of the Managed Cluster as reported by AKS.
+	// +optional
+	Monitoring *ManagedClusterMonitoringStatus `json:"monitoring,omitempty"`
 }
 
 // OIDCIssuerProfileStatus is the OIDC issuer profile of the Managed Cluster.
@@ -528,6 +676,506 @@ type OIDCIssuerProfile struct {
 	Enabled *bool `json:"enabled,omitempty"`
 }
 
+// ManagedClusterSecurityProfile defines the security profile of the Managed Cluster.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/en-us/azure/aks/concepts-security
+type ManagedClusterSecurityProfile struct {
+	// AzureKeyVaultKms configures the Key Management Service plugin, which encrypts Kubernetes secrets with a key
+	// from Azure Key Vault.
+	// Once set, it cannot be removed. Set `enabled` to false to disable it.
+	// +optional
+	AzureKeyVaultKms *AzureKeyVaultKms `json:"azureKeyVaultKms,omitempty"`
+
+	// Defender configures Microsoft Defender for Containers.
+	// Once set, it cannot be removed. Set `securityMonitoring.enabled` to false to disable it.
+	// +optional
+	Defender *ManagedClusterSecurityProfileDefender `json:"defender,omitempty"`
+
+	// ImageCleaner configures the Image Cleaner, which removes unused and vulnerable images from the nodes.
+	// Once set, it cannot be removed. Set `enabled` to false to disable it.
+	// +optional
+	ImageCleaner *ManagedClusterSecurityProfileImageCleaner `json:"imageCleaner,omitempty"`
+
+	// WorkloadIdentity configures Microsoft Entra Workload ID, which lets applications use Azure AD identities.
+	// Requires the OIDC issuer to be enabled.
+	// Once set, it cannot be removed. Set `enabled` to false to disable it.
+	// +optional
+	WorkloadIdentity *ManagedClusterSecurityProfileWorkloadIdentity `json:"workloadIdentity,omitempty"`
+}
+
+// KeyVaultNetworkAccessTypes is the network access of the Azure Key Vault used for KMS.
+type KeyVaultNetworkAccessTypes string
+
+const (
+	// KeyVaultNetworkAccessTypesPrivate means the key vault disables public access and enables private link.
+	KeyVaultNetworkAccessTypesPrivate KeyVaultNetworkAccessTypes = "Private"
+	// KeyVaultNetworkAccessTypesPublic means the key vault allows public access from all networks.
+	KeyVaultNetworkAccessTypesPublic KeyVaultNetworkAccessTypes = "Public"
+)
+
+// AzureKeyVaultKms is the Azure Key Vault Key Management Service configuration of the Managed Cluster.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/en-us/azure/aks/use-kms-etcd-encryption
+type AzureKeyVaultKms struct {
+	// Enabled is whether the Azure Key Vault Key Management Service is enabled.
+	Enabled bool `json:"enabled"`
+
+	// KeyID is the identifier of the Azure Key Vault key, including its version.
+	// Required when `enabled` is true.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// KeyVaultNetworkAccess is the network access of the key vault.
+	// Allowed values are "Public", "Private". Defaults to "Public".
+	// +kubebuilder:validation:Enum=Public;Private
+	// +optional
+	KeyVaultNetworkAccess *KeyVaultNetworkAccessTypes `json:"keyVaultNetworkAccess,omitempty"`
+
+	// KeyVaultResourceID is the resource ID of the key vault.
+	// Required when `keyVaultNetworkAccess` is "Private", and must not be set when it is "Public".
+	// +optional
+	KeyVaultResourceID *string `json:"keyVaultResourceID,omitempty"`
+}
+
+// ManagedClusterSecurityProfileDefender is the Microsoft Defender for Containers configuration of the Managed Cluster.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/en-us/azure/defender-for-cloud/defender-for-containers-introduction
+type ManagedClusterSecurityProfileDefender struct {
+	// LogAnalyticsWorkspaceResourceID is the resource ID of the Log Analytics workspace Defender sends its data to.
+	// Required when `securityMonitoring.enabled` is true.
+	// +optional
+	LogAnalyticsWorkspaceResourceID string `json:"logAnalyticsWorkspaceResourceID,omitempty"`
+
+	// SecurityMonitoring configures the Defender threat detection.
+	SecurityMonitoring ManagedClusterSecurityProfileDefenderSecurityMonitoring `json:"securityMonitoring"`
+}
+
+// ManagedClusterSecurityProfileDefenderSecurityMonitoring is the Defender threat detection configuration.
+type ManagedClusterSecurityProfileDefenderSecurityMonitoring struct {
+	// Enabled is whether Defender threat detection is enabled.
+	Enabled bool `json:"enabled"`
+}
+
+// ManagedClusterSecurityProfileImageCleaner is the Image Cleaner configuration of the Managed Cluster.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/en-us/azure/aks/image-cleaner
+type ManagedClusterSecurityProfileImageCleaner struct {
+	// Enabled is whether the Image Cleaner is enabled.
+	Enabled bool `json:"enabled"`
+
+	// IntervalHours is the interval in hours between Image Cleaner runs. AKS defaults it to 168 hours (7 days).
+	// +kubebuilder:validation:Minimum=24
+	// +kubebuilder:validation:Maximum=2160
+	// +optional
+	IntervalHours *int32 `json:"intervalHours,omitempty"`
+}
+
+// ManagedClusterSecurityProfileWorkloadIdentity is the workload identity configuration of the Managed Cluster.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/en-us/azure/aks/workload-identity-overview
+type ManagedClusterSecurityProfileWorkloadIdentity struct {
+	// Enabled is whether workload identity is enabled.
+	Enabled bool `json:"enabled"`
+}
+
+// ManagedClusterMonitoring configures the Azure Monitor integration of the Managed Cluster.
+// Settings which are not set are left unchanged on the Managed Cluster.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/azure/azure-monitor/containers/container-insights-overview
+type ManagedClusterMonitoring struct {
+	// LogAnalyticsWorkspaceResourceID is the resource ID of the Log Analytics workspace Container Insights sends
+	// logs to. Setting it enables the omsagent add-on, which must then not be set in `addonProfiles`.
+	// +optional
+	LogAnalyticsWorkspaceResourceID *string `json:"logAnalyticsWorkspaceResourceID,omitempty"`
+
+	// ManagedPrometheus configures Azure Monitor managed service for Prometheus.
+	// +optional
+	ManagedPrometheus *ManagedPrometheus `json:"managedPrometheus,omitempty"`
+}
+
+// ManagedPrometheus configures Azure Monitor managed service for Prometheus, which collects Prometheus metrics of
+// the Managed Cluster into an Azure Monitor workspace.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/azure/azure-monitor/essentials/prometheus-metrics-enable
+type ManagedPrometheus struct {
+	// Enabled is whether Prometheus metrics are collected.
+	Enabled bool `json:"enabled"`
+
+	// MetricLabelsAllowlist is a comma-separated list of additional Kubernetes label keys used in the labels
+	// metrics of kube-state-metrics, for example "namespaces=[k8s-label-1,k8s-label-n],pods=[app]".
+	// +optional
+	MetricLabelsAllowlist *string `json:"metricLabelsAllowlist,omitempty"`
+
+	// MetricAnnotationsAllowList is a comma-separated list of Kubernetes annotation keys used in the labels
+	// metrics of kube-state-metrics, for example "namespaces=[kubernetes.io/team],pods=[kubernetes.io/team]".
+	// +optional
+	MetricAnnotationsAllowList *string `json:"metricAnnotationsAllowList,omitempty"`
+}
+
+// ManagedClusterMonitoringStatus is the Azure Monitor integration of the Managed Cluster as reported by AKS.
+type ManagedClusterMonitoringStatus struct {
+	// LogAnalyticsWorkspaceResourceID is the resource ID of the Log Analytics workspace the omsagent add-on sends
+	// logs to. It is empty when the add-on is disabled.
+	// +optional
+	LogAnalyticsWorkspaceResourceID string `json:"logAnalyticsWorkspaceResourceID,omitempty"`
+
+	// ManagedPrometheusEnabled is whether Prometheus metrics are collected by Azure Monitor.
+	// +optional
+	ManagedPrometheusEnabled bool `json:"managedPrometheusEnabled,omitempty"`
+}
+
+// PowerState is the power state of an AKS cluster.
+type PowerState string
+
+const (
+	// PowerStateRunning means the cluster is running.
+	PowerStateRunning PowerState = "Running"
+	// PowerStateStopped means the cluster is stopped and its resources are deallocated.
+	PowerStateStopped PowerState = "Stopped"
+)
+
+// AdoptionMode is how an existing AKS cluster is taken over by CAPZ.
+type AdoptionMode string
+
+const (
+	// AdoptionModeNone updates an existing cluster to match the spec.
+	AdoptionModeNone AdoptionMode = "None"
+	// AdoptionModeAdopt imports an existing cluster and its agent pools without updating them until their spec matches.
+	AdoptionModeAdopt AdoptionMode = "Adopt"
+)
+
+// DriftMode is what CAPZ does when an AKS cluster or agent pool differs from its spec.
+type DriftMode string
+
+const (
+	// DriftModeCorrect updates the cluster and its agent pools to match their spec.
+	DriftModeCorrect DriftMode = "Correct"
+	// DriftModeReport only reports the fields of the cluster and its agent pools which differ from their spec.
+	DriftModeReport DriftMode = "Report"
+)
+
+// UpgradeChannel is the auto-upgrade channel of an AKS cluster.
+type UpgradeChannel string
+
+const (
+	// UpgradeChannelNodeImage automatically upgrades the node image to the latest version available.
+	UpgradeChannelNodeImage UpgradeChannel = "node-image"
+	// UpgradeChannelNone disables auto-upgrades.
+	UpgradeChannelNone UpgradeChannel = "none"
+	// UpgradeChannelPatch automatically upgrades the cluster to the latest supported patch version of its minor version.
+	UpgradeChannelPatch UpgradeChannel = "patch"
+	// UpgradeChannelRapid automatically upgrades the cluster to the latest supported patch release on the latest
+	// supported minor version.
+	UpgradeChannelRapid UpgradeChannel = "rapid"
+	// UpgradeChannelStable automatically upgrades the cluster to the latest supported patch release on minor
+	// version N-1, where N is the latest supported minor version.
+	UpgradeChannelStable UpgradeChannel = "stable"
+)
+
+// NodeOSUpgradeChannel is the node OS auto-upgrade channel of an AKS cluster.
+type NodeOSUpgradeChannel string
+
+const (
+	// NodeOSUpgradeChannelNodeImage automatically upgrades the node image to the latest version available.
+	NodeOSUpgradeChannelNodeImage NodeOSUpgradeChannel = "NodeImage"
+	// NodeOSUpgradeChannelNone leaves the node OS unchanged.
+	NodeOSUpgradeChannelNone NodeOSUpgradeChannel = "None"
+	// NodeOSUpgradeChannelUnmanaged leaves OS updates to the OS built-in patching infrastructure.
+	NodeOSUpgradeChannelUnmanaged NodeOSUpgradeChannel = "Unmanaged"
+)
+
+// ManagedClusterAutoUpgradeProfile defines the auto-upgrade configuration of an AKS cluster.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/azure/aks/auto-upgrade-cluster
+type ManagedClusterAutoUpgradeProfile struct {
+	// UpgradeChannel is the channel used to automatically upgrade the Kubernetes version of the cluster.
+	// When AKS upgrades the cluster to a newer version than the one in the spec, CAPZ does not attempt to downgrade it.
+	// +kubebuilder:validation:Enum=node-image;none;patch;rapid;stable
+	// +optional
+	UpgradeChannel *UpgradeChannel `json:"upgradeChannel,omitempty"`
+
+	// NodeOSUpgradeChannel is the manner in which the OS on the nodes is updated.
+	// +kubebuilder:validation:Enum=NodeImage;None;Unmanaged
+	// +optional
+	NodeOSUpgradeChannel *NodeOSUpgradeChannel `json:"nodeOSUpgradeChannel,omitempty"`
+}
+
+// MaintenanceConfigurationName is the name of an AKS maintenance configuration.
+type MaintenanceConfigurationName string
+
+const (
+	// MaintenanceConfigurationNameDefault is the maintenance configuration used for AKS weekly releases.
+	MaintenanceConfigurationNameDefault MaintenanceConfigurationName = "default"
+	// MaintenanceConfigurationNameAutoUpgrade is the maintenance configuration used for cluster auto-upgrades.
+	MaintenanceConfigurationNameAutoUpgrade MaintenanceConfigurationName = "aksManagedAutoUpgradeSchedule"
+	// MaintenanceConfigurationNameNodeOSUpgrade is the maintenance configuration used for node OS auto-upgrades.
+	MaintenanceConfigurationNameNodeOSUpgrade MaintenanceConfigurationName = "aksManagedNodeOSUpgradeSchedule"
+)
+
+// MaintenanceConfiguration is a planned maintenance window of an AKS cluster.
+// The "default" configuration is described with TimeInWeek and NotAllowedTime, the
+// "aksManagedAutoUpgradeSchedule" and "aksManagedNodeOSUpgradeSchedule" configurations with MaintenanceWindow.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/azure/aks/planned-maintenance
+type MaintenanceConfiguration struct {
+	// Name is the name of the maintenance configuration.
+	// +kubebuilder:validation:Enum=default;aksManagedAutoUpgradeSchedule;aksManagedNodeOSUpgradeSchedule
+	Name MaintenanceConfigurationName `json:"name"`
+
+	// TimeInWeek are the days and hours of the week during which maintenance is allowed.
+	// Only valid for the "default" maintenance configuration.
+	// +optional
+	TimeInWeek []TimeInWeek `json:"timeInWeek,omitempty"`
+
+	// NotAllowedTime are time spans during which maintenance is not allowed.
+	// Only valid for the "default" maintenance configuration.
+	// +optional
+	NotAllowedTime []TimeSpan `json:"notAllowedTime,omitempty"`
+
+	// MaintenanceWindow is the recurring maintenance window.
+	// Required for the "aksManagedAutoUpgradeSchedule" and "aksManagedNodeOSUpgradeSchedule" maintenance configurations.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// WeekDay is a day of the week.
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type WeekDay string
+
+// TimeInWeek is a day of the week and the hours of that day during which maintenance is allowed.
+type TimeInWeek struct {
+	// Day is the day of the week.
+	Day WeekDay `json:"day"`
+
+	// HourSlots are the hours of the day during which maintenance is allowed, in UTC. Each hour slot starts at
+	// the beginning of the hour and ends at the next hour, e.g. [0, 1] means the 00:00 - 02:00 UTC time range.
+	// +optional
+	HourSlots []int32 `json:"hourSlots,omitempty"`
+}
+
+// TimeSpan is a span of time.
+type TimeSpan struct {
+	// Start is the beginning of the time span.
+	Start metav1.Time `json:"start"`
+
+	// End is the end of the time span.
+	End metav1.Time `json:"end"`
+}
+
+// DateSpan is a span of dates.
+type DateSpan struct {
+	// Start is the first date of the date span, in the format YYYY-MM-DD.
+	// +kubebuilder:validation:Format=date
+	Start string `json:"start"`
+
+	// End is the last date of the date span, in the format YYYY-MM-DD.
+	// +kubebuilder:validation:Format=date
+	End string `json:"end"`
+}
+
+// MaintenanceWindow is a recurring maintenance window.
+type MaintenanceWindow struct {
+	// Schedule is the recurrence of the maintenance window.
+	Schedule MaintenanceSchedule `json:"schedule"`
+
+	// DurationHours is the length of the maintenance window.
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=24
+	DurationHours int32 `json:"durationHours"`
+
+	// StartTime is the time of day the maintenance window starts, in the format HH:mm.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// UTCOffset is the offset from UTC applied to StartTime, StartDate and NotAllowedDates, in the format +/-HH:mm.
+	// Defaults to "+00:00".
+	// +kubebuilder:validation:Pattern=`^(-|\+)[0-9]{2}:[0-9]{2}$`
+	// +optional
+	UTCOffset string `json:"utcOffset,omitempty"`
+
+	// StartDate is the date the maintenance window becomes active, in the format YYYY-MM-DD.
+	// When not set, the maintenance window is active right away.
+	// +kubebuilder:validation:Format=date
+	// +optional
+	StartDate string `json:"startDate,omitempty"`
+
+	// NotAllowedDates are date spans during which maintenance is not allowed.
+	// +optional
+	NotAllowedDates []DateSpan `json:"notAllowedDates,omitempty"`
+}
+
+// MaintenanceSchedule is the recurrence of a maintenance window. Exactly one schedule must be set.
+type MaintenanceSchedule struct {
+	// Daily recurs every IntervalDays days.
+	// +optional
+	Daily *DailySchedule `json:"daily,omitempty"`
+
+	// Weekly recurs every IntervalWeeks weeks on DayOfWeek.
+	// +optional
+	Weekly *WeeklySchedule `json:"weekly,omitempty"`
+
+	// AbsoluteMonthly recurs every IntervalMonths months on DayOfMonth.
+	// +optional
+	AbsoluteMonthly *AbsoluteMonthlySchedule `json:"absoluteMonthly,omitempty"`
+
+	// RelativeMonthly recurs every IntervalMonths months on DayOfWeek of the WeekIndex week.
+	// +optional
+	RelativeMonthly *RelativeMonthlySchedule `json:"relativeMonthly,omitempty"`
+}
+
+// DailySchedule is a schedule which recurs every few days.
+type DailySchedule struct {
+	// IntervalDays is the number of days between each occurrence.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=7
+	IntervalDays int32 `json:"intervalDays"`
+}
+
+// WeeklySchedule is a schedule which recurs on a day of the week every few weeks.
+type WeeklySchedule struct {
+	// DayOfWeek is the day of the week the maintenance occurs.
+	DayOfWeek WeekDay `json:"dayOfWeek"`
+
+	// IntervalWeeks is the number of weeks between each occurrence.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4
+	IntervalWeeks int32 `json:"intervalWeeks"`
+}
+
+// AbsoluteMonthlySchedule is a schedule which recurs on a date of the month every few months.
+type AbsoluteMonthlySchedule struct {
+	// DayOfMonth is the date of the month the maintenance occurs.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=31
+	DayOfMonth int32 `json:"dayOfMonth"`
+
+	// IntervalMonths is the number of months between each occurrence.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=6
+	IntervalMonths int32 `json:"intervalMonths"`
+}
+
+// RelativeMonthlySchedule is a schedule which recurs on a day of a week of the month every few months.
+type RelativeMonthlySchedule struct {
+	// DayOfWeek is the day of the week the maintenance occurs.
+	DayOfWeek WeekDay `json:"dayOfWeek"`
+
+	// WeekIndex is the week of the month the maintenance occurs.
+	// +kubebuilder:validation:Enum=First;Second;Third;Fourth;Last
+	WeekIndex string `json:"weekIndex"`
+
+	// IntervalMonths is the number of months between each occurrence.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=6
+	IntervalMonths int32 `json:"intervalMonths"`
+}
+
+// AKSExtension is a cluster extension installed on an AKS cluster.
+// See also [AKS doc].
+//
+// [AKS doc]: https://learn.microsoft.com/azure/aks/cluster-extensions
+type AKSExtension struct {
+	// Name is the name of the extension.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ExtensionType is the type of the extension, such as "microsoft.flux" or "microsoft.dapr".
+	// Immutable.
+	// +kubebuilder:validation:MinLength=1
+	ExtensionType string `json:"extensionType"`
+
+	// AutoUpgradeMinorVersion defines whether the minor version of the extension is upgraded automatically.
+	// It defaults to true, or to false when Version is set. Version must not be set when it is true.
+	// +optional
+	AutoUpgradeMinorVersion *bool `json:"autoUpgradeMinorVersion,omitempty"`
+
+	// ReleaseTrain is the release train the extension is upgraded from, such as "Stable" or "Preview".
+	// +optional
+	ReleaseTrain *string `json:"releaseTrain,omitempty"`
+
+	// Version is the version of the extension to install.
+	// +optional
+	Version *string `json:"version,omitempty"`
+
+	// Scope is the scope at which the extension is installed.
+	// Immutable.
+	// +optional
+	Scope *ExtensionScope `json:"scope,omitempty"`
+
+	// ConfigurationSettings are the configuration settings of the extension.
+	// +optional
+	ConfigurationSettings map[string]string `json:"configurationSettings,omitempty"`
+
+	// Plan is the marketplace plan of the extension, required for extensions offered through the Azure Marketplace.
+	// Immutable.
+	// +optional
+	Plan *ExtensionPlan `json:"plan,omitempty"`
+}
+
+// ExtensionScopeType is the scope type of a cluster extension.
+type ExtensionScopeType string
+
+const (
+	// ExtensionScopeTypeCluster installs the extension cluster-wide.
+	ExtensionScopeTypeCluster ExtensionScopeType = "Cluster"
+	// ExtensionScopeTypeNamespace installs the extension in a single namespace.
+	ExtensionScopeTypeNamespace ExtensionScopeType = "Namespace"
+)
+
+// ExtensionScope is the scope at which a cluster extension is installed.
+type ExtensionScope struct {
+	// ScopeType is the scope type of the extension.
+	// +kubebuilder:validation:Enum=Cluster;Namespace
+	ScopeType ExtensionScopeType `json:"scopeType"`
+
+	// ReleaseNamespace is the namespace the extension is installed in when ScopeType is Cluster.
+	// +optional
+	ReleaseNamespace string `json:"releaseNamespace,omitempty"`
+
+	// TargetNamespace is the namespace the extension is installed in when ScopeType is Namespace.
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+}
+
+// ExtensionPlan is the Azure Marketplace plan of a cluster extension.
+type ExtensionPlan struct {
+	// Name is the plan ID.
+	Name string `json:"name"`
+
+	// Product is the offer ID of the extension.
+	Product string `json:"product"`
+
+	// Publisher is the publisher ID of the extension.
+	Publisher string `json:"publisher"`
+
+	// Version is the version of the plan.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// AKSExtensionStatus is the observed state of a cluster extension.
+type AKSExtensionStatus struct {
+	// Name is the name of the extension.
+	Name string `json:"name"`
+
+	// ProvisioningState is the provisioning state of the extension.
+	// +optional
+	ProvisioningState ProvisioningState `json:"provisioningState,omitempty"`
+
+	// Version is the installed version of the extension.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=azuremanagedcontrolplanes,scope=Namespaced,categories=cluster-api,shortName=amcp
 // +kubebuilder:storageversion
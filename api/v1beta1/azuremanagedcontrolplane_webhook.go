@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capifeature "sigs.k8s.io/cluster-api/feature"
@@ -72,25 +73,10 @@ func (mw *azureManagedControlPlaneWebhook) Default(ctx context.Context, obj runt
 	if !ok {
 		return apierrors.NewBadRequest("expected an AzureManagedControlPlane")
 	}
-	if m.Spec.NetworkPlugin == nil {
-		networkPlugin := "azure"
-		m.Spec.NetworkPlugin = &networkPlugin
-	}
-	if m.Spec.LoadBalancerSKU == nil {
-		loadBalancerSKU := "Standard"
-		m.Spec.LoadBalancerSKU = &loadBalancerSKU
-	}
-
-	if m.Spec.Version != "" && !strings.HasPrefix(m.Spec.Version, "v") {
-		normalizedVersion := "v" + m.Spec.Version
-		m.Spec.Version = normalizedVersion
-	}
-
-	if m.Spec.Identity == nil {
-		m.Spec.Identity = &Identity{
-			Type: ManagedControlPlaneIdentityTypeSystemAssigned,
-		}
-	}
+	m.setDefaultNetworkPlugin()
+	m.setDefaultLoadBalancerSKU()
+	m.setDefaultVersion()
+	m.setDefaultIdentity()
 
 	if err := m.setDefaultSSHPublicKey(); err != nil {
 		ctrl.Log.WithName("AzureManagedControlPlaneWebHookLogger").Error(err, "setDefaultSSHPublicKey failed")
@@ -128,7 +114,6 @@ func (mw *azureManagedControlPlaneWebhook) ValidateCreate(ctx context.Context, o
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (mw *azureManagedControlPlaneWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
-	var allErrs field.ErrorList
 	old, ok := oldObj.(*AzureManagedControlPlane)
 	if !ok {
 		return nil, apierrors.NewBadRequest("expected an AzureManagedControlPlane")
@@ -138,6 +123,24 @@ func (mw *azureManagedControlPlaneWebhook) ValidateUpdate(ctx context.Context, o
 		return nil, apierrors.NewBadRequest("expected an AzureManagedControlPlane")
 	}
 
+	allErrs := m.validateImmutableFields(old)
+
+	if len(allErrs) == 0 {
+		return nil, m.Validate(mw.Client)
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("AzureManagedControlPlane").GroupKind(), m.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (mw *azureManagedControlPlaneWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateImmutableFields validates that no immutable field of an AzureManagedControlPlane was changed.
+func (m *AzureManagedControlPlane) validateImmutableFields(old *AzureManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "SubscriptionID"),
 		old.Spec.SubscriptionID,
@@ -194,6 +197,34 @@ func (mw *azureManagedControlPlaneWebhook) ValidateUpdate(ctx context.Context, o
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkDataplane"),
+		old.Spec.NetworkDataplane,
+		m.Spec.NetworkDataplane); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "PodCIDR"),
+		old.Spec.PodCIDR,
+		m.Spec.PodCIDR); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "ServiceCIDR"),
+		old.Spec.ServiceCIDR,
+		m.Spec.ServiceCIDR); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "IPFamilies"),
+		old.Spec.IPFamilies,
+		m.Spec.IPFamilies); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "LoadBalancerSKU"),
 		old.Spec.LoadBalancerSKU,
@@ -250,6 +281,13 @@ func (mw *azureManagedControlPlaneWebhook) ValidateUpdate(ctx context.Context, o
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "AdoptionMode"),
+		old.Spec.AdoptionMode,
+		m.Spec.AdoptionMode); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if errs := m.validateVirtualNetworkUpdate(old); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
@@ -266,16 +304,15 @@ func (mw *azureManagedControlPlaneWebhook) ValidateUpdate(ctx context.Context, o
 		allErrs = append(allErrs, errs...)
 	}
 
-	if len(allErrs) == 0 {
-		return nil, m.Validate(mw.Client)
+	if errs := m.validateSecurityProfileUpdate(old); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
 	}
 
-	return nil, apierrors.NewInvalid(GroupVersion.WithKind("AzureManagedControlPlane").GroupKind(), m.Name, allErrs)
-}
+	if errs := m.validateExtensionsUpdate(old); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
-func (mw *azureManagedControlPlaneWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+	return allErrs
 }
 
 // Validate the Azure Managed Control Plane and return an aggregate error.
@@ -290,6 +327,13 @@ func (m *AzureManagedControlPlane) Validate(cli client.Client) error {
 		m.validateAutoScalerProfile,
 		m.validateIdentity,
 		m.validateNetworkPluginMode,
+		m.validateNetworkProfile,
+		m.validatePodSubnets,
+		m.validateSecurityProfile,
+		m.validateDisableLocalAccounts,
+		m.validateMonitoring,
+		m.validateMaintenanceConfigurations,
+		m.validateExtensions,
 	}
 
 	var errs []error
@@ -412,25 +456,40 @@ func (m *AzureManagedControlPlane) validateManagedClusterNetwork(cli client.Clie
 		serviceCIDR string
 	)
 
+	// Dual-stack clusters take one Service/Pod CIDR per IP family.
+	maxCIDRBlocks := 1
+	if m.isDualStack() {
+		maxCIDRBlocks = 2
+	}
+
 	if clusterNetwork := ownerCluster.Spec.ClusterNetwork; clusterNetwork != nil {
 		if clusterNetwork.Services != nil {
-			// A user may provide zero or one CIDR blocks. If they provide an empty array,
-			// we ignore it and use the default. AKS doesn't support > 1 Service/Pod CIDR.
-			if len(clusterNetwork.Services.CIDRBlocks) > 1 {
-				allErrs = append(allErrs, field.TooMany(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Services", "CIDRBlocks"), len(clusterNetwork.Services.CIDRBlocks), 1))
+			// A user may provide zero or one CIDR blocks per IP family. If they provide an empty array,
+			// we ignore it and use the default. AKS doesn't support > 1 Service/Pod CIDR per IP family.
+			if len(clusterNetwork.Services.CIDRBlocks) > maxCIDRBlocks {
+				allErrs = append(allErrs, field.TooMany(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Services", "CIDRBlocks"), len(clusterNetwork.Services.CIDRBlocks), maxCIDRBlocks))
 			}
-			if len(clusterNetwork.Services.CIDRBlocks) == 1 {
+			if len(clusterNetwork.Services.CIDRBlocks) > 0 {
 				serviceCIDR = clusterNetwork.Services.CIDRBlocks[0]
 			}
+			if m.Spec.ServiceCIDR != nil && len(clusterNetwork.Services.CIDRBlocks) > 0 && *m.Spec.ServiceCIDR != serviceCIDR {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "ServiceCIDR"), *m.Spec.ServiceCIDR, "must match the service CIDR block of the Cluster's clusterNetwork"))
+			}
 		}
 		if clusterNetwork.Pods != nil {
-			// A user may provide zero or one CIDR blocks. If they provide an empty array,
-			// we ignore it and use the default. AKS doesn't support > 1 Service/Pod CIDR.
-			if len(clusterNetwork.Pods.CIDRBlocks) > 1 {
-				allErrs = append(allErrs, field.TooMany(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Pods", "CIDRBlocks"), len(clusterNetwork.Pods.CIDRBlocks), 1))
+			// A user may provide zero or one CIDR blocks per IP family. If they provide an empty array,
+			// we ignore it and use the default. AKS doesn't support > 1 Service/Pod CIDR per IP family.
+			if len(clusterNetwork.Pods.CIDRBlocks) > maxCIDRBlocks {
+				allErrs = append(allErrs, field.TooMany(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Pods", "CIDRBlocks"), len(clusterNetwork.Pods.CIDRBlocks), maxCIDRBlocks))
+			}
+			if m.Spec.PodCIDR != nil && len(clusterNetwork.Pods.CIDRBlocks) > 0 && *m.Spec.PodCIDR != clusterNetwork.Pods.CIDRBlocks[0] {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "PodCIDR"), *m.Spec.PodCIDR, "must match the pod CIDR block of the Cluster's clusterNetwork"))
 			}
 		}
 	}
+	if m.Spec.ServiceCIDR != nil {
+		serviceCIDR = *m.Spec.ServiceCIDR
+	}
 
 	if m.Spec.DNSServiceIP != nil {
 		if serviceCIDR == "" {
@@ -545,6 +604,20 @@ func (m *AzureManagedControlPlane) validateVirtualNetworkUpdate(old *AzureManage
 				m.Spec.VirtualNetwork.ResourceGroup,
 				"Virtual Network Resource Group is immutable"))
 	}
+
+	oldPodSubnetCIDRs := make(map[string]string, len(old.Spec.VirtualNetwork.PodSubnets))
+	for _, subnet := range old.Spec.VirtualNetwork.PodSubnets {
+		oldPodSubnetCIDRs[subnet.Name] = subnet.CIDRBlock
+	}
+	for i, subnet := range m.Spec.VirtualNetwork.PodSubnets {
+		if oldCIDR, ok := oldPodSubnetCIDRs[subnet.Name]; ok && oldCIDR != subnet.CIDRBlock {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("Spec", "VirtualNetwork.PodSubnets").Index(i).Child("CIDRBlock"),
+					subnet.CIDRBlock,
+					"Pod Subnet CIDRBlock is immutable"))
+		}
+	}
 	return allErrs
 }
 
@@ -578,6 +651,34 @@ func (m *AzureManagedControlPlane) validateOIDCIssuerProfileUpdate(old *AzureMan
 	return allErrs
 }
 
+// validateExtensionsUpdate validates that the immutable fields of an existing extension were not changed.
+func (m *AzureManagedControlPlane) validateExtensionsUpdate(old *AzureManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	oldExtensions := make(map[string]AKSExtension, len(old.Spec.Extensions))
+	for _, extension := range old.Spec.Extensions {
+		oldExtensions[extension.Name] = extension
+	}
+	for i, extension := range m.Spec.Extensions {
+		oldExtension, ok := oldExtensions[extension.Name]
+		if !ok {
+			continue
+		}
+		fldPath := field.NewPath("Spec", "Extensions").Index(i)
+		if err := webhookutils.ValidateImmutable(fldPath.Child("ExtensionType"), oldExtension.ExtensionType, extension.ExtensionType); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if err := webhookutils.ValidateImmutable(fldPath.Child("Scope"), oldExtension.Scope, extension.Scope); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if err := webhookutils.ValidateImmutable(fldPath.Child("Plan"), oldExtension.Plan, extension.Plan); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	return allErrs
+}
+
 func (m *AzureManagedControlPlane) validateName(_ client.Client) error {
 	if lName := strings.ToLower(m.Name); strings.Contains(lName, "microsoft") ||
 		strings.Contains(lName, "windows") {
@@ -770,3 +871,454 @@ func (m *AzureManagedControlPlane) validateNetworkPluginMode(_ client.Client) er
 
 	return nil
 }
+
+// validateNetworkProfile validates the NetworkDataplane, PodCIDR, ServiceCIDR and IPFamilies.
+func (m *AzureManagedControlPlane) validateNetworkProfile(cli client.Client) error {
+	var allErrs field.ErrorList
+
+	const (
+		azureNetworkPlugin  = "azure"
+		ciliumNetworkPolicy = "cilium"
+	)
+	if ptr.Deref(m.Spec.NetworkDataplane, "") == NetworkDataplaneTypeCilium {
+		if ptr.Deref(m.Spec.NetworkPlugin, "") != azureNetworkPlugin {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "NetworkDataplane"), *m.Spec.NetworkDataplane, fmt.Sprintf("requires NetworkPlugin to be %q", azureNetworkPlugin)))
+		}
+		if m.Spec.NetworkPolicy != nil && *m.Spec.NetworkPolicy != ciliumNetworkPolicy {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "NetworkPolicy"), *m.Spec.NetworkPolicy, fmt.Sprintf("must be %q when NetworkDataplane is %q", ciliumNetworkPolicy, NetworkDataplaneTypeCilium)))
+		}
+	} else if ptr.Deref(m.Spec.NetworkPolicy, "") == ciliumNetworkPolicy {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "NetworkPolicy"), *m.Spec.NetworkPolicy, fmt.Sprintf("requires NetworkDataplane to be %q", NetworkDataplaneTypeCilium)))
+	}
+
+	if len(m.Spec.IPFamilies) > 0 {
+		seen := make(map[IPFamily]bool, len(m.Spec.IPFamilies))
+		for i, family := range m.Spec.IPFamilies {
+			if seen[family] {
+				allErrs = append(allErrs, field.Duplicate(field.NewPath("Spec", "IPFamilies").Index(i), family))
+			}
+			seen[family] = true
+		}
+		if !seen[IPFamilyIPv4] {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "IPFamilies"), m.Spec.IPFamilies, fmt.Sprintf("must include %q", IPFamilyIPv4)))
+		}
+	}
+
+	for _, c := range []struct {
+		name string
+		cidr *string
+	}{
+		{name: "PodCIDR", cidr: m.Spec.PodCIDR},
+		{name: "ServiceCIDR", cidr: m.Spec.ServiceCIDR},
+	} {
+		if c.cidr == nil {
+			continue
+		}
+		fldPath := field.NewPath("Spec", c.name)
+		if m.isDualStack() {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be set for dual-stack clusters, use the CIDR blocks of the Cluster's clusterNetwork instead"))
+			continue
+		}
+		if _, _, err := net.ParseCIDR(*c.cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, *c.cidr, fmt.Sprintf("failed to parse cidr: %v", err)))
+		}
+	}
+
+	allErrs = append(allErrs, m.validateNetworkCIDROverlap(cli)...)
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// networkCIDR is a pod or service CIDR block along with the field it is set from.
+type networkCIDR struct {
+	fldPath *field.Path
+	value   string
+	cidr    *net.IPNet
+}
+
+// validateNetworkCIDROverlap validates that the pod and service CIDR blocks used by the cluster don't overlap with each other
+// or with the virtual network. As for the managed cluster, the CIDR blocks of the Cluster's clusterNetwork are used unless
+// PodCIDR or ServiceCIDR are set.
+func (m *AzureManagedControlPlane) validateNetworkCIDROverlap(cli client.Client) field.ErrorList {
+	var podRanges, serviceRanges []string
+	if clusterName, ok := m.Labels[clusterv1.ClusterNameLabel]; ok && cli != nil {
+		ownerCluster := &clusterv1.Cluster{}
+		// Failing to get the Cluster is reported by validateManagedClusterNetwork.
+		if err := cli.Get(context.Background(), client.ObjectKey{Namespace: m.Namespace, Name: clusterName}, ownerCluster); err == nil && ownerCluster.Spec.ClusterNetwork != nil {
+			if ownerCluster.Spec.ClusterNetwork.Pods != nil {
+				podRanges = ownerCluster.Spec.ClusterNetwork.Pods.CIDRBlocks
+			}
+			if ownerCluster.Spec.ClusterNetwork.Services != nil {
+				serviceRanges = ownerCluster.Spec.ClusterNetwork.Services.CIDRBlocks
+			}
+		}
+	}
+	podCIDRs := effectiveNetworkCIDRs(m.Spec.PodCIDR, field.NewPath("Spec", "PodCIDR"),
+		podRanges, field.NewPath("Cluster", "Spec", "ClusterNetwork", "Pods", "CIDRBlocks"))
+	serviceCIDRs := effectiveNetworkCIDRs(m.Spec.ServiceCIDR, field.NewPath("Spec", "ServiceCIDR"),
+		serviceRanges, field.NewPath("Cluster", "Spec", "ClusterNetwork", "Services", "CIDRBlocks"))
+
+	var allErrs field.ErrorList
+	if _, vnetCIDR, err := net.ParseCIDR(m.Spec.VirtualNetwork.CIDRBlock); err == nil {
+		for _, c := range append(podCIDRs, serviceCIDRs...) {
+			if cidrsOverlap(c.cidr, vnetCIDR) {
+				allErrs = append(allErrs, field.Invalid(c.fldPath, c.value, fmt.Sprintf("must not overlap with the virtual network CIDR block %s", m.Spec.VirtualNetwork.CIDRBlock)))
+			}
+		}
+	}
+	for _, service := range serviceCIDRs {
+		for _, pod := range podCIDRs {
+			if cidrsOverlap(service.cidr, pod.cidr) {
+				allErrs = append(allErrs, field.Invalid(service.fldPath, service.value, fmt.Sprintf("must not overlap with the pod CIDR block %s", pod.value)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// effectiveNetworkCIDRs returns the parsed override CIDR block if it is set, or else the parsed CIDR blocks of the Cluster's network ranges.
+// Invalid CIDR blocks are skipped as they are reported separately.
+func effectiveNetworkCIDRs(override *string, overridePath *field.Path, ranges []string, rangesPath *field.Path) []networkCIDR {
+	var cidrs []networkCIDR
+	if override != nil {
+		if _, cidr, err := net.ParseCIDR(*override); err == nil {
+			cidrs = append(cidrs, networkCIDR{fldPath: overridePath, value: *override, cidr: cidr})
+		}
+		return cidrs
+	}
+	for i, block := range ranges {
+		if _, cidr, err := net.ParseCIDR(block); err == nil {
+			cidrs = append(cidrs, networkCIDR{fldPath: rangesPath.Index(i), value: block, cidr: cidr})
+		}
+	}
+	return cidrs
+}
+
+// validatePodSubnets validates the pod subnets of the VirtualNetwork.
+func (m *AzureManagedControlPlane) validatePodSubnets(_ client.Client) error {
+	if len(m.Spec.VirtualNetwork.PodSubnets) == 0 {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("Spec", "VirtualNetwork.PodSubnets")
+
+	const azureNetworkPlugin = "azure"
+	if ptr.Deref(m.Spec.NetworkPlugin, "") != azureNetworkPlugin || ptr.Deref(m.Spec.NetworkPluginMode, "") == NetworkPluginModeOverlay {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("requires NetworkPlugin %q without the %q NetworkPluginMode", azureNetworkPlugin, NetworkPluginModeOverlay)))
+	}
+
+	_, vnetCIDR, vnetErr := net.ParseCIDR(m.Spec.VirtualNetwork.CIDRBlock)
+	_, nodeSubnetCIDR, nodeSubnetErr := net.ParseCIDR(m.Spec.VirtualNetwork.Subnet.CIDRBlock)
+	seen := map[string]bool{m.Spec.VirtualNetwork.Subnet.Name: true}
+	var podSubnetCIDRs []*net.IPNet
+	for i, subnet := range m.Spec.VirtualNetwork.PodSubnets {
+		if seen[subnet.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("Name"), subnet.Name))
+		}
+		seen[subnet.Name] = true
+
+		_, cidr, err := net.ParseCIDR(subnet.CIDRBlock)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("CIDRBlock"), subnet.CIDRBlock, fmt.Sprintf("failed to parse cidr: %v", err)))
+			continue
+		}
+		if vnetErr == nil && !cidrContains(vnetCIDR, cidr) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("CIDRBlock"), subnet.CIDRBlock, fmt.Sprintf("must be within the virtual network CIDR block %s", m.Spec.VirtualNetwork.CIDRBlock)))
+		}
+		if nodeSubnetErr == nil && cidrsOverlap(cidr, nodeSubnetCIDR) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("CIDRBlock"), subnet.CIDRBlock, fmt.Sprintf("must not overlap with the subnet CIDR block %s", m.Spec.VirtualNetwork.Subnet.CIDRBlock)))
+		}
+		for _, other := range podSubnetCIDRs {
+			if cidrsOverlap(cidr, other) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("CIDRBlock"), subnet.CIDRBlock, fmt.Sprintf("must not overlap with the pod subnet CIDR block %s", other)))
+			}
+		}
+		podSubnetCIDRs = append(podSubnetCIDRs, cidr)
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// isDualStack returns true if the AzureManagedControlPlane uses both the IPv4 and IPv6 IP families.
+func (m *AzureManagedControlPlane) isDualStack() bool {
+	return len(m.Spec.IPFamilies) > 1
+}
+
+// cidrContains returns true if the CIDR block inner is entirely within the CIDR block outer.
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerPrefixLen, _ := outer.Mask.Size()
+	innerPrefixLen, _ := inner.Mask.Size()
+	return outer.Contains(inner.IP) && innerPrefixLen >= outerPrefixLen
+}
+
+// cidrsOverlap returns true if the two CIDR blocks share any address.
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// validateSecurityProfile validates a SecurityProfile.
+func (m *AzureManagedControlPlane) validateSecurityProfile(_ client.Client) error {
+	profile := m.Spec.SecurityProfile
+	if profile == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("Spec", "SecurityProfile")
+
+	if profile.WorkloadIdentity != nil && profile.WorkloadIdentity.Enabled &&
+		(m.Spec.OIDCIssuerProfile == nil || !ptr.Deref(m.Spec.OIDCIssuerProfile.Enabled, false)) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("WorkloadIdentity"), "cannot be enabled unless the OIDC issuer is enabled"))
+	}
+
+	if kms := profile.AzureKeyVaultKms; kms != nil && kms.Enabled {
+		kmsPath := fldPath.Child("AzureKeyVaultKms")
+		if kms.KeyID == "" {
+			allErrs = append(allErrs, field.Required(kmsPath.Child("KeyID"), "must be set when the Key Management Service is enabled"))
+		}
+		switch ptr.Deref(kms.KeyVaultNetworkAccess, KeyVaultNetworkAccessTypesPublic) {
+		case KeyVaultNetworkAccessTypesPrivate:
+			if ptr.Deref(kms.KeyVaultResourceID, "") == "" {
+				allErrs = append(allErrs, field.Required(kmsPath.Child("KeyVaultResourceID"), fmt.Sprintf("must be set when KeyVaultNetworkAccess is %q", KeyVaultNetworkAccessTypesPrivate)))
+			}
+		case KeyVaultNetworkAccessTypesPublic:
+			if kms.KeyVaultResourceID != nil {
+				allErrs = append(allErrs, field.Forbidden(kmsPath.Child("KeyVaultResourceID"), fmt.Sprintf("cannot be set when KeyVaultNetworkAccess is %q", KeyVaultNetworkAccessTypesPublic)))
+			}
+		}
+	}
+
+	if defender := profile.Defender; defender != nil && defender.SecurityMonitoring.Enabled && defender.LogAnalyticsWorkspaceResourceID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("Defender", "LogAnalyticsWorkspaceResourceID"), "must be set when security monitoring is enabled"))
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// validateDisableLocalAccounts validates that local accounts are only disabled for AAD integrated clusters.
+func (m *AzureManagedControlPlane) validateDisableLocalAccounts(_ client.Client) error {
+	if ptr.Deref(m.Spec.DisableLocalAccounts, false) && (m.Spec.AADProfile == nil || !m.Spec.AADProfile.Managed) {
+		return field.Forbidden(field.NewPath("Spec", "DisableLocalAccounts"), "can only be set for clusters with a managed AADProfile")
+	}
+	return nil
+}
+
+// validateMonitoring validates Monitoring.
+func (m *AzureManagedControlPlane) validateMonitoring(_ client.Client) error {
+	monitoring := m.Spec.Monitoring
+	if monitoring == nil || monitoring.LogAnalyticsWorkspaceResourceID == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("Spec", "Monitoring", "LogAnalyticsWorkspaceResourceID")
+	workspaceID := *monitoring.LogAnalyticsWorkspaceResourceID
+	if resourceID, err := azureutil.ParseResourceID(workspaceID); err != nil || !strings.EqualFold(resourceID.ResourceType.String(), "Microsoft.OperationalInsights/workspaces") {
+		allErrs = append(allErrs, field.Invalid(fldPath, workspaceID, "must be the resource ID of a Log Analytics workspace"))
+	}
+	for i, addon := range m.Spec.AddonProfiles {
+		if strings.EqualFold(addon.Name, "omsagent") {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "AddonProfiles").Index(i), "cannot configure the omsagent add-on when Monitoring.LogAnalyticsWorkspaceResourceID is set"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// validateSecurityProfileUpdate validates update to SecurityProfile.
+// AKS keeps a security feature as is when it is omitted, so a feature must be disabled explicitly instead of being removed.
+func (m *AzureManagedControlPlane) validateSecurityProfileUpdate(old *AzureManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if old.Spec.SecurityProfile == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("Spec", "SecurityProfile")
+	oldProfile := old.Spec.SecurityProfile
+	profile := m.Spec.SecurityProfile
+	if profile == nil {
+		profile = &ManagedClusterSecurityProfile{}
+	}
+
+	if oldProfile.AzureKeyVaultKms != nil && profile.AzureKeyVaultKms == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("AzureKeyVaultKms"), "cannot be removed, set enabled to false to disable it"))
+	}
+	if oldProfile.Defender != nil && profile.Defender == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("Defender"), "cannot be removed, set securityMonitoring.enabled to false to disable it"))
+	}
+	if oldProfile.ImageCleaner != nil && profile.ImageCleaner == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ImageCleaner"), "cannot be removed, set enabled to false to disable it"))
+	}
+	if oldProfile.WorkloadIdentity != nil && profile.WorkloadIdentity == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("WorkloadIdentity"), "cannot be removed, set enabled to false to disable it"))
+	}
+
+	return allErrs
+}
+
+// validateMaintenanceConfigurations validates the MaintenanceConfigurations.
+func (m *AzureManagedControlPlane) validateMaintenanceConfigurations(_ client.Client) error {
+	var allErrs field.ErrorList
+
+	names := make(map[MaintenanceConfigurationName]struct{}, len(m.Spec.MaintenanceConfigurations))
+	for i, config := range m.Spec.MaintenanceConfigurations {
+		fldPath := field.NewPath("Spec", "MaintenanceConfigurations").Index(i)
+		if _, ok := names[config.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("Name"), config.Name))
+		}
+		names[config.Name] = struct{}{}
+
+		switch config.Name {
+		case MaintenanceConfigurationNameDefault:
+			if config.MaintenanceWindow != nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("MaintenanceWindow"), fmt.Sprintf("cannot be set for the %q maintenance configuration", config.Name)))
+			}
+			if len(config.TimeInWeek) == 0 {
+				allErrs = append(allErrs, field.Required(fldPath.Child("TimeInWeek"), fmt.Sprintf("must be set for the %q maintenance configuration", config.Name)))
+			}
+			for j, timeInWeek := range config.TimeInWeek {
+				for k, hour := range timeInWeek.HourSlots {
+					if hour < 0 || hour > 23 {
+						allErrs = append(allErrs, field.Invalid(fldPath.Child("TimeInWeek").Index(j).Child("HourSlots").Index(k), hour, "value should be in between 0 and 23"))
+					}
+				}
+			}
+			for j, span := range config.NotAllowedTime {
+				if !span.Start.Before(&span.End) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("NotAllowedTime").Index(j).Child("End"), span.End, "must be after start"))
+				}
+			}
+		case MaintenanceConfigurationNameAutoUpgrade, MaintenanceConfigurationNameNodeOSUpgrade:
+			if len(config.TimeInWeek) > 0 {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("TimeInWeek"), fmt.Sprintf("cannot be set for the %q maintenance configuration", config.Name)))
+			}
+			if len(config.NotAllowedTime) > 0 {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("NotAllowedTime"), fmt.Sprintf("cannot be set for the %q maintenance configuration", config.Name)))
+			}
+			if config.MaintenanceWindow == nil {
+				allErrs = append(allErrs, field.Required(fldPath.Child("MaintenanceWindow"), fmt.Sprintf("must be set for the %q maintenance configuration", config.Name)))
+				continue
+			}
+			allErrs = append(allErrs, validateMaintenanceWindow(config.Name, config.MaintenanceWindow, fldPath.Child("MaintenanceWindow"))...)
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("Name"), config.Name, []string{
+				string(MaintenanceConfigurationNameDefault),
+				string(MaintenanceConfigurationNameAutoUpgrade),
+				string(MaintenanceConfigurationNameNodeOSUpgrade),
+			}))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// validateExtensions validates the cluster extensions of the managed cluster.
+func (m *AzureManagedControlPlane) validateExtensions(_ client.Client) error {
+	var allErrs field.ErrorList
+
+	names := make(map[string]struct{}, len(m.Spec.Extensions))
+	for i, extension := range m.Spec.Extensions {
+		fldPath := field.NewPath("Spec", "Extensions").Index(i)
+		if _, ok := names[extension.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("Name"), extension.Name))
+		}
+		names[extension.Name] = struct{}{}
+
+		if ptr.Deref(extension.AutoUpgradeMinorVersion, false) && extension.Version != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("Version"), "cannot be set when AutoUpgradeMinorVersion is true"))
+		}
+
+		if extension.Scope == nil {
+			continue
+		}
+		switch extension.Scope.ScopeType {
+		case ExtensionScopeTypeCluster:
+			if extension.Scope.TargetNamespace != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("Scope", "TargetNamespace"), "cannot be set when ScopeType is Cluster"))
+			}
+		case ExtensionScopeTypeNamespace:
+			if extension.Scope.ReleaseNamespace != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("Scope", "ReleaseNamespace"), "cannot be set when ScopeType is Namespace"))
+			}
+			if extension.Scope.TargetNamespace == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("Scope", "TargetNamespace"), "must be set when ScopeType is Namespace"))
+			}
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// validateMaintenanceWindow validates the MaintenanceWindow of a maintenance configuration.
+func validateMaintenanceWindow(name MaintenanceConfigurationName, window *MaintenanceWindow, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	schedule := window.Schedule
+	numSchedules := 0
+	for _, set := range []bool{schedule.Daily != nil, schedule.Weekly != nil, schedule.AbsoluteMonthly != nil, schedule.RelativeMonthly != nil} {
+		if set {
+			numSchedules++
+		}
+	}
+	if numSchedules != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("Schedule"), schedule, "exactly one of Daily, Weekly, AbsoluteMonthly and RelativeMonthly must be set"))
+	}
+	// Cluster auto-upgrades are released at most weekly, so AKS rejects a daily schedule for them.
+	if name == MaintenanceConfigurationNameAutoUpgrade && schedule.Daily != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("Schedule", "Daily"), fmt.Sprintf("cannot be set for the %q maintenance configuration", name)))
+	}
+
+	if window.DurationHours < 4 || window.DurationHours > 24 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("DurationHours"), window.DurationHours, "value should be in between 4 and 24"))
+	}
+	if _, err := time.Parse("15:04", window.StartTime); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("StartTime"), window.StartTime, "must be in the format HH:mm"))
+	}
+	if window.StartDate != "" {
+		if _, err := time.Parse(time.DateOnly, window.StartDate); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("StartDate"), window.StartDate, "must be in the format YYYY-MM-DD"))
+		}
+	}
+	for i, span := range window.NotAllowedDates {
+		start, startErr := time.Parse(time.DateOnly, span.Start)
+		if startErr != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("NotAllowedDates").Index(i).Child("Start"), span.Start, "must be in the format YYYY-MM-DD"))
+		}
+		end, endErr := time.Parse(time.DateOnly, span.End)
+		if endErr != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("NotAllowedDates").Index(i).Child("End"), span.End, "must be in the format YYYY-MM-DD"))
+		}
+		if startErr == nil && endErr == nil && end.Before(start) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("NotAllowedDates").Index(i).Child("End"), span.End, "must not be before start"))
+		}
+	}
+
+	return allErrs
+}
@@ -19,14 +19,18 @@ package v1beta1
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestDefaultingWebhook(t *testing.T) {
@@ -1130,6 +1134,62 @@ func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "AzureManagedControlPlane NetworkDataplane is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP:     ptr.To("192.168.0.10"),
+					NetworkPlugin:    ptr.To("azure"),
+					NetworkDataplane: ptr.To(NetworkDataplaneTypeAzure),
+					Version:          "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP:     ptr.To("192.168.0.10"),
+					NetworkPlugin:    ptr.To("azure"),
+					NetworkDataplane: ptr.To(NetworkDataplaneTypeCilium),
+					Version:          "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane PodCIDR is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: ptr.To("192.168.0.10"),
+					PodCIDR:      ptr.To("172.16.0.0/16"),
+					Version:      "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: ptr.To("192.168.0.10"),
+					PodCIDR:      ptr.To("172.17.0.0/16"),
+					Version:      "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane IPFamilies is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: ptr.To("192.168.0.10"),
+					IPFamilies:   []IPFamily{IPFamilyIPv4},
+					Version:      "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: ptr.To("192.168.0.10"),
+					IPFamilies:   []IPFamily{IPFamilyIPv4, IPFamilyIPv6},
+					Version:      "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "AzureManagedControlPlane LoadBalancerSKU is immutable",
 			oldAMCP: &AzureManagedControlPlane{
@@ -1436,6 +1496,26 @@ func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "AdoptionMode update",
+			oldAMCP: &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					AdoptionMode: ptr.To(AdoptionModeAdopt),
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					AdoptionMode: ptr.To(AdoptionModeNone),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "AzureManagedControlPlane HTTPProxyConfig is immutable",
 			oldAMCP: &AzureManagedControlPlane{
@@ -1626,40 +1706,1008 @@ func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
 	}
 }
 
-func createAzureManagedControlPlane(serviceIP, version, sshKey string) *AzureManagedControlPlane {
-	return &AzureManagedControlPlane{
-		ObjectMeta: getAMCPMetaData(),
-		Spec: AzureManagedControlPlaneSpec{
-			SSHPublicKey: &sshKey,
-			DNSServiceIP: ptr.To(serviceIP),
-			Version:      version,
+func TestValidateMaintenanceConfigurations(t *testing.T) {
+	weeklyWindow := &MaintenanceWindow{
+		Schedule: MaintenanceSchedule{
+			Weekly: &WeeklySchedule{DayOfWeek: "Sunday", IntervalWeeks: 1},
+		},
+		DurationHours: 4,
+		StartTime:     "00:00",
+	}
+	now := metav1.Now()
+
+	tests := []struct {
+		name    string
+		configs []MaintenanceConfiguration
+		wantErr bool
+	}{
+		{
+			name: "valid maintenance configurations",
+			configs: []MaintenanceConfiguration{
+				{
+					Name:       MaintenanceConfigurationNameDefault,
+					TimeInWeek: []TimeInWeek{{Day: "Monday", HourSlots: []int32{0, 23}}},
+					NotAllowedTime: []TimeSpan{
+						{Start: now, End: metav1.NewTime(now.Add(time.Hour))},
+					},
+				},
+				{
+					Name:              MaintenanceConfigurationNameAutoUpgrade,
+					MaintenanceWindow: weeklyWindow,
+				},
+				{
+					Name: MaintenanceConfigurationNameNodeOSUpgrade,
+					MaintenanceWindow: &MaintenanceWindow{
+						Schedule: MaintenanceSchedule{
+							Daily: &DailySchedule{IntervalDays: 1},
+						},
+						DurationHours:   8,
+						StartTime:       "22:30",
+						UTCOffset:       "+05:30",
+						StartDate:       "2023-11-01",
+						NotAllowedDates: []DateSpan{{Start: "2023-12-23", End: "2024-01-03"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate maintenance configuration",
+			configs: []MaintenanceConfiguration{
+				{Name: MaintenanceConfigurationNameAutoUpgrade, MaintenanceWindow: weeklyWindow},
+				{Name: MaintenanceConfigurationNameAutoUpgrade, MaintenanceWindow: weeklyWindow},
+			},
+			wantErr: true,
+		},
+		{
+			name: "default maintenance configuration with maintenance window",
+			configs: []MaintenanceConfiguration{
+				{
+					Name:              MaintenanceConfigurationNameDefault,
+					TimeInWeek:        []TimeInWeek{{Day: "Monday"}},
+					MaintenanceWindow: weeklyWindow,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "default maintenance configuration without time in week",
+			configs: []MaintenanceConfiguration{
+				{Name: MaintenanceConfigurationNameDefault},
+			},
+			wantErr: true,
+		},
+		{
+			name: "default maintenance configuration with invalid hour slot",
+			configs: []MaintenanceConfiguration{
+				{
+					Name:       MaintenanceConfigurationNameDefault,
+					TimeInWeek: []TimeInWeek{{Day: "Monday", HourSlots: []int32{24}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "default maintenance configuration with not allowed time ending before start",
+			configs: []MaintenanceConfiguration{
+				{
+					Name:       MaintenanceConfigurationNameDefault,
+					TimeInWeek: []TimeInWeek{{Day: "Monday"}},
+					NotAllowedTime: []TimeSpan{
+						{Start: now, End: metav1.NewTime(now.Add(-time.Hour))},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auto-upgrade maintenance configuration without maintenance window",
+			configs: []MaintenanceConfiguration{
+				{Name: MaintenanceConfigurationNameAutoUpgrade},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auto-upgrade maintenance configuration with time in week",
+			configs: []MaintenanceConfiguration{
+				{
+					Name:              MaintenanceConfigurationNameAutoUpgrade,
+					TimeInWeek:        []TimeInWeek{{Day: "Monday"}},
+					MaintenanceWindow: weeklyWindow,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auto-upgrade maintenance configuration with daily schedule",
+			configs: []MaintenanceConfiguration{
+				{
+					Name: MaintenanceConfigurationNameAutoUpgrade,
+					MaintenanceWindow: &MaintenanceWindow{
+						Schedule:      MaintenanceSchedule{Daily: &DailySchedule{IntervalDays: 1}},
+						DurationHours: 4,
+						StartTime:     "00:00",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "maintenance window without schedule",
+			configs: []MaintenanceConfiguration{
+				{
+					Name: MaintenanceConfigurationNameNodeOSUpgrade,
+					MaintenanceWindow: &MaintenanceWindow{
+						DurationHours: 4,
+						StartTime:     "00:00",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "maintenance window with multiple schedules",
+			configs: []MaintenanceConfiguration{
+				{
+					Name: MaintenanceConfigurationNameNodeOSUpgrade,
+					MaintenanceWindow: &MaintenanceWindow{
+						Schedule: MaintenanceSchedule{
+							Daily:           &DailySchedule{IntervalDays: 1},
+							AbsoluteMonthly: &AbsoluteMonthlySchedule{DayOfMonth: 1, IntervalMonths: 1},
+						},
+						DurationHours: 4,
+						StartTime:     "00:00",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "maintenance window with invalid duration",
+			configs: []MaintenanceConfiguration{
+				{
+					Name: MaintenanceConfigurationNameNodeOSUpgrade,
+					MaintenanceWindow: &MaintenanceWindow{
+						Schedule:      MaintenanceSchedule{Daily: &DailySchedule{IntervalDays: 1}},
+						DurationHours: 3,
+						StartTime:     "00:00",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "maintenance window with invalid start time",
+			configs: []MaintenanceConfiguration{
+				{
+					Name: MaintenanceConfigurationNameNodeOSUpgrade,
+					MaintenanceWindow: &MaintenanceWindow{
+						Schedule:      MaintenanceSchedule{Daily: &DailySchedule{IntervalDays: 1}},
+						DurationHours: 4,
+						StartTime:     "24:00",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "maintenance window with not allowed dates ending before start",
+			configs: []MaintenanceConfiguration{
+				{
+					Name: MaintenanceConfigurationNameNodeOSUpgrade,
+					MaintenanceWindow: &MaintenanceWindow{
+						Schedule:        MaintenanceSchedule{Daily: &DailySchedule{IntervalDays: 1}},
+						DurationHours:   4,
+						StartTime:       "00:00",
+						NotAllowedDates: []DateSpan{{Start: "2024-01-03", End: "2023-12-23"}},
+					},
+				},
+			},
+			wantErr: true,
 		},
 	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			m := getKnownValidAzureManagedControlPlane()
+			m.Spec.MaintenanceConfigurations = tc.configs
+			err := m.validateMaintenanceConfigurations(nil)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
 }
 
-func getKnownValidAzureManagedControlPlane() *AzureManagedControlPlane {
-	return &AzureManagedControlPlane{
-		ObjectMeta: getAMCPMetaData(),
-		Spec: AzureManagedControlPlaneSpec{
-			DNSServiceIP: ptr.To("192.168.0.10"),
-			Version:      "v1.18.0",
-			SSHPublicKey: ptr.To(generateSSHPublicKey(true)),
-			AADProfile: &AADProfile{
-				Managed: true,
-				AdminGroupObjectIDs: []string{
-					"616077a8-5db7-4c98-b856-b34619afg75h",
+func TestValidateExtensions(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions []AKSExtension
+		wantErr    bool
+	}{
+		{
+			name: "valid extensions",
+			extensions: []AKSExtension{
+				{
+					Name:                    "flux",
+					ExtensionType:           "microsoft.flux",
+					AutoUpgradeMinorVersion: ptr.To(true),
+					Scope: &ExtensionScope{
+						ScopeType:        ExtensionScopeTypeCluster,
+						ReleaseNamespace: "flux-system",
+					},
+				},
+				{
+					Name:          "dapr",
+					ExtensionType: "microsoft.dapr",
+					Version:       ptr.To("1.11.3"),
+					Scope: &ExtensionScope{
+						ScopeType:       ExtensionScopeTypeNamespace,
+						TargetNamespace: "dapr-system",
+					},
+					ConfigurationSettings: map[string]string{"global.ha.enabled": "true"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate extension names",
+			extensions: []AKSExtension{
+				{Name: "flux", ExtensionType: "microsoft.flux"},
+				{Name: "flux", ExtensionType: "microsoft.flux"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "version set with minor version auto-upgrade",
+			extensions: []AKSExtension{
+				{
+					Name:                    "flux",
+					ExtensionType:           "microsoft.flux",
+					AutoUpgradeMinorVersion: ptr.To(true),
+					Version:                 ptr.To("1.7.5"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cluster scope with target namespace",
+			extensions: []AKSExtension{
+				{
+					Name:          "flux",
+					ExtensionType: "microsoft.flux",
+					Scope: &ExtensionScope{
+						ScopeType:       ExtensionScopeTypeCluster,
+						TargetNamespace: "flux-system",
+					},
 				},
 			},
+			wantErr: true,
+		},
+		{
+			name: "namespace scope without target namespace",
+			extensions: []AKSExtension{
+				{
+					Name:          "dapr",
+					ExtensionType: "microsoft.dapr",
+					Scope: &ExtensionScope{
+						ScopeType: ExtensionScopeTypeNamespace,
+					},
+				},
+			},
+			wantErr: true,
 		},
 	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			m := getKnownValidAzureManagedControlPlane()
+			m.Spec.Extensions = tc.extensions
+			err := m.validateExtensions(nil)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
 }
 
-func getAMCPMetaData() metav1.ObjectMeta {
-	return metav1.ObjectMeta{
-		Name: "test-AMCP",
-		Labels: map[string]string{
-			"cluster.x-k8s.io/cluster-name": "test-cluster",
+func TestValidateExtensionsUpdate(t *testing.T) {
+	oldExtension := AKSExtension{
+		Name:          "flux",
+		ExtensionType: "microsoft.flux",
+		Scope: &ExtensionScope{
+			ScopeType:        ExtensionScopeTypeCluster,
+			ReleaseNamespace: "flux-system",
 		},
-		Namespace: "default",
+	}
+
+	tests := []struct {
+		name      string
+		extension AKSExtension
+		wantErr   bool
+	}{
+		{
+			name: "mutable fields can be updated",
+			extension: AKSExtension{
+				Name:                  "flux",
+				ExtensionType:         "microsoft.flux",
+				Version:               ptr.To("1.7.5"),
+				Scope:                 oldExtension.Scope,
+				ConfigurationSettings: map[string]string{"helm-controller.enabled": "false"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "extension type cannot be updated",
+			extension: AKSExtension{
+				Name:          "flux",
+				ExtensionType: "microsoft.dapr",
+				Scope:         oldExtension.Scope,
+			},
+			wantErr: true,
+		},
+		{
+			name: "scope cannot be updated",
+			extension: AKSExtension{
+				Name:          "flux",
+				ExtensionType: "microsoft.flux",
+				Scope: &ExtensionScope{
+					ScopeType:        ExtensionScopeTypeCluster,
+					ReleaseNamespace: "gitops",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "an extension can be replaced by a new one",
+			extension: AKSExtension{
+				Name:          "dapr",
+				ExtensionType: "microsoft.dapr",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			old := getKnownValidAzureManagedControlPlane()
+			old.Spec.Extensions = []AKSExtension{oldExtension}
+			m := getKnownValidAzureManagedControlPlane()
+			m.Spec.Extensions = []AKSExtension{tc.extension}
+			errs := m.validateExtensionsUpdate(old)
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateNetworkProfile(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           func(spec *AzureManagedControlPlaneSpec)
+		clusterNetwork *clusterv1.ClusterNetwork
+		wantErr        bool
+	}{
+		{
+			name:    "no network profile settings",
+			spec:    func(spec *AzureManagedControlPlaneSpec) {},
+			wantErr: false,
+		},
+		{
+			name: "cilium dataplane with azure network plugin and cilium network policy",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.NetworkPlugin = ptr.To("azure")
+				spec.NetworkPluginMode = ptr.To(NetworkPluginModeOverlay)
+				spec.NetworkDataplane = ptr.To(NetworkDataplaneTypeCilium)
+				spec.NetworkPolicy = ptr.To("cilium")
+			},
+			wantErr: false,
+		},
+		{
+			name: "cilium dataplane with kubenet network plugin",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.NetworkPlugin = ptr.To("kubenet")
+				spec.NetworkDataplane = ptr.To(NetworkDataplaneTypeCilium)
+			},
+			wantErr: true,
+		},
+		{
+			name: "cilium dataplane with calico network policy",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.NetworkPlugin = ptr.To("azure")
+				spec.NetworkDataplane = ptr.To(NetworkDataplaneTypeCilium)
+				spec.NetworkPolicy = ptr.To("calico")
+			},
+			wantErr: true,
+		},
+		{
+			name: "cilium network policy without cilium dataplane",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.NetworkPlugin = ptr.To("azure")
+				spec.NetworkPolicy = ptr.To("cilium")
+			},
+			wantErr: true,
+		},
+		{
+			name: "dual-stack IP families",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.IPFamilies = []IPFamily{IPFamilyIPv4, IPFamilyIPv6}
+			},
+			wantErr: false,
+		},
+		{
+			name: "IPv6 only",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.IPFamilies = []IPFamily{IPFamilyIPv6}
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate IP families",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.IPFamilies = []IPFamily{IPFamilyIPv4, IPFamilyIPv4}
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod and service CIDRs outside of the virtual network",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.CIDRBlock = "10.224.0.0/12"
+				spec.PodCIDR = ptr.To("192.168.0.0/16")
+				spec.ServiceCIDR = ptr.To("172.16.0.0/16")
+			},
+			wantErr: false,
+		},
+		{
+			name: "pod CIDR overlaps with the virtual network",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.CIDRBlock = "10.0.0.0/8"
+				spec.PodCIDR = ptr.To("10.244.0.0/16")
+			},
+			wantErr: true,
+		},
+		{
+			name: "service CIDR overlaps with the virtual network",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.CIDRBlock = "10.224.0.0/12"
+				spec.ServiceCIDR = ptr.To("10.0.0.0/8")
+			},
+			wantErr: true,
+		},
+		{
+			name: "service CIDR overlaps with the pod CIDR",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.PodCIDR = ptr.To("192.168.0.0/16")
+				spec.ServiceCIDR = ptr.To("192.168.128.0/24")
+			},
+			wantErr: true,
+		},
+		{
+			name: "cluster pod CIDR overlaps with the virtual network",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.CIDRBlock = "10.0.0.0/8"
+			},
+			clusterNetwork: &clusterv1.ClusterNetwork{
+				Pods: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.244.0.0/16"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cluster service CIDR overlaps with the pod CIDR",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.PodCIDR = ptr.To("192.168.0.0/16")
+			},
+			clusterNetwork: &clusterv1.ClusterNetwork{
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.128.0/24"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cluster CIDRs overridden by pod and service CIDRs outside of the virtual network",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.CIDRBlock = "10.0.0.0/8"
+				spec.PodCIDR = ptr.To("192.168.0.0/16")
+				spec.ServiceCIDR = ptr.To("172.16.0.0/16")
+			},
+			clusterNetwork: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.244.0.0/16"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/16"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid pod CIDR",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.PodCIDR = ptr.To("192.168.0.0")
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod CIDR with dual-stack",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.IPFamilies = []IPFamily{IPFamilyIPv4, IPFamilyIPv6}
+				spec.PodCIDR = ptr.To("192.168.0.0/16")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			m := getKnownValidAzureManagedControlPlane()
+			tc.spec(&m.Spec)
+			var cli client.Client
+			if tc.clusterNetwork != nil {
+				cluster := &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+					Spec:       clusterv1.ClusterSpec{ClusterNetwork: tc.clusterNetwork},
+				}
+				m.Namespace = "default"
+				m.Labels = map[string]string{clusterv1.ClusterNameLabel: "my-cluster"}
+				scheme := runtime.NewScheme()
+				_ = clusterv1.AddToScheme(scheme)
+				cli = fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cluster).Build()
+			}
+			err := m.validateNetworkProfile(cli)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidatePodSubnets(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    func(spec *AzureManagedControlPlaneSpec)
+		wantErr bool
+	}{
+		{
+			name:    "no pod subnets",
+			spec:    func(spec *AzureManagedControlPlaneSpec) {},
+			wantErr: false,
+		},
+		{
+			name: "valid pod subnets",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{
+					{Name: "pods1", CIDRBlock: "10.241.0.0/16"},
+					{Name: "pods2", CIDRBlock: "10.242.0.0/16"},
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "pod subnets with kubenet",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{{Name: "pods", CIDRBlock: "10.241.0.0/16"}}
+				spec.NetworkPlugin = ptr.To("kubenet")
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod subnets with overlay",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{{Name: "pods", CIDRBlock: "10.241.0.0/16"}}
+				spec.NetworkPluginMode = ptr.To(NetworkPluginModeOverlay)
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod subnet with the name of the node subnet",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{{Name: "nodes", CIDRBlock: "10.241.0.0/16"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod subnet outside of the virtual network",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{{Name: "pods", CIDRBlock: "192.168.0.0/16"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod subnet larger than the virtual network",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.CIDRBlock = "10.0.0.0/16"
+				spec.VirtualNetwork.Subnet.CIDRBlock = "192.168.0.0/24"
+				spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{{Name: "pods", CIDRBlock: "10.0.0.0/8"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod subnet overlaps with the node subnet",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{{Name: "pods", CIDRBlock: "10.240.0.0/24"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod subnets overlap",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{
+					{Name: "pods1", CIDRBlock: "10.241.0.0/16"},
+					{Name: "pods2", CIDRBlock: "10.241.128.0/17"},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid pod subnet CIDR",
+			spec: func(spec *AzureManagedControlPlaneSpec) {
+				spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{{Name: "pods", CIDRBlock: "10.241.0.0"}}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			m := getKnownValidAzureManagedControlPlane()
+			m.Spec.NetworkPlugin = ptr.To("azure")
+			m.Spec.VirtualNetwork = ManagedControlPlaneVirtualNetwork{
+				Name:      "vnet",
+				CIDRBlock: "10.0.0.0/8",
+				Subnet: ManagedControlPlaneSubnet{
+					Name:      "nodes",
+					CIDRBlock: "10.240.0.0/16",
+				},
+			}
+			tc.spec(&m.Spec)
+			err := m.validatePodSubnets(nil)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidatePodSubnetsUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	old := getKnownValidAzureManagedControlPlane()
+	old.Spec.VirtualNetwork.PodSubnets = []ManagedControlPlanePodSubnet{
+		{Name: "pods1", CIDRBlock: "10.241.0.0/16"},
+	}
+
+	m := old.DeepCopy()
+	m.Spec.VirtualNetwork.PodSubnets = append(m.Spec.VirtualNetwork.PodSubnets, ManagedControlPlanePodSubnet{Name: "pods2", CIDRBlock: "10.242.0.0/16"})
+	g.Expect(m.validateVirtualNetworkUpdate(old)).To(BeEmpty())
+
+	m.Spec.VirtualNetwork.PodSubnets[0].CIDRBlock = "10.243.0.0/16"
+	g.Expect(m.validateVirtualNetworkUpdate(old)).To(HaveLen(1))
+}
+
+func TestValidateSecurityProfile(t *testing.T) {
+	tests := []struct {
+		name              string
+		oidcIssuerEnabled bool
+		profile           *ManagedClusterSecurityProfile
+		wantErr           bool
+	}{
+		{
+			name:    "no security profile",
+			profile: nil,
+			wantErr: false,
+		},
+		{
+			name:              "all security features enabled",
+			oidcIssuerEnabled: true,
+			profile: &ManagedClusterSecurityProfile{
+				AzureKeyVaultKms: &AzureKeyVaultKms{
+					Enabled:               true,
+					KeyID:                 "https://my-vault.vault.azure.net/keys/my-key/1",
+					KeyVaultNetworkAccess: ptr.To(KeyVaultNetworkAccessTypesPrivate),
+					KeyVaultResourceID:    ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.KeyVault/vaults/my-vault"),
+				},
+				Defender: &ManagedClusterSecurityProfileDefender{
+					LogAnalyticsWorkspaceResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+					SecurityMonitoring:              ManagedClusterSecurityProfileDefenderSecurityMonitoring{Enabled: true},
+				},
+				ImageCleaner:     &ManagedClusterSecurityProfileImageCleaner{Enabled: true, IntervalHours: ptr.To[int32](48)},
+				WorkloadIdentity: &ManagedClusterSecurityProfileWorkloadIdentity{Enabled: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:              "workload identity without OIDC issuer",
+			oidcIssuerEnabled: false,
+			profile: &ManagedClusterSecurityProfile{
+				WorkloadIdentity: &ManagedClusterSecurityProfileWorkloadIdentity{Enabled: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "disabled workload identity without OIDC issuer",
+			profile: &ManagedClusterSecurityProfile{
+				WorkloadIdentity: &ManagedClusterSecurityProfileWorkloadIdentity{Enabled: false},
+			},
+			wantErr: false,
+		},
+		{
+			name: "KMS without key ID",
+			profile: &ManagedClusterSecurityProfile{
+				AzureKeyVaultKms: &AzureKeyVaultKms{Enabled: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "private KMS without key vault resource ID",
+			profile: &ManagedClusterSecurityProfile{
+				AzureKeyVaultKms: &AzureKeyVaultKms{
+					Enabled:               true,
+					KeyID:                 "https://my-vault.vault.azure.net/keys/my-key/1",
+					KeyVaultNetworkAccess: ptr.To(KeyVaultNetworkAccessTypesPrivate),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "public KMS with key vault resource ID",
+			profile: &ManagedClusterSecurityProfile{
+				AzureKeyVaultKms: &AzureKeyVaultKms{
+					Enabled:            true,
+					KeyID:              "https://my-vault.vault.azure.net/keys/my-key/1",
+					KeyVaultResourceID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.KeyVault/vaults/my-vault"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "defender without Log Analytics workspace",
+			profile: &ManagedClusterSecurityProfile{
+				Defender: &ManagedClusterSecurityProfileDefender{
+					SecurityMonitoring: ManagedClusterSecurityProfileDefenderSecurityMonitoring{Enabled: true},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			m := getKnownValidAzureManagedControlPlane()
+			m.Spec.OIDCIssuerProfile = &OIDCIssuerProfile{Enabled: ptr.To(tc.oidcIssuerEnabled)}
+			m.Spec.SecurityProfile = tc.profile
+			err := m.validateSecurityProfile(nil)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateSecurityProfileUpdate(t *testing.T) {
+	oldProfile := &ManagedClusterSecurityProfile{
+		ImageCleaner:     &ManagedClusterSecurityProfileImageCleaner{Enabled: true},
+		WorkloadIdentity: &ManagedClusterSecurityProfileWorkloadIdentity{Enabled: true},
+	}
+	tests := []struct {
+		name    string
+		profile *ManagedClusterSecurityProfile
+		wantErr bool
+	}{
+		{
+			name: "security features can be disabled",
+			profile: &ManagedClusterSecurityProfile{
+				ImageCleaner:     &ManagedClusterSecurityProfileImageCleaner{Enabled: false},
+				WorkloadIdentity: &ManagedClusterSecurityProfileWorkloadIdentity{Enabled: false},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security features can be added",
+			profile: &ManagedClusterSecurityProfile{
+				AzureKeyVaultKms: &AzureKeyVaultKms{
+					Enabled: true,
+					KeyID:   "https://my-vault.vault.azure.net/keys/my-key/1",
+				},
+				ImageCleaner:     &ManagedClusterSecurityProfileImageCleaner{Enabled: true, IntervalHours: ptr.To[int32](24)},
+				WorkloadIdentity: &ManagedClusterSecurityProfileWorkloadIdentity{Enabled: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security feature cannot be removed",
+			profile: &ManagedClusterSecurityProfile{
+				WorkloadIdentity: &ManagedClusterSecurityProfileWorkloadIdentity{Enabled: true},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "security profile cannot be removed",
+			profile: nil,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			old := getKnownValidAzureManagedControlPlane()
+			old.Spec.SecurityProfile = oldProfile
+			m := getKnownValidAzureManagedControlPlane()
+			m.Spec.SecurityProfile = tc.profile
+			errs := m.validateSecurityProfileUpdate(old)
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func createAzureManagedControlPlane(serviceIP, version, sshKey string) *AzureManagedControlPlane {
+	return &AzureManagedControlPlane{
+		ObjectMeta: getAMCPMetaData(),
+		Spec: AzureManagedControlPlaneSpec{
+			SSHPublicKey: &sshKey,
+			DNSServiceIP: ptr.To(serviceIP),
+			Version:      version,
+		},
+	}
+}
+
+func getKnownValidAzureManagedControlPlane() *AzureManagedControlPlane {
+	return &AzureManagedControlPlane{
+		ObjectMeta: getAMCPMetaData(),
+		Spec: AzureManagedControlPlaneSpec{
+			DNSServiceIP: ptr.To("192.168.0.10"),
+			Version:      "v1.18.0",
+			SSHPublicKey: ptr.To(generateSSHPublicKey(true)),
+			AADProfile: &AADProfile{
+				Managed: true,
+				AdminGroupObjectIDs: []string{
+					"616077a8-5db7-4c98-b856-b34619afg75h",
+				},
+			},
+		},
+	}
+}
+
+func getAMCPMetaData() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name: "test-AMCP",
+		Labels: map[string]string{
+			"cluster.x-k8s.io/cluster-name": "test-cluster",
+		},
+		Namespace: "default",
+	}
+}
+
+func TestValidateDisableLocalAccounts(t *testing.T) {
+	tests := []struct {
+		name                 string
+		aadProfile           *AADProfile
+		disableLocalAccounts *bool
+		wantErr              bool
+	}{
+		{
+			name:                 "local accounts not disabled",
+			aadProfile:           nil,
+			disableLocalAccounts: nil,
+			wantErr:              false,
+		},
+		{
+			name:                 "local accounts explicitly enabled without AAD",
+			aadProfile:           nil,
+			disableLocalAccounts: ptr.To(false),
+			wantErr:              false,
+		},
+		{
+			name:                 "local accounts disabled with managed AAD",
+			aadProfile:           &AADProfile{Managed: true, AdminGroupObjectIDs: []string{"616077a8-5db7-4c98-b856-b34619afg75h"}},
+			disableLocalAccounts: ptr.To(true),
+			wantErr:              false,
+		},
+		{
+			name:                 "local accounts disabled without AAD",
+			aadProfile:           nil,
+			disableLocalAccounts: ptr.To(true),
+			wantErr:              true,
+		},
+		{
+			name:                 "local accounts disabled without managed AAD",
+			aadProfile:           &AADProfile{Managed: false},
+			disableLocalAccounts: ptr.To(true),
+			wantErr:              true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			m := getKnownValidAzureManagedControlPlane()
+			m.Spec.AADProfile = tc.aadProfile
+			m.Spec.DisableLocalAccounts = tc.disableLocalAccounts
+			err := m.validateDisableLocalAccounts(nil)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateMonitoring(t *testing.T) {
+	tests := []struct {
+		name          string
+		monitoring    *ManagedClusterMonitoring
+		addonProfiles []AddonProfile
+		wantErr       bool
+	}{
+		{
+			name:       "no monitoring",
+			monitoring: nil,
+			wantErr:    false,
+		},
+		{
+			name: "valid Log Analytics workspace",
+			monitoring: &ManagedClusterMonitoring{
+				LogAnalyticsWorkspaceResourceID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace"),
+				ManagedPrometheus:               &ManagedPrometheus{Enabled: true},
+			},
+			addonProfiles: []AddonProfile{{Name: "azurepolicy", Enabled: true}},
+			wantErr:       false,
+		},
+		{
+			name: "invalid Log Analytics workspace resource ID",
+			monitoring: &ManagedClusterMonitoring{
+				LogAnalyticsWorkspaceResourceID: ptr.To("my-workspace"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "resource ID of another resource type",
+			monitoring: &ManagedClusterMonitoring{
+				LogAnalyticsWorkspaceResourceID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Insights/components/my-component"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "omsagent add-on is also configured",
+			monitoring: &ManagedClusterMonitoring{
+				LogAnalyticsWorkspaceResourceID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace"),
+			},
+			addonProfiles: []AddonProfile{{Name: "omsagent", Enabled: true}},
+			wantErr:       true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			m := getKnownValidAzureManagedControlPlane()
+			m.Spec.Monitoring = tc.monitoring
+			m.Spec.AddonProfiles = tc.addonProfiles
+			err := m.validateMonitoring(nil)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
 	}
 }
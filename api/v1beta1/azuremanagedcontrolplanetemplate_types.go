@@ -0,0 +1,249 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AzureManagedControlPlaneTemplateSpec defines the desired state of AzureManagedControlPlaneTemplate.
+type AzureManagedControlPlaneTemplateSpec struct {
+	Template AzureManagedControlPlaneTemplateResource `json:"template"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=azuremanagedcontrolplanetemplates,scope=Namespaced,categories=cluster-api,shortName=amcpt
+// +kubebuilder:storageversion
+
+// AzureManagedControlPlaneTemplate is the Schema for the AzureManagedControlPlaneTemplates API.
+type AzureManagedControlPlaneTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AzureManagedControlPlaneTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureManagedControlPlaneTemplateList contains a list of AzureManagedControlPlaneTemplates.
+type AzureManagedControlPlaneTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureManagedControlPlaneTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AzureManagedControlPlaneTemplate{}, &AzureManagedControlPlaneTemplateList{})
+}
+
+// AzureManagedControlPlaneTemplateResource describes the data needed to create an AzureManagedControlPlane from a template.
+type AzureManagedControlPlaneTemplateResource struct {
+	Spec AzureManagedControlPlaneTemplateResourceSpec `json:"spec"`
+}
+
+// AzureManagedControlPlaneTemplateResourceSpec specifies an Azure managed control plane template resource.
+// It holds the fields of an AzureManagedControlPlaneSpec that can be shared across clusters. Fields that identify a
+// single cluster, such as resourceGroupName, nodeResourceGroupName, subscriptionID, sshPublicKey and
+// controlPlaneEndpoint, must be set on the AzureManagedControlPlane itself.
+type AzureManagedControlPlaneTemplateResourceSpec struct {
+	// Version defines the desired Kubernetes version.
+	// When using a ClusterClass, the version is set from the Cluster topology.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// VirtualNetwork describes the vnet for the AKS cluster. Will be created if it does not exist.
+	// Immutable except for `subnet`.
+	// +optional
+	VirtualNetwork ManagedControlPlaneVirtualNetwork `json:"virtualNetwork,omitempty"`
+
+	// Location is a string matching one of the canonical Azure region names. Examples: "westus2", "eastus".
+	// Immutable.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// AdditionalTags is an optional set of tags to add to Azure resources managed by the Azure provider, in addition to the
+	// ones added by default.
+	// +optional
+	AdditionalTags Tags `json:"additionalTags,omitempty"`
+
+	// NetworkPlugin used for building Kubernetes network.
+	// Allowed values are "azure", "kubenet".
+	// Immutable.
+	// +kubebuilder:validation:Enum=azure;kubenet
+	// +optional
+	NetworkPlugin *string `json:"networkPlugin,omitempty"`
+
+	// NetworkPluginMode is the mode the network plugin should use.
+	// Allowed value is "overlay".
+	// +kubebuilder:validation:Enum=overlay
+	// +optional
+	NetworkPluginMode *NetworkPluginMode `json:"networkPluginMode,omitempty"`
+
+	// NetworkPolicy used for building Kubernetes network.
+	// Allowed values are "azure", "calico", "cilium".
+	// "cilium" requires NetworkDataplane to be "cilium".
+	// Immutable.
+	// +kubebuilder:validation:Enum=azure;calico;cilium
+	// +optional
+	NetworkPolicy *string `json:"networkPolicy,omitempty"`
+
+	// NetworkDataplane is the dataplane used for building the Kubernetes network.
+	// Allowed values are "azure", "cilium".
+	// "cilium" requires the "azure" NetworkPlugin.
+	// Immutable.
+	// +kubebuilder:validation:Enum=azure;cilium
+	// +optional
+	NetworkDataplane *NetworkDataplaneType `json:"networkDataplane,omitempty"`
+
+	// PodCIDR is the CIDR block from which pod IPs are assigned when using kubenet or the "overlay" NetworkPluginMode.
+	// Takes precedence over the pod CIDR block of the Cluster's clusterNetwork, and must not overlap with the
+	// virtual network CIDR block.
+	// Immutable.
+	// +optional
+	PodCIDR *string `json:"podCIDR,omitempty"`
+
+	// ServiceCIDR is the CIDR block from which Kubernetes service cluster IPs are assigned.
+	// Takes precedence over the service CIDR block of the Cluster's clusterNetwork, and must not overlap with the
+	// virtual network CIDR block.
+	// Immutable.
+	// +optional
+	ServiceCIDR *string `json:"serviceCIDR,omitempty"`
+
+	// IPFamilies are the IP families used by the cluster. Use ["IPv4"] for single-stack or ["IPv4", "IPv6"]
+	// for dual-stack networking. Defaults to single-stack IPv4 when not set.
+	// Immutable.
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=set
+	// +optional
+	IPFamilies []IPFamily `json:"ipFamilies,omitempty"`
+
+	// Outbound configuration used by Nodes.
+	// Immutable.
+	// +kubebuilder:validation:Enum=loadBalancer;managedNATGateway;userAssignedNATGateway;userDefinedRouting
+	// +optional
+	OutboundType *ManagedControlPlaneOutboundType `json:"outboundType,omitempty"`
+
+	// DNSServiceIP is an IP address assigned to the Kubernetes DNS service.
+	// It must be within the Kubernetes service address range specified in serviceCidr.
+	// Immutable.
+	// +optional
+	DNSServiceIP *string `json:"dnsServiceIP,omitempty"`
+
+	// LoadBalancerSKU is the SKU of the loadBalancer to be provisioned.
+	// Immutable.
+	// +kubebuilder:validation:Enum=Basic;Standard
+	// +optional
+	LoadBalancerSKU *string `json:"loadBalancerSKU,omitempty"`
+
+	// IdentityRef is a reference to a AzureClusterIdentity to be used when reconciling this cluster
+	// +optional
+	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
+
+	// AadProfile is Azure Active Directory configuration to integrate with AKS for aad authentication.
+	// +optional
+	AADProfile *AADProfile `json:"aadProfile,omitempty"`
+
+	// DisableLocalAccounts disables the static local accounts of the cluster when set to true, so the cluster can
+	// only be accessed with AAD credentials. It requires AADProfile to be set.
+	// The kubeconfig secret of the cluster then authenticates with a token of the cluster identity.
+	// +optional
+	DisableLocalAccounts *bool `json:"disableLocalAccounts,omitempty"`
+
+	// AddonProfiles are the profiles of managed cluster add-on.
+	// +optional
+	AddonProfiles []AddonProfile `json:"addonProfiles,omitempty"`
+
+	// SKU is the SKU of the AKS to be provisioned.
+	// +optional
+	SKU *AKSSku `json:"sku,omitempty"`
+
+	// LoadBalancerProfile is the profile of the cluster load balancer.
+	// +optional
+	LoadBalancerProfile *LoadBalancerProfile `json:"loadBalancerProfile,omitempty"`
+
+	// APIServerAccessProfile is the access profile for AKS API server.
+	// Immutable except for `authorizedIPRanges`.
+	// +optional
+	APIServerAccessProfile *APIServerAccessProfile `json:"apiServerAccessProfile,omitempty"`
+
+	// AutoscalerProfile is the parameters to be applied to the cluster-autoscaler when enabled
+	// +optional
+	AutoScalerProfile *AutoScalerProfile `json:"autoscalerProfile,omitempty"`
+
+	// AzureEnvironment is the name of the AzureCloud to be used.
+	// The default value that would be used by most users is "AzurePublicCloud", other values are:
+	// - ChinaCloud: "AzureChinaCloud"
+	// - PublicCloud: "AzurePublicCloud"
+	// - USGovernmentCloud: "AzureUSGovernmentCloud"
+	// +optional
+	AzureEnvironment string `json:"azureEnvironment,omitempty"`
+
+	// Identity configuration used by the AKS control plane.
+	// +optional
+	Identity *Identity `json:"identity,omitempty"`
+
+	// KubeletUserAssignedIdentity is the user-assigned identity for kubelet.
+	// For authentication with Azure Container Registry.
+	// +optional
+	KubeletUserAssignedIdentity string `json:"kubeletUserAssignedIdentity,omitempty"`
+
+	// HTTPProxyConfig is the HTTP proxy configuration for the cluster.
+	// Immutable.
+	// +optional
+	HTTPProxyConfig *HTTPProxyConfig `json:"httpProxyConfig,omitempty"`
+
+	// OIDCIssuerProfile is the OIDC issuer profile of the Managed Cluster.
+	// +optional
+	OIDCIssuerProfile *OIDCIssuerProfile `json:"oidcIssuerProfile,omitempty"`
+
+	// SecurityProfile defines the security profile of the Managed Cluster.
+	// +optional
+	SecurityProfile *ManagedClusterSecurityProfile `json:"securityProfile,omitempty"`
+
+	// Monitoring configures the Azure Monitor integration of the Managed Cluster.
+	// +optional
+	Monitoring *ManagedClusterMonitoring `json:"monitoring,omitempty"`
+
+	// MaintenanceConfigurations are the planned maintenance windows of the Managed Cluster.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	MaintenanceConfigurations []MaintenanceConfiguration `json:"maintenanceConfigurations,omitempty"`
+
+	// AutoUpgradeProfile defines the auto-upgrade configuration of the Managed Cluster.
+	// Channels which are not set are left unchanged on the Managed Cluster.
+	// +optional
+	AutoUpgradeProfile *ManagedClusterAutoUpgradeProfile `json:"autoUpgradeProfile,omitempty"`
+
+	// Extensions are the cluster extensions, such as Flux or Dapr, installed on the Managed Cluster.
+	// An extension which is removed from this list is uninstalled from the Managed Cluster.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []AKSExtension `json:"extensions,omitempty"`
+
+	// DriftMode controls what happens when the AKS cluster or one of its agent pools differs from its spec, for
+	// example after it was changed outside of CAPZ.
+	// "Correct" updates the cluster and its agent pools to match their spec.
+	// "Report" leaves them as they are. In both modes, the fields which differ are listed by the DriftDetected
+	// condition of the AzureManagedControlPlane and AzureManagedMachinePools.
+	// When not set, it defaults to "Correct".
+	// +kubebuilder:validation:Enum=Correct;Report
+	// +optional
+	DriftMode *DriftMode `json:"driftMode,omitempty"`
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/topology"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupAzureManagedControlPlaneTemplateWebhookWithManager sets up and registers the webhook with the manager.
+func SetupAzureManagedControlPlaneTemplateWebhookWithManager(mgr ctrl.Manager) error {
+	mcpw := &azureManagedControlPlaneTemplateWebhook{Client: mgr.GetClient()}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&AzureManagedControlPlaneTemplate{}).
+		WithDefaulter(mcpw).
+		WithValidator(mcpw).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremanagedcontrolplanetemplate,mutating=true,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=azuremanagedcontrolplanetemplates,verbs=create;update,versions=v1beta1,name=default.azuremanagedcontrolplanetemplates.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// azureManagedControlPlaneTemplateWebhook implements a validating and defaulting webhook for AzureManagedControlPlaneTemplate.
+type azureManagedControlPlaneTemplateWebhook struct {
+	Client client.Client
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (mcpw *azureManagedControlPlaneTemplateWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	mcp, ok := obj.(*AzureManagedControlPlaneTemplate)
+	if !ok {
+		return apierrors.NewBadRequest("expected an AzureManagedControlPlaneTemplate")
+	}
+
+	// The defaults are applied to a control plane without a name or resource group, so that values derived
+	// from them (node resource group, virtual network and subnet names) are left for each
+	// AzureManagedControlPlane to default on its own. The SSH public key is not generated for the same reason.
+	m := mcp.toAzureManagedControlPlane()
+	m.setDefaultNetworkPlugin()
+	m.setDefaultLoadBalancerSKU()
+	m.setDefaultVersion()
+	m.setDefaultIdentity()
+	m.setDefaultVirtualNetwork()
+	m.setDefaultSubnet()
+	m.setDefaultSku()
+	m.setDefaultAutoScalerProfile()
+	m.setDefaultOIDCIssuerProfile()
+	mcp.setFromAzureManagedControlPlane(m)
+
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuremanagedcontrolplanetemplate,mutating=false,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=azuremanagedcontrolplanetemplates,versions=v1beta1,name=validation.azuremanagedcontrolplanetemplates.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (mcpw *azureManagedControlPlaneTemplateWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	mcp, ok := obj.(*AzureManagedControlPlaneTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureManagedControlPlaneTemplate")
+	}
+	// NOTE: AzureManagedControlPlaneTemplate relies upon MachinePools, which is behind a feature gate flag.
+	// The webhook must prevent creating new objects in case the feature flag is disabled.
+	if !feature.Gates.Enabled(capifeature.MachinePool) {
+		return nil, field.Forbidden(
+			field.NewPath("spec"),
+			"can be set only if the Cluster API 'MachinePool' feature flag is enabled",
+		)
+	}
+
+	return nil, mcp.validateManagedControlPlaneTemplate(mcpw.Client)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (mcpw *azureManagedControlPlaneTemplateWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*AzureManagedControlPlaneTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureManagedControlPlaneTemplate")
+	}
+	mcp, ok := newObj.(*AzureManagedControlPlaneTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureManagedControlPlaneTemplate")
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a admission.Request inside context: %v", err))
+	}
+
+	var allErrs field.ErrorList
+	if !topology.ShouldSkipImmutabilityChecks(req, mcp) {
+		// A template is subject to the same immutability rules as the control planes created from it.
+		allErrs = mcp.toAzureManagedControlPlane().validateImmutableFields(old.toAzureManagedControlPlane())
+	}
+
+	if len(allErrs) == 0 {
+		return nil, mcp.validateManagedControlPlaneTemplate(mcpw.Client)
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("AzureManagedControlPlaneTemplate").GroupKind(), mcp.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (mcpw *azureManagedControlPlaneTemplateWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateManagedControlPlaneTemplate validates an AzureManagedControlPlaneTemplate and returns an aggregate error.
+func (mcp *AzureManagedControlPlaneTemplate) validateManagedControlPlaneTemplate(cli client.Client) error {
+	m := mcp.toAzureManagedControlPlane()
+
+	validators := []func(client client.Client) error{
+		m.validateLoadBalancerProfile,
+		m.validateAPIServerAccessProfile,
+		m.validateAutoScalerProfile,
+		m.validateIdentity,
+		m.validateNetworkPluginMode,
+		m.validateNetworkProfile,
+		m.validatePodSubnets,
+		m.validateSecurityProfile,
+		m.validateDisableLocalAccounts,
+		m.validateMonitoring,
+		m.validateMaintenanceConfigurations,
+		m.validateExtensions,
+	}
+	// The version is usually set from the Cluster topology rather than in the template.
+	if m.Spec.Version != "" {
+		validators = append(validators, m.validateVersion)
+	}
+
+	var errs []error
+	for _, validator := range validators {
+		if err := validator(cli); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// toAzureManagedControlPlane returns an AzureManagedControlPlane carrying the spec of the template, so the
+// AzureManagedControlPlane defaulting and validation can be applied to it.
+func (mcp *AzureManagedControlPlaneTemplate) toAzureManagedControlPlane() *AzureManagedControlPlane {
+	spec := mcp.Spec.Template.Spec
+	return &AzureManagedControlPlane{
+		Spec: AzureManagedControlPlaneSpec{
+			Version:                     spec.Version,
+			VirtualNetwork:              spec.VirtualNetwork,
+			Location:                    spec.Location,
+			AdditionalTags:              spec.AdditionalTags,
+			NetworkPlugin:               spec.NetworkPlugin,
+			NetworkPluginMode:           spec.NetworkPluginMode,
+			NetworkPolicy:               spec.NetworkPolicy,
+			NetworkDataplane:            spec.NetworkDataplane,
+			PodCIDR:                     spec.PodCIDR,
+			ServiceCIDR:                 spec.ServiceCIDR,
+			IPFamilies:                  spec.IPFamilies,
+			OutboundType:                spec.OutboundType,
+			DNSServiceIP:                spec.DNSServiceIP,
+			LoadBalancerSKU:             spec.LoadBalancerSKU,
+			IdentityRef:                 spec.IdentityRef,
+			AADProfile:                  spec.AADProfile,
+			DisableLocalAccounts:        spec.DisableLocalAccounts,
+			AddonProfiles:               spec.AddonProfiles,
+			SKU:                         spec.SKU,
+			LoadBalancerProfile:         spec.LoadBalancerProfile,
+			APIServerAccessProfile:      spec.APIServerAccessProfile,
+			AutoScalerProfile:           spec.AutoScalerProfile,
+			AzureEnvironment:            spec.AzureEnvironment,
+			Identity:                    spec.Identity,
+			KubeletUserAssignedIdentity: spec.KubeletUserAssignedIdentity,
+			HTTPProxyConfig:             spec.HTTPProxyConfig,
+			OIDCIssuerProfile:           spec.OIDCIssuerProfile,
+			SecurityProfile:             spec.SecurityProfile,
+			Monitoring:                  spec.Monitoring,
+			MaintenanceConfigurations:   spec.MaintenanceConfigurations,
+			AutoUpgradeProfile:          spec.AutoUpgradeProfile,
+			Extensions:                  spec.Extensions,
+			DriftMode:                   spec.DriftMode,
+		},
+	}
+}
+
+// setFromAzureManagedControlPlane copies the template fields of an AzureManagedControlPlane spec into the template.
+func (mcp *AzureManagedControlPlaneTemplate) setFromAzureManagedControlPlane(m *AzureManagedControlPlane) {
+	mcp.Spec.Template.Spec = AzureManagedControlPlaneTemplateResourceSpec{
+		Version:                     m.Spec.Version,
+		VirtualNetwork:              m.Spec.VirtualNetwork,
+		Location:                    m.Spec.Location,
+		AdditionalTags:              m.Spec.AdditionalTags,
+		NetworkPlugin:               m.Spec.NetworkPlugin,
+		NetworkPluginMode:           m.Spec.NetworkPluginMode,
+		NetworkPolicy:               m.Spec.NetworkPolicy,
+		NetworkDataplane:            m.Spec.NetworkDataplane,
+		PodCIDR:                     m.Spec.PodCIDR,
+		ServiceCIDR:                 m.Spec.ServiceCIDR,
+		IPFamilies:                  m.Spec.IPFamilies,
+		OutboundType:                m.Spec.OutboundType,
+		DNSServiceIP:                m.Spec.DNSServiceIP,
+		LoadBalancerSKU:             m.Spec.LoadBalancerSKU,
+		IdentityRef:                 m.Spec.IdentityRef,
+		AADProfile:                  m.Spec.AADProfile,
+		DisableLocalAccounts:        m.Spec.DisableLocalAccounts,
+		AddonProfiles:               m.Spec.AddonProfiles,
+		SKU:                         m.Spec.SKU,
+		LoadBalancerProfile:         m.Spec.LoadBalancerProfile,
+		APIServerAccessProfile:      m.Spec.APIServerAccessProfile,
+		AutoScalerProfile:           m.Spec.AutoScalerProfile,
+		AzureEnvironment:            m.Spec.AzureEnvironment,
+		Identity:                    m.Spec.Identity,
+		KubeletUserAssignedIdentity: m.Spec.KubeletUserAssignedIdentity,
+		HTTPProxyConfig:             m.Spec.HTTPProxyConfig,
+		OIDCIssuerProfile:           m.Spec.OIDCIssuerProfile,
+		SecurityProfile:             m.Spec.SecurityProfile,
+		Monitoring:                  m.Spec.Monitoring,
+		MaintenanceConfigurations:   m.Spec.MaintenanceConfigurations,
+		AutoUpgradeProfile:          m.Spec.AutoUpgradeProfile,
+		Extensions:                  m.Spec.Extensions,
+		DriftMode:                   m.Spec.DriftMode,
+	}
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestAzureManagedControlPlaneTemplateDefaultingWebhook(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Logf("Testing amcpt defaulting webhook with no baseline")
+	amcpt := getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+		amcpt.Spec.Template.Spec.Version = "1.17.5"
+	})
+	mcptw := &azureManagedControlPlaneTemplateWebhook{}
+	err := mcptw.Default(context.Background(), amcpt)
+	g.Expect(err).NotTo(HaveOccurred())
+	spec := amcpt.Spec.Template.Spec
+	g.Expect(*spec.NetworkPlugin).To(Equal("azure"))
+	g.Expect(*spec.LoadBalancerSKU).To(Equal("Standard"))
+	g.Expect(spec.Version).To(Equal("v1.17.5"))
+	g.Expect(spec.VirtualNetwork.Name).To(BeEmpty())
+	g.Expect(spec.VirtualNetwork.ResourceGroup).To(BeEmpty())
+	g.Expect(spec.VirtualNetwork.CIDRBlock).To(Equal(defaultAKSVnetCIDR))
+	g.Expect(spec.VirtualNetwork.Subnet.Name).To(BeEmpty())
+	g.Expect(spec.VirtualNetwork.Subnet.CIDRBlock).To(Equal(defaultAKSNodeSubnetCIDR))
+	g.Expect(spec.SKU.Tier).To(Equal(FreeManagedControlPlaneTier))
+	g.Expect(spec.Identity.Type).To(Equal(ManagedControlPlaneIdentityTypeSystemAssigned))
+	g.Expect(*spec.OIDCIssuerProfile.Enabled).To(BeFalse())
+
+	t.Logf("Testing amcpt defaulting webhook with baseline")
+	amcpt = getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+		amcpt.Spec.Template.Spec.NetworkPlugin = ptr.To("kubenet")
+		amcpt.Spec.Template.Spec.LoadBalancerSKU = ptr.To("Basic")
+		amcpt.Spec.Template.Spec.VirtualNetwork.Name = "fooVnetName"
+		amcpt.Spec.Template.Spec.VirtualNetwork.Subnet.Name = "fooSubnetName"
+		amcpt.Spec.Template.Spec.SKU = &AKSSku{Tier: PaidManagedControlPlaneTier}
+		amcpt.Spec.Template.Spec.OIDCIssuerProfile = &OIDCIssuerProfile{Enabled: ptr.To(true)}
+	})
+	err = mcptw.Default(context.Background(), amcpt)
+	g.Expect(err).NotTo(HaveOccurred())
+	spec = amcpt.Spec.Template.Spec
+	g.Expect(*spec.NetworkPlugin).To(Equal("kubenet"))
+	g.Expect(*spec.LoadBalancerSKU).To(Equal("Basic"))
+	g.Expect(spec.VirtualNetwork.Name).To(Equal("fooVnetName"))
+	g.Expect(spec.VirtualNetwork.Subnet.Name).To(Equal("fooSubnetName"))
+	g.Expect(spec.SKU.Tier).To(Equal(PaidManagedControlPlaneTier))
+	g.Expect(*spec.OIDCIssuerProfile.Enabled).To(BeTrue())
+}
+
+func TestAzureManagedControlPlaneTemplateValidateCreate(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, capifeature.MachinePool, true)()
+	tests := []struct {
+		name     string
+		template *AzureManagedControlPlaneTemplate
+		wantErr  bool
+	}{
+		{
+			name:     "valid template",
+			template: getAzureManagedControlPlaneTemplate(),
+			wantErr:  false,
+		},
+		{
+			name: "template without version",
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.Version = ""
+			}),
+			wantErr: false,
+		},
+		{
+			name: "invalid version",
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.Version = "v1.a"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "invalid load balancer profile",
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.LoadBalancerProfile = &LoadBalancerProfile{
+					ManagedOutboundIPs: ptr.To[int32](200),
+				}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "invalid user assigned identity",
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.Identity = &Identity{
+					Type: ManagedControlPlaneIdentityTypeUserAssigned,
+				}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "overlay with kubenet",
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.NetworkPlugin = ptr.To("kubenet")
+				amcpt.Spec.Template.Spec.NetworkPluginMode = ptr.To(NetworkPluginModeOverlay)
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mcptw := &azureManagedControlPlaneTemplateWebhook{}
+			_, err := mcptw.ValidateCreate(context.Background(), tc.template)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureManagedControlPlaneTemplateValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldTemplate *AzureManagedControlPlaneTemplate
+		template    *AzureManagedControlPlaneTemplate
+		dryRun      bool
+		wantErr     bool
+	}{
+		{
+			name:        "no change",
+			oldTemplate: getAzureManagedControlPlaneTemplate(),
+			template:    getAzureManagedControlPlaneTemplate(),
+			wantErr:     false,
+		},
+		{
+			name:        "mutable field changed",
+			oldTemplate: getAzureManagedControlPlaneTemplate(),
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.APIServerAccessProfile = &APIServerAccessProfile{
+					AuthorizedIPRanges: []string{"192.168.0.1/32"},
+				}
+			}),
+			wantErr: false,
+		},
+		{
+			name:        "location changed",
+			oldTemplate: getAzureManagedControlPlaneTemplate(),
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.Location = "westus2"
+			}),
+			wantErr: true,
+		},
+		{
+			name:        "network plugin changed",
+			oldTemplate: getAzureManagedControlPlaneTemplate(),
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.NetworkPlugin = ptr.To("kubenet")
+			}),
+			wantErr: true,
+		},
+		{
+			name:        "virtual network CIDR changed",
+			oldTemplate: getAzureManagedControlPlaneTemplate(),
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.VirtualNetwork.CIDRBlock = "10.1.0.0/16"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "OIDC issuer disabled",
+			oldTemplate: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.OIDCIssuerProfile = &OIDCIssuerProfile{Enabled: ptr.To(true)}
+			}),
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Spec.Template.Spec.OIDCIssuerProfile = &OIDCIssuerProfile{Enabled: ptr.To(false)}
+			}),
+			wantErr: true,
+		},
+		{
+			name:        "immutable field changed during topology dry-run",
+			oldTemplate: getAzureManagedControlPlaneTemplate(),
+			template: getAzureManagedControlPlaneTemplate(func(amcpt *AzureManagedControlPlaneTemplate) {
+				amcpt.Annotations = map[string]string{clusterv1.TopologyDryRunAnnotation: ""}
+				amcpt.Spec.Template.Spec.Location = "westus2"
+			}),
+			dryRun:  true,
+			wantErr: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mcptw := &azureManagedControlPlaneTemplateWebhook{}
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{DryRun: ptr.To(tc.dryRun)}})
+			_, err := mcptw.ValidateUpdate(ctx, tc.oldTemplate, tc.template)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func getAzureManagedControlPlaneTemplate(changes ...func(*AzureManagedControlPlaneTemplate)) *AzureManagedControlPlaneTemplate {
+	input := &AzureManagedControlPlaneTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fooName",
+		},
+		Spec: AzureManagedControlPlaneTemplateSpec{
+			Template: AzureManagedControlPlaneTemplateResource{
+				Spec: AzureManagedControlPlaneTemplateResourceSpec{
+					Location: "fooLocation",
+					Version:  "v1.17.5",
+				},
+			},
+		},
+	}
+
+	for _, change := range changes {
+		change(input)
+	}
+
+	return input
+}
+
+func TestAzureManagedControlPlaneTemplateSpecFields(t *testing.T) {
+	g := NewWithT(t)
+
+	// instanceFields are the AzureManagedControlPlane spec fields which are specific to a single managed cluster and
+	// can't be set from a template.
+	instanceFields := map[string]bool{
+		"ResourceGroupName":     true,
+		"NodeResourceGroupName": true,
+		"SubscriptionID":        true,
+		"ControlPlaneEndpoint":  true,
+		"SSHPublicKey":          true,
+		"PowerState":            true,
+		"AdoptionMode":          true,
+	}
+
+	templateType := reflect.TypeOf(AzureManagedControlPlaneTemplateResourceSpec{})
+	specType := reflect.TypeOf(AzureManagedControlPlaneSpec{})
+	for i := 0; i < specType.NumField(); i++ {
+		specField := specType.Field(i)
+		templateField, ok := templateType.FieldByName(specField.Name)
+		if instanceFields[specField.Name] {
+			g.Expect(ok).To(BeFalse(), "instance field %s must not be part of the template", specField.Name)
+			continue
+		}
+		g.Expect(ok).To(BeTrue(), "field %s is missing from the template, or must be listed as an instance field", specField.Name)
+		g.Expect(templateField.Type).To(Equal(specField.Type), "field %s has a different type in the template", specField.Name)
+	}
+	for i := 0; i < templateType.NumField(); i++ {
+		_, ok := specType.FieldByName(templateType.Field(i).Name)
+		g.Expect(ok).To(BeTrue(), "template field %s is missing from the AzureManagedControlPlane spec", templateType.Field(i).Name)
+	}
+}
+
+func TestAzureManagedControlPlaneTemplateSpecConversion(t *testing.T) {
+	g := NewWithT(t)
+
+	f := fuzz.New().NilChance(0).NumElements(1, 2)
+	for i := 0; i < 10; i++ {
+		template := &AzureManagedControlPlaneTemplate{}
+		f.Fuzz(&template.Spec.Template.Spec)
+
+		// Every field of the template must be carried to the AzureManagedControlPlane.
+		mcp := template.toAzureManagedControlPlane()
+		templateSpec := reflect.ValueOf(template.Spec.Template.Spec)
+		mcpSpec := reflect.ValueOf(mcp.Spec)
+		for j := 0; j < templateSpec.NumField(); j++ {
+			name := templateSpec.Type().Field(j).Name
+			g.Expect(mcpSpec.FieldByName(name).Interface()).To(Equal(templateSpec.Field(j).Interface()), "field %s is not copied to the AzureManagedControlPlane", name)
+		}
+
+		// And back to the template.
+		roundTripped := &AzureManagedControlPlaneTemplate{}
+		roundTripped.setFromAzureManagedControlPlane(mcp)
+		g.Expect(roundTripped.Spec.Template.Spec).To(Equal(template.Spec.Template.Spec))
+	}
+}
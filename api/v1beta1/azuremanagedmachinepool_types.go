@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 )
@@ -35,6 +36,26 @@ const (
 
 	// DefaultOSType represents the default operating system for azmachinepool.
 	DefaultOSType string = LinuxOS
+
+	// LatestNodeImageVersion is the NodeImageVersion which keeps the agent pool on the latest node image version.
+	LatestNodeImageVersion = "latest"
+)
+
+const (
+	// OSSKUUbuntu is the Ubuntu node image for Linux agent pools.
+	OSSKUUbuntu = "Ubuntu"
+
+	// OSSKUAzureLinux is the Azure Linux node image for Linux agent pools.
+	OSSKUAzureLinux = "AzureLinux"
+
+	// OSSKUCBLMariner is the CBL-Mariner node image for Linux agent pools. It is superseded by Azure Linux.
+	OSSKUCBLMariner = "CBLMariner"
+
+	// OSSKUWindows2019 is the Windows Server 2019 node image for Windows agent pools.
+	OSSKUWindows2019 = "Windows2019"
+
+	// OSSKUWindows2022 is the Windows Server 2022 node image for Windows agent pools.
+	OSSKUWindows2022 = "Windows2022"
 )
 
 // NodePoolMode enumerates the values for agent pool mode.
@@ -63,6 +84,22 @@ const (
 	KubeletDiskTypeTemporary KubeletDiskType = "Temporary"
 )
 
+// GPUInstanceProfile enumerates the values for the agent pool's GPUInstanceProfile.
+type GPUInstanceProfile string
+
+const (
+	// GPUInstanceProfileMIG1g ...
+	GPUInstanceProfileMIG1g GPUInstanceProfile = "MIG1g"
+	// GPUInstanceProfileMIG2g ...
+	GPUInstanceProfileMIG2g GPUInstanceProfile = "MIG2g"
+	// GPUInstanceProfileMIG3g ...
+	GPUInstanceProfileMIG3g GPUInstanceProfile = "MIG3g"
+	// GPUInstanceProfileMIG4g ...
+	GPUInstanceProfileMIG4g GPUInstanceProfile = "MIG4g"
+	// GPUInstanceProfileMIG7g ...
+	GPUInstanceProfileMIG7g GPUInstanceProfile = "MIG7g"
+)
+
 const (
 	// TopologyManagerPolicyNone ...
 	TopologyManagerPolicyNone TopologyManagerPolicy = "none"
@@ -505,6 +542,17 @@ type AzureManagedMachinePoolSpec struct {
 	// +optional
 	OSType *string `json:"osType,omitempty"`
 
+	// OSSKU specifies the OS SKU used by the agent pool. Possible values include: 'Ubuntu', 'AzureLinux', 'CBLMariner',
+	// 'Windows2019', 'Windows2022'. 'Ubuntu', 'AzureLinux' and 'CBLMariner' require OSType 'Linux', while 'Windows2019'
+	// and 'Windows2022' require OSType 'Windows'. Defaults to 'Ubuntu' for Linux and 'Windows2019' for Windows on AKS.
+	// Immutable.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://learn.microsoft.com/rest/api/aks/agent-pools/create-or-update?tabs=HTTP#ossku
+	// +kubebuilder:validation:Enum=Ubuntu;AzureLinux;CBLMariner;Windows2019;Windows2022
+	// +optional
+	OSSKU *string `json:"osSKU,omitempty"`
+
 	// EnableNodePublicIP controls whether or not nodes in the pool each have a public IP address.
 	// Immutable.
 	// +optional
@@ -557,10 +605,106 @@ type AzureManagedMachinePoolSpec struct {
 	// +optional
 	SubnetName *string `json:"subnetName,omitempty"`
 
+	// PodSubnetName specifies the subnet from which Azure CNI dynamically allocates pod IPs to the nodes of the pool.
+	// It must be one of the AzureManagedControlPlane's `spec.virtualNetwork.podSubnets` when the VNet is managed.
+	// Immutable.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://learn.microsoft.com/azure/aks/configure-azure-cni-dynamic-ip-allocation
+	// +optional
+	PodSubnetName *string `json:"podSubnetName,omitempty"`
+
 	// EnableFIPS indicates whether FIPS is enabled on the node pool.
 	// Immutable.
 	// +optional
 	EnableFIPS *bool `json:"enableFIPS,omitempty"`
+
+	// ProximityPlacementGroupID is the resource ID of the proximity placement group the nodes of the pool are placed in.
+	// Immutable.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://learn.microsoft.com/azure/aks/reduce-latency-ppg
+	// +optional
+	ProximityPlacementGroupID *string `json:"proximityPlacementGroupID,omitempty"`
+
+	// HostGroupID is the resource ID of the dedicated host group the nodes of the pool are provisioned on.
+	// Immutable.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://learn.microsoft.com/azure/aks/use-azure-dedicated-hosts
+	// +optional
+	HostGroupID *string `json:"hostGroupID,omitempty"`
+
+	// CapacityReservationGroupID is the resource ID of the capacity reservation group the nodes of the pool consume
+	// reserved capacity from.
+	// Immutable.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://learn.microsoft.com/azure/aks/manage-node-pools#associate-capacity-reservation-groups-to-node-pools
+	// +optional
+	CapacityReservationGroupID *string `json:"capacityReservationGroupID,omitempty"`
+
+	// GPUInstanceProfile is the Multi-Instance GPU profile used to partition the GPUs of the nodes.
+	// Only supported by GPU VM sizes.
+	// Immutable.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://learn.microsoft.com/azure/aks/gpu-multi-instance
+	// +kubebuilder:validation:Enum=MIG1g;MIG2g;MIG3g;MIG4g;MIG7g
+	// +optional
+	GPUInstanceProfile *GPUInstanceProfile `json:"gpuInstanceProfile,omitempty"`
+
+	// SkipGPUDriverInstall skips the installation of the GPU drivers by AKS, e.g. to let the NVIDIA GPU operator manage
+	// them instead. Only supported by GPU VM sizes, and only honored for agent pools created after the managed cluster.
+	// Immutable.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://learn.microsoft.com/azure/aks/gpu-cluster#skip-gpu-driver-installation-preview
+	// +optional
+	SkipGPUDriverInstall *bool `json:"skipGPUDriverInstall,omitempty"`
+
+	// NodeImageVersion is the desired node image version of the agent pool. AKS can only upgrade an agent pool to the
+	// latest node image version available for it, which is reported in `status.latestNodeImageVersion`. CAPZ upgrades
+	// the node image once the desired version is the latest one and differs from `status.nodeImageVersion`. Until then,
+	// the NodeImageUpgraded condition is false with the NodeImageVersionNotLatest reason.
+	// Set it to `latest` to upgrade the node image whenever a newer one is released.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://learn.microsoft.com/azure/aks/node-image-upgrade
+	// +optional
+	NodeImageVersion *string `json:"nodeImageVersion,omitempty"`
+
+	// UpgradeSettings configures how the nodes of the pool are surged and drained when the agent pool is upgraded.
+	// AKS keeps the current values of the settings which are removed, so they have to be set back explicitly to
+	// restore the AKS defaults.
+	// See also [AKS doc].
+	//
+	// [AKS doc]: https://learn.microsoft.com/azure/aks/upgrade-aks-cluster#customize-node-surge-upgrade
+	// +optional
+	UpgradeSettings *ManagedMachinePoolUpgradeSettings `json:"upgradeSettings,omitempty"`
+}
+
+// ManagedMachinePoolUpgradeSettings specifies the settings used to upgrade the nodes of an agent pool.
+type ManagedMachinePoolUpgradeSettings struct {
+	// MaxSurge is the maximum number of extra nodes created during an upgrade. Value can be an absolute number
+	// (ex: 5) or a percentage of the nodes of the pool at the time of the upgrade (ex: 33%), rounded up.
+	// AKS defaults to 1 when it is not set.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// DrainTimeoutInMinutes is the time to wait for the eviction of the pods of a node, including the ones protected
+	// by pod disruption budgets. The upgrade fails when it is exceeded. AKS defaults to 30 minutes when it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1440
+	// +optional
+	DrainTimeoutInMinutes *int32 `json:"drainTimeoutInMinutes,omitempty"`
+
+	// NodeSoakDurationInMinutes is the time to wait after draining a node and before reimaging it and moving on to the
+	// next node. AKS defaults to 0 minutes when it is not set.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=30
+	// +optional
+	NodeSoakDurationInMinutes *int32 `json:"nodeSoakDurationInMinutes,omitempty"`
 }
 
 // ManagedMachinePoolScaling specifies scaling options.
@@ -598,6 +742,15 @@ type AzureManagedMachinePoolStatus struct {
 	// +optional
 	Replicas int32 `json:"replicas"`
 
+	// NodeImageVersion is the node image version of the agent pool.
+	// +optional
+	NodeImageVersion string `json:"nodeImageVersion,omitempty"`
+
+	// LatestNodeImageVersion is the latest node image version available for the agent pool. It is only reported when
+	// NodeImageVersion is set in the spec.
+	// +optional
+	LatestNodeImageVersion string `json:"latestNodeImageVersion,omitempty"`
+
 	// Any transient errors that occur during the reconciliation of Machines
 	// can be added as events to the Machine object and/or logged in the
 	// controller's output.
@@ -43,6 +43,12 @@ import (
 
 var validNodePublicPrefixID = regexp.MustCompile(`(?i)^/?subscriptions/[0-9a-f]{8}-([0-9a-f]{4}-){3}[0-9a-f]{12}/resourcegroups/[^/]+/providers/microsoft\.network/publicipprefixes/[^/]+$`)
 
+// osSKUsByOSType lists the OS SKUs AKS supports for each OS type.
+var osSKUsByOSType = map[string][]string{
+	LinuxOS:   {OSSKUUbuntu, OSSKUAzureLinux, OSSKUCBLMariner},
+	WindowsOS: {OSSKUWindows2019, OSSKUWindows2022},
+}
+
 // SetupAzureManagedMachinePoolWebhookWithManager sets up and registers the webhook with the manager.
 func SetupAzureManagedMachinePoolWebhookWithManager(mgr ctrl.Manager) error {
 	mw := &azureManagedMachinePoolWebhook{Client: mgr.GetClient()}
@@ -98,9 +104,70 @@ func (mw *azureManagedMachinePoolWebhook) ValidateCreate(ctx context.Context, ob
 			"can be set only if the Cluster API 'MachinePool' feature flag is enabled",
 		)
 	}
+	return nil, kerrors.Flatten(kerrors.NewAggregate([]error{
+		m.validateSpec(),
+		m.validatePodSubnetInControlPlane(mw.Client),
+	}))
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (mw *azureManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*AzureManagedMachinePool)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureManagedMachinePool")
+	}
+	m, ok := newObj.(*AzureManagedMachinePool)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureManagedMachinePool")
+	}
+	allErrs := m.validateImmutableFields(old)
+
+	// custom headers are immutable
+	oldCustomHeaders := maps.FilterByKeyPrefix(old.ObjectMeta.Annotations, CustomHeaderPrefix)
+	newCustomHeaders := maps.FilterByKeyPrefix(m.ObjectMeta.Annotations, CustomHeaderPrefix)
+	if !reflect.DeepEqual(oldCustomHeaders, newCustomHeaders) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("metadata", "annotations"),
+				m.ObjectMeta.Annotations,
+				fmt.Sprintf("annotations with '%s' prefix are immutable", CustomHeaderPrefix)))
+	}
+
+	if m.Spec.Mode != string(NodePoolModeSystem) && old.Spec.Mode == string(NodePoolModeSystem) {
+		// validate for last system node pool
+		if err := m.validateLastSystemNodePool(mw.Client); err != nil {
+			allErrs = append(allErrs, field.Forbidden(
+				field.NewPath("Spec", "Mode"),
+				"Cannot change node pool mode to User, you must have at least one System node pool in your cluster"))
+		}
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("AzureManagedMachinePool").GroupKind(), m.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (mw *azureManagedMachinePoolWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	m, ok := obj.(*AzureManagedMachinePool)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureManagedMachinePool")
+	}
+	if m.Spec.Mode != string(NodePoolModeSystem) {
+		return nil, nil
+	}
+
+	return nil, errors.Wrapf(m.validateLastSystemNodePool(mw.Client), "if the delete is triggered via owner MachinePool please refer to trouble shooting section in https://capz.sigs.k8s.io/topics/managedcluster.html")
+}
+
+// validateSpec validates the AzureManagedMachinePool spec and returns an aggregate error.
+func (m *AzureManagedMachinePool) validateSpec() error {
 	validators := []func() error{
 		m.validateMaxPods,
 		m.validateOSType,
+		m.validateOSSKU,
 		m.validateName,
 		m.validateNodeLabels,
 		m.validateNodePublicIPPrefixID,
@@ -108,6 +175,11 @@ func (mw *azureManagedMachinePoolWebhook) ValidateCreate(ctx context.Context, ob
 		m.validateKubeletConfig,
 		m.validateLinuxOSConfig,
 		m.validateSubnetName,
+		m.validatePodSubnetName,
+		m.validateProximityPlacementGroupID,
+		m.validateHostGroupID,
+		m.validateCapacityReservationGroupID,
+		m.validateUpgradeSettings,
 	}
 
 	var errs []error
@@ -117,19 +189,11 @@ func (mw *azureManagedMachinePoolWebhook) ValidateCreate(ctx context.Context, ob
 		}
 	}
 
-	return nil, kerrors.NewAggregate(errs)
+	return kerrors.NewAggregate(errs)
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (mw *azureManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
-	old, ok := oldObj.(*AzureManagedMachinePool)
-	if !ok {
-		return nil, apierrors.NewBadRequest("expected an AzureManagedMachinePool")
-	}
-	m, ok := newObj.(*AzureManagedMachinePool)
-	if !ok {
-		return nil, apierrors.NewBadRequest("expected an AzureManagedMachinePool")
-	}
+// validateImmutableFields validates that no immutable field of an AzureManagedMachinePool was changed.
+func (m *AzureManagedMachinePool) validateImmutableFields(old *AzureManagedMachinePool) field.ErrorList {
 	var allErrs field.ErrorList
 
 	if err := webhookutils.ValidateImmutable(
@@ -154,6 +218,13 @@ func (mw *azureManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, ol
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "OSSKU"),
+		old.Spec.OSSKU,
+		m.Spec.OSSKU); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "SKU"),
 		old.Spec.SKU,
@@ -175,6 +246,48 @@ func (mw *azureManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, ol
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "PodSubnetName"),
+		old.Spec.PodSubnetName,
+		m.Spec.PodSubnetName); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "ProximityPlacementGroupID"),
+		old.Spec.ProximityPlacementGroupID,
+		m.Spec.ProximityPlacementGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "HostGroupID"),
+		old.Spec.HostGroupID,
+		m.Spec.HostGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "CapacityReservationGroupID"),
+		old.Spec.CapacityReservationGroupID,
+		m.Spec.CapacityReservationGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "GPUInstanceProfile"),
+		old.Spec.GPUInstanceProfile,
+		m.Spec.GPUInstanceProfile); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "SkipGPUDriverInstall"),
+		old.Spec.SkipGPUDriverInstall,
+		m.Spec.SkipGPUDriverInstall); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "EnableFIPS"),
 		old.Spec.EnableFIPS,
@@ -182,17 +295,6 @@ func (mw *azureManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, ol
 		allErrs = append(allErrs, err)
 	}
 
-	// custom headers are immutable
-	oldCustomHeaders := maps.FilterByKeyPrefix(old.ObjectMeta.Annotations, CustomHeaderPrefix)
-	newCustomHeaders := maps.FilterByKeyPrefix(m.ObjectMeta.Annotations, CustomHeaderPrefix)
-	if !reflect.DeepEqual(oldCustomHeaders, newCustomHeaders) {
-		allErrs = append(allErrs,
-			field.Invalid(
-				field.NewPath("metadata", "annotations"),
-				m.ObjectMeta.Annotations,
-				fmt.Sprintf("annotations with '%s' prefix are immutable", CustomHeaderPrefix)))
-	}
-
 	if !webhookutils.EnsureStringSlicesAreEquivalent(m.Spec.AvailabilityZones, old.Spec.AvailabilityZones) {
 		allErrs = append(allErrs,
 			field.Invalid(
@@ -201,15 +303,6 @@ func (mw *azureManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, ol
 				"field is immutable"))
 	}
 
-	if m.Spec.Mode != string(NodePoolModeSystem) && old.Spec.Mode == string(NodePoolModeSystem) {
-		// validate for last system node pool
-		if err := m.validateLastSystemNodePool(mw.Client); err != nil {
-			allErrs = append(allErrs, field.Forbidden(
-				field.NewPath("Spec", "Mode"),
-				"Cannot change node pool mode to User, you must have at least one System node pool in your cluster"))
-		}
-	}
-
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "MaxPods"),
 		old.Spec.MaxPods,
@@ -271,24 +364,7 @@ func (mw *azureManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, ol
 		allErrs = append(allErrs, err)
 	}
 
-	if len(allErrs) != 0 {
-		return nil, apierrors.NewInvalid(GroupVersion.WithKind("AzureManagedMachinePool").GroupKind(), m.Name, allErrs)
-	}
-
-	return nil, nil
-}
-
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
-func (mw *azureManagedMachinePoolWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	m, ok := obj.(*AzureManagedMachinePool)
-	if !ok {
-		return nil, apierrors.NewBadRequest("expected an AzureManagedMachinePool")
-	}
-	if m.Spec.Mode != string(NodePoolModeSystem) {
-		return nil, nil
-	}
-
-	return nil, errors.Wrapf(m.validateLastSystemNodePool(mw.Client), "if the delete is triggered via owner MachinePool please refer to trouble shooting section in https://capz.sigs.k8s.io/topics/managedcluster.html")
+	return allErrs
 }
 
 // validateLastSystemNodePool is used to check if the existing system node pool is the last system node pool.
@@ -362,6 +438,25 @@ func (m *AzureManagedMachinePool) validateOSType() error {
 	return nil
 }
 
+func (m *AzureManagedMachinePool) validateOSSKU() error {
+	if m.Spec.OSSKU == nil {
+		return nil
+	}
+
+	osType := ptr.Deref(m.Spec.OSType, DefaultOSType)
+	allowed := osSKUsByOSType[osType]
+	for _, osSKU := range allowed {
+		if *m.Spec.OSSKU == osSKU {
+			return nil
+		}
+	}
+
+	return field.Invalid(
+		field.NewPath("Spec", "OSSKU"),
+		m.Spec.OSSKU,
+		fmt.Sprintf("OSSKU must be one of %v for OSType %q", allowed, osType))
+}
+
 func (m *AzureManagedMachinePool) validateName() error {
 	if m.Spec.OSType != nil && *m.Spec.OSType == WindowsOS &&
 		m.Spec.Name != nil && len(*m.Spec.Name) > 6 {
@@ -409,17 +504,98 @@ func (m *AzureManagedMachinePool) validateEnableNodePublicIP() error {
 }
 
 func (m *AzureManagedMachinePool) validateSubnetName() error {
-	if m.Spec.SubnetName != nil {
+	return validateSubnetNameField(m.Spec.SubnetName, field.NewPath("Spec", "SubnetName"))
+}
+
+func (m *AzureManagedMachinePool) validatePodSubnetName() error {
+	return validateSubnetNameField(m.Spec.PodSubnetName, field.NewPath("Spec", "PodSubnetName"))
+}
+
+// validatePodSubnetInControlPlane validates that PodSubnetName is one of the pod subnets declared in the VirtualNetwork of
+// the AzureManagedControlPlane. The check is skipped when the AzureManagedControlPlane doesn't exist yet.
+func (m *AzureManagedMachinePool) validatePodSubnetInControlPlane(cli client.Client) error {
+	clusterName, ok := m.Labels[clusterv1.ClusterNameLabel]
+	if m.Spec.PodSubnetName == nil || !ok || cli == nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	ownerCluster := &clusterv1.Cluster{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: m.Namespace, Name: clusterName}, ownerCluster); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	controlPlaneRef := ownerCluster.Spec.ControlPlaneRef
+	if controlPlaneRef == nil || controlPlaneRef.Kind != "AzureManagedControlPlane" {
+		return nil
+	}
+	controlPlane := &AzureManagedControlPlane{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: ownerCluster.Namespace, Name: controlPlaneRef.Name}, controlPlane); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	podSubnetNames := make([]string, 0, len(controlPlane.Spec.VirtualNetwork.PodSubnets))
+	for _, subnet := range controlPlane.Spec.VirtualNetwork.PodSubnets {
+		if subnet.Name == *m.Spec.PodSubnetName {
+			return nil
+		}
+		podSubnetNames = append(podSubnetNames, subnet.Name)
+	}
+	return field.NotSupported(field.NewPath("Spec", "PodSubnetName"), *m.Spec.PodSubnetName, podSubnetNames)
+}
+
+func validateSubnetNameField(name *string, fldPath *field.Path) error {
+	if name != nil {
 		subnetRegex := "^[a-zA-Z0-9][a-zA-Z0-9-]{0,78}[a-zA-Z0-9]$"
 		regex := regexp.MustCompile(subnetRegex)
-		if success := regex.MatchString(ptr.Deref(m.Spec.SubnetName, "")); !success {
-			return field.Invalid(field.NewPath("Spec", "SubnetName"), m.Spec.SubnetName,
+		if success := regex.MatchString(ptr.Deref(name, "")); !success {
+			return field.Invalid(fldPath, name,
 				fmt.Sprintf("name of subnet doesn't match regex %s", subnetRegex))
 		}
 	}
 	return nil
 }
 
+func (m *AzureManagedMachinePool) validateProximityPlacementGroupID() error {
+	return validateResourceIDField(m.Spec.ProximityPlacementGroupID, "Microsoft.Compute/proximityPlacementGroups", field.NewPath("Spec", "ProximityPlacementGroupID"))
+}
+
+func (m *AzureManagedMachinePool) validateHostGroupID() error {
+	return validateResourceIDField(m.Spec.HostGroupID, "Microsoft.Compute/hostGroups", field.NewPath("Spec", "HostGroupID"))
+}
+
+func (m *AzureManagedMachinePool) validateCapacityReservationGroupID() error {
+	return validateResourceIDField(m.Spec.CapacityReservationGroupID, "Microsoft.Compute/capacityReservationGroups", field.NewPath("Spec", "CapacityReservationGroupID"))
+}
+
+// validateResourceIDField checks that id, when set, is the ARM resource ID of a resource of the given type.
+func validateResourceIDField(id *string, resourceType string, fldPath *field.Path) error {
+	if id == nil {
+		return nil
+	}
+	if resourceID, err := azureutil.ParseResourceID(*id); err != nil || !strings.EqualFold(resourceID.ResourceType.String(), resourceType) {
+		return field.Invalid(fldPath, *id, fmt.Sprintf("must be the resource ID of a %s resource", resourceType))
+	}
+	return nil
+}
+
+// validateUpgradeSettings checks that maxSurge is either a positive number of nodes or a percentage between 1% and 100%.
+func (m *AzureManagedMachinePool) validateUpgradeSettings() error {
+	if m.Spec.UpgradeSettings == nil || m.Spec.UpgradeSettings.MaxSurge == nil {
+		return nil
+	}
+
+	// AKS takes maxSurge as a string, so integers passed as strings are valid too.
+	maxSurge := m.Spec.UpgradeSettings.MaxSurge.String()
+	isPercent := strings.HasSuffix(maxSurge, "%")
+	if n, err := strconv.Atoi(strings.TrimSuffix(maxSurge, "%")); err == nil && n >= 1 && (!isPercent || n <= 100) {
+		return nil
+	}
+	return field.Invalid(
+		field.NewPath("Spec", "UpgradeSettings", "MaxSurge"),
+		maxSurge,
+		"MaxSurge must be a number of nodes of at least 1 or a percentage between 1% and 100%")
+}
+
 // validateKubeletConfig enforces the AKS API configuration for KubeletConfig.
 // See:  https://learn.microsoft.com/en-us/azure/aks/custom-node-configuration.
 func (m *AzureManagedMachinePool) validateKubeletConfig() error {
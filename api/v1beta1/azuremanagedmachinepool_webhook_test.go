@@ -22,8 +22,10 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
@@ -145,6 +147,155 @@ func TestAzureManagedMachinePoolUpdatingWebhook(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Cannot change OSSKU of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					OSSKU:        ptr.To(OSSKUAzureLinux),
+					Mode:         "System",
+					SKU:          "StandardD2S_V3",
+					OSDiskSizeGB: ptr.To[int32](512),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					OSSKU:        ptr.To(OSSKUUbuntu),
+					Mode:         "System",
+					SKU:          "StandardD2S_V3",
+					OSDiskSizeGB: ptr.To[int32](512),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot change PodSubnetName of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:          "User",
+					SKU:           "StandardD2S_V3",
+					PodSubnetName: ptr.To("pods2"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:          "User",
+					SKU:           "StandardD2S_V3",
+					PodSubnetName: ptr.To("pods1"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot change ProximityPlacementGroupID of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                      "User",
+					SKU:                       "StandardD2S_V3",
+					ProximityPlacementGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/ppg2"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                      "User",
+					SKU:                       "StandardD2S_V3",
+					ProximityPlacementGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/ppg1"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Can change UpgradeSettings of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					SKU:  "StandardD2S_V3",
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{
+						MaxSurge:              ptr.To(intstr.FromString("50%")),
+						DrainTimeoutInMinutes: ptr.To[int32](10),
+					},
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					SKU:  "StandardD2S_V3",
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{
+						MaxSurge: ptr.To(intstr.FromInt(1)),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Cannot change CapacityReservationGroupID of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                       "User",
+					SKU:                        "StandardD2S_V3",
+					CapacityReservationGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg2"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                       "User",
+					SKU:                        "StandardD2S_V3",
+					CapacityReservationGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg1"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot add HostGroupID to the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:        "User",
+					SKU:         "StandardD2S_V3",
+					HostGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					SKU:  "StandardD2S_V3",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot change GPUInstanceProfile of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:               "User",
+					SKU:                "Standard_ND96asr_v4",
+					GPUInstanceProfile: ptr.To(GPUInstanceProfileMIG2g),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:               "User",
+					SKU:                "Standard_ND96asr_v4",
+					GPUInstanceProfile: ptr.To(GPUInstanceProfileMIG1g),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot change SkipGPUDriverInstall of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                 "User",
+					SKU:                  "Standard_NC6s_v3",
+					SkipGPUDriverInstall: ptr.To(true),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					SKU:  "Standard_NC6s_v3",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Cannot change OSDiskSizeGB of the agentpool",
 			new: &AzureManagedMachinePool{
@@ -797,6 +948,51 @@ func TestAzureManagedMachinePool_ValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Windows2022 OSSKU with Windows ostype",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:   "User",
+					OSType: ptr.To(WindowsOS),
+					OSSKU:  ptr.To(OSSKUWindows2022),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "AzureLinux OSSKU with default ostype",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:  "System",
+					OSSKU: ptr.To(OSSKUAzureLinux),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Windows OSSKU with Linux ostype not allowed",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:   "User",
+					OSType: ptr.To(LinuxOS),
+					OSSKU:  ptr.To(OSSKUWindows2019),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "Linux OSSKU with Windows ostype not allowed",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:   "User",
+					OSType: ptr.To(WindowsOS),
+					OSSKU:  ptr.To(OSSKUUbuntu),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
 		{
 			name: "Windows clusters with 6char or less name",
 			ammp: &AzureManagedMachinePool{
@@ -849,6 +1045,157 @@ func TestAzureManagedMachinePool_ValidateCreate(t *testing.T) {
 			wantErr:  true,
 			errorLen: 1,
 		},
+		{
+			name: "valid pod subnet name",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:          "User",
+					PodSubnetName: ptr.To("pod-subnet1"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid pod subnet name",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:          "User",
+					PodSubnetName: ptr.To("-pod-subnet"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "valid proximity placement group and host group IDs",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                      "User",
+					ProximityPlacementGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg"),
+					HostGroupID:               ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid proximity placement group ID",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                      "User",
+					ProximityPlacementGroupID: ptr.To("my-ppg"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "host group ID of another resource type",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:        "User",
+					HostGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "valid capacity reservation group ID",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                       "User",
+					CapacityReservationGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "capacity reservation group ID of another resource type",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                       "User",
+					CapacityReservationGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "valid upgrade settings with a percentage",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{
+						MaxSurge:              ptr.To(intstr.FromString("33%")),
+						DrainTimeoutInMinutes: ptr.To[int32](60),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid upgrade settings with a number of nodes",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{
+						MaxSurge: ptr.To(intstr.FromInt(5)),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid upgrade settings with a number of nodes as a string",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{
+						MaxSurge: ptr.To(intstr.FromString("5")),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid upgrade settings with zero max surge",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{
+						MaxSurge: ptr.To(intstr.FromInt(0)),
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "invalid upgrade settings with a percentage over 100%",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{
+						MaxSurge: ptr.To(intstr.FromString("150%")),
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "invalid upgrade settings with a malformed max surge",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: "User",
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{
+						MaxSurge: ptr.To(intstr.FromString("five")),
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
 		{
 			name: "pool with invalid public ip prefix",
 			ammp: &AzureManagedMachinePool{
@@ -1288,6 +1635,93 @@ func TestAzureManagedMachinePool_validateLastSystemNodePool(t *testing.T) {
 	}
 }
 
+func TestAzureManagedMachinePool_validatePodSubnetInControlPlane(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{
+				Kind: "AzureManagedControlPlane",
+				Name: "test-control-plane",
+			},
+		},
+	}
+	controlPlane := &AzureManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-control-plane",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: AzureManagedControlPlaneSpec{
+			VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+				PodSubnets: []ManagedControlPlanePodSubnet{
+					{Name: "pods1", CIDRBlock: "10.241.0.0/16"},
+					{Name: "pods2", CIDRBlock: "10.242.0.0/16"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		podSubnetName *string
+		objects       []runtime.Object
+		wantErr       bool
+	}{
+		{
+			name:          "pod subnet declared in the control plane",
+			podSubnetName: ptr.To("pods2"),
+			objects:       []runtime.Object{cluster, controlPlane},
+			wantErr:       false,
+		},
+		{
+			name:          "pod subnet not declared in the control plane",
+			podSubnetName: ptr.To("pods3"),
+			objects:       []runtime.Object{cluster, controlPlane},
+			wantErr:       true,
+		},
+		{
+			name:          "no pod subnet",
+			podSubnetName: nil,
+			objects:       []runtime.Object{cluster, controlPlane},
+			wantErr:       false,
+		},
+		{
+			name:          "control plane not created yet",
+			podSubnetName: ptr.To("pods3"),
+			objects:       []runtime.Object{cluster},
+			wantErr:       false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.objects...).Build()
+			ammp := &AzureManagedMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Labels: map[string]string{
+						clusterv1.ClusterNameLabel: "test-cluster",
+					},
+				},
+				Spec: AzureManagedMachinePoolSpec{
+					PodSubnetName: tc.podSubnetName,
+				},
+			}
+			err := ammp.validatePodSubnetInControlPlane(fakeClient)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func getKnownValidAzureManagedMachinePool() *AzureManagedMachinePool {
 	return &AzureManagedMachinePool{
 		Spec: AzureManagedMachinePoolSpec{
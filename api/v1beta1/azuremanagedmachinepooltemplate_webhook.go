@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/topology"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AzureManagedMachinePoolTemplateProviderIDListMsg is the error returned when a template sets providerIDList.
+const AzureManagedMachinePoolTemplateProviderIDListMsg = "AzureManagedMachinePoolTemplate spec.template.spec.providerIDList field can't be set"
+
+// SetupAzureManagedMachinePoolTemplateWebhookWithManager sets up and registers the webhook with the manager.
+func SetupAzureManagedMachinePoolTemplateWebhookWithManager(mgr ctrl.Manager) error {
+	mpw := &azureManagedMachinePoolTemplateWebhook{Client: mgr.GetClient()}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&AzureManagedMachinePoolTemplate{}).
+		WithDefaulter(mpw).
+		WithValidator(mpw).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremanagedmachinepooltemplate,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuremanagedmachinepooltemplates,verbs=create;update,versions=v1beta1,name=default.azuremanagedmachinepooltemplates.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// azureManagedMachinePoolTemplateWebhook implements a validating and defaulting webhook for AzureManagedMachinePoolTemplate.
+type azureManagedMachinePoolTemplateWebhook struct {
+	Client client.Client
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (mpw *azureManagedMachinePoolTemplateWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	mp, ok := obj.(*AzureManagedMachinePoolTemplate)
+	if !ok {
+		return apierrors.NewBadRequest("expected an AzureManagedMachinePoolTemplate")
+	}
+
+	// Name is left unset so each AzureManagedMachinePool defaults it from its own name.
+	if mp.Spec.Template.Spec.OSType == nil {
+		mp.Spec.Template.Spec.OSType = ptr.To(DefaultOSType)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuremanagedmachinepooltemplate,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuremanagedmachinepooltemplates,versions=v1beta1,name=validation.azuremanagedmachinepooltemplates.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (mpw *azureManagedMachinePoolTemplateWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	mp, ok := obj.(*AzureManagedMachinePoolTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureManagedMachinePoolTemplate")
+	}
+	// NOTE: AzureManagedMachinePoolTemplate relies upon MachinePools, which is behind a feature gate flag.
+	// The webhook must prevent creating new objects in case the feature flag is disabled.
+	if !feature.Gates.Enabled(capifeature.MachinePool) {
+		return nil, field.Forbidden(
+			field.NewPath("spec"),
+			"can be set only if the Cluster API 'MachinePool' feature flag is enabled",
+		)
+	}
+
+	return nil, mp.validateManagedMachinePoolTemplate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (mpw *azureManagedMachinePoolTemplateWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*AzureManagedMachinePoolTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureManagedMachinePoolTemplate")
+	}
+	mp, ok := newObj.(*AzureManagedMachinePoolTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureManagedMachinePoolTemplate")
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a admission.Request inside context: %v", err))
+	}
+
+	var allErrs field.ErrorList
+	if !topology.ShouldSkipImmutabilityChecks(req, mp) {
+		// A template is subject to the same immutability rules as the machine pools created from it.
+		allErrs = mp.toAzureManagedMachinePool().validateImmutableFields(old.toAzureManagedMachinePool())
+	}
+
+	if len(allErrs) == 0 {
+		return nil, mp.validateManagedMachinePoolTemplate()
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("AzureManagedMachinePoolTemplate").GroupKind(), mp.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (mpw *azureManagedMachinePoolTemplateWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateManagedMachinePoolTemplate validates an AzureManagedMachinePoolTemplate and returns an aggregate error.
+func (mp *AzureManagedMachinePoolTemplate) validateManagedMachinePoolTemplate() error {
+	var errs []error
+	if len(mp.Spec.Template.Spec.ProviderIDList) > 0 {
+		errs = append(errs, field.Forbidden(field.NewPath("Spec", "Template", "Spec", "ProviderIDList"), AzureManagedMachinePoolTemplateProviderIDListMsg))
+	}
+
+	if err := mp.toAzureManagedMachinePool().validateSpec(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// toAzureManagedMachinePool returns an AzureManagedMachinePool carrying the spec of the template, so the
+// AzureManagedMachinePool validation can be applied to it.
+func (mp *AzureManagedMachinePoolTemplate) toAzureManagedMachinePool() *AzureManagedMachinePool {
+	return &AzureManagedMachinePool{
+		Spec: mp.Spec.Template.Spec,
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestAzureManagedMachinePoolTemplateDefaultingWebhook(t *testing.T) {
+	g := NewWithT(t)
+
+	ammpt := getAzureManagedMachinePoolTemplate()
+	mptw := &azureManagedMachinePoolTemplateWebhook{}
+	err := mptw.Default(context.Background(), ammpt)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ammpt.Spec.Template.Spec.Name).To(BeNil())
+	g.Expect(*ammpt.Spec.Template.Spec.OSType).To(Equal(LinuxOS))
+}
+
+func TestAzureManagedMachinePoolTemplateValidateCreate(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, capifeature.MachinePool, true)()
+	tests := []struct {
+		name     string
+		template *AzureManagedMachinePoolTemplate
+		wantErr  bool
+	}{
+		{
+			name:     "valid template",
+			template: getAzureManagedMachinePoolTemplate(),
+			wantErr:  false,
+		},
+		{
+			name: "providerIDList set",
+			template: getAzureManagedMachinePoolTemplate(func(ammpt *AzureManagedMachinePoolTemplate) {
+				ammpt.Spec.Template.Spec.ProviderIDList = []string{"azure:///subscriptions/foo"}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "invalid maxPods",
+			template: getAzureManagedMachinePoolTemplate(func(ammpt *AzureManagedMachinePoolTemplate) {
+				ammpt.Spec.Template.Spec.MaxPods = ptr.To[int32](5)
+			}),
+			wantErr: true,
+		},
+		{
+			name: "windows system pool",
+			template: getAzureManagedMachinePoolTemplate(func(ammpt *AzureManagedMachinePoolTemplate) {
+				ammpt.Spec.Template.Spec.OSType = ptr.To(WindowsOS)
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mptw := &azureManagedMachinePoolTemplateWebhook{}
+			_, err := mptw.ValidateCreate(context.Background(), tc.template)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureManagedMachinePoolTemplateValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldTemplate *AzureManagedMachinePoolTemplate
+		template    *AzureManagedMachinePoolTemplate
+		wantErr     bool
+	}{
+		{
+			name:        "no change",
+			oldTemplate: getAzureManagedMachinePoolTemplate(),
+			template:    getAzureManagedMachinePoolTemplate(),
+			wantErr:     false,
+		},
+		{
+			name:        "scaling changed",
+			oldTemplate: getAzureManagedMachinePoolTemplate(),
+			template: getAzureManagedMachinePoolTemplate(func(ammpt *AzureManagedMachinePoolTemplate) {
+				ammpt.Spec.Template.Spec.Scaling = &ManagedMachinePoolScaling{
+					MinSize: ptr.To[int32](1),
+					MaxSize: ptr.To[int32](3),
+				}
+			}),
+			wantErr: false,
+		},
+		{
+			name:        "SKU changed",
+			oldTemplate: getAzureManagedMachinePoolTemplate(),
+			template: getAzureManagedMachinePoolTemplate(func(ammpt *AzureManagedMachinePoolTemplate) {
+				ammpt.Spec.Template.Spec.SKU = "Standard_D4s_v3"
+			}),
+			wantErr: true,
+		},
+		{
+			name:        "availability zones changed",
+			oldTemplate: getAzureManagedMachinePoolTemplate(),
+			template: getAzureManagedMachinePoolTemplate(func(ammpt *AzureManagedMachinePoolTemplate) {
+				ammpt.Spec.Template.Spec.AvailabilityZones = []string{"1"}
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mptw := &azureManagedMachinePoolTemplateWebhook{}
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{DryRun: ptr.To(false)}})
+			_, err := mptw.ValidateUpdate(ctx, tc.oldTemplate, tc.template)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func getAzureManagedMachinePoolTemplate(changes ...func(*AzureManagedMachinePoolTemplate)) *AzureManagedMachinePoolTemplate {
+	input := &AzureManagedMachinePoolTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fooName",
+		},
+		Spec: AzureManagedMachinePoolTemplateSpec{
+			Template: AzureManagedMachinePoolTemplateResource{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode: string(NodePoolModeSystem),
+					SKU:  "Standard_D2s_v3",
+				},
+			},
+		},
+	}
+
+	for _, change := range changes {
+		change(input)
+	}
+
+	return input
+}
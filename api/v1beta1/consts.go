@@ -88,6 +88,20 @@ const (
 	AgentPoolsReadyCondition clusterv1.ConditionType = "AgentPoolsReady"
 	// AzureResourceAvailableCondition means the AKS cluster is healthy according to Azure's Resource Health API.
 	AzureResourceAvailableCondition clusterv1.ConditionType = "AzureResourceAvailable"
+	// MaintenanceConfigurationsReadyCondition means the AKS maintenance configurations have been applied to the cluster.
+	MaintenanceConfigurationsReadyCondition clusterv1.ConditionType = "MaintenanceConfigurationsReady"
+	// AKSExtensionsReadyCondition means the AKS cluster extensions have been applied to the cluster.
+	AKSExtensionsReadyCondition clusterv1.ConditionType = "AKSExtensionsReady"
+	// NodeImageUpgradedCondition means the AKS agent pool runs the desired node image version.
+	NodeImageUpgradedCondition clusterv1.ConditionType = "NodeImageUpgraded"
+	// NodeLabelsAndTaintsSyncedCondition means the node labels and taints set by CAPZ on the AKS agent pool were not
+	// changed outside of CAPZ.
+	NodeLabelsAndTaintsSyncedCondition clusterv1.ConditionType = "NodeLabelsAndTaintsSynced"
+	// AdoptedCondition means the existing AKS resource was imported and matches its spec, so CAPZ manages it from now on.
+	AdoptedCondition clusterv1.ConditionType = "Adopted"
+	// DriftDetectedCondition means the AKS resource differs from its spec. Unlike other conditions, it is true when
+	// something is off, so it is not part of the Ready condition.
+	DriftDetectedCondition clusterv1.ConditionType = "DriftDetected"
 )
 
 // Azure Services Conditions and Reasons.
@@ -100,8 +114,12 @@ const (
 	VnetPeeringReadyCondition clusterv1.ConditionType = "VnetPeeringReady"
 	// SecurityGroupsReadyCondition means the security groups exist and are ready to be used.
 	SecurityGroupsReadyCondition clusterv1.ConditionType = "SecurityGroupsReady"
+	// FlowLogsReadyCondition means the network security group flow logs exist and are ready to be used.
+	FlowLogsReadyCondition clusterv1.ConditionType = "FlowLogsReady"
 	// RouteTablesReadyCondition means the route tables exist and are ready to be used.
 	RouteTablesReadyCondition clusterv1.ConditionType = "RouteTablesReady"
+	// ApplicationSecurityGroupsReadyCondition means the application security groups exist and are ready to be used.
+	ApplicationSecurityGroupsReadyCondition clusterv1.ConditionType = "ApplicationSecurityGroupsReady"
 	// PublicIPsReadyCondition means the public IPs exist and are ready to be used.
 	PublicIPsReadyCondition clusterv1.ConditionType = "PublicIPsReady"
 	// NATGatewaysReadyCondition means the NAT gateways exist and are ready to be used.
@@ -143,6 +161,19 @@ const (
 	DeletionFailedReason = "DeletionFailed"
 	// UpdatingReason means the resource is being updated.
 	UpdatingReason = "Updating"
+	// DriftedReason means the resource was changed outside of CAPZ.
+	DriftedReason = "Drifted"
+	// AdoptionPendingReason means the existing resource is not updated until its spec matches the live state.
+	AdoptionPendingReason = "AdoptionPending"
+	// AdoptionFailedReason means the resource to adopt could not be imported.
+	AdoptionFailedReason = "AdoptionFailed"
+	// DriftReportedReason means the resource differs from its spec and is not updated as drift is only reported.
+	DriftReportedReason = "DriftReported"
+	// NoDriftReason means the resource matches its spec.
+	NoDriftReason = "NoDrift"
+	// NodeImageVersionNotLatestReason means the desired node image version is not the latest one available, which is
+	// the only version AKS can upgrade to.
+	NodeImageVersionNotLatestReason = "NodeImageVersionNotLatest"
 )
 
 const (
@@ -63,6 +63,12 @@ const (
 	PutFuture string = "PUT"
 	// DeleteFuture is a future that was derived from a DELETE request.
 	DeleteFuture string = "DELETE"
+	// StartFuture is a future that was derived from a POST request starting a resource.
+	StartFuture string = "START"
+	// StopFuture is a future that was derived from a POST request stopping a resource.
+	StopFuture string = "STOP"
+	// UpgradeNodeImageFuture is a future that was derived from a POST request upgrading the node image of an agent pool.
+	UpgradeNodeImageFuture string = "UPGRADENODEIMAGE"
 )
 
 // Future contains the data needed for an Azure long-running operation to continue across reconcile loops.
@@ -207,6 +213,11 @@ type ServiceEndpoints []ServiceEndpointSpec
 // +listMapKey=name
 type PrivateEndpoints []PrivateEndpointSpec
 
+// SubnetDelegations is a slice of SubnetDelegation.
+// +listType=map
+// +listMapKey=name
+type SubnetDelegations []SubnetDelegation
+
 // SecurityGroup defines an Azure security group.
 type SecurityGroup struct {
 	// ID is the Azure resource ID of the security group.
@@ -225,8 +236,51 @@ type RouteTable struct {
 	// +optional
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
+	// Routes are the routes CAPZ adds to the route table. Routes added to the route table outside of CAPZ are kept.
+	// +optional
+	Routes Routes `json:"routes,omitempty"`
+}
+
+// RouteNextHopType defines the type of Azure hop a route sends packets to.
+type RouteNextHopType string
+
+const (
+	// RouteNextHopTypeVirtualNetworkGateway sends packets to the virtual network gateway.
+	RouteNextHopTypeVirtualNetworkGateway = RouteNextHopType("VirtualNetworkGateway")
+
+	// RouteNextHopTypeVnetLocal sends packets within the virtual network.
+	RouteNextHopTypeVnetLocal = RouteNextHopType("VnetLocal")
+
+	// RouteNextHopTypeInternet sends packets to the Internet.
+	RouteNextHopTypeInternet = RouteNextHopType("Internet")
+
+	// RouteNextHopTypeVirtualAppliance sends packets to a virtual appliance, such as a firewall.
+	RouteNextHopTypeVirtualAppliance = RouteNextHopType("VirtualAppliance")
+
+	// RouteNextHopTypeNone drops packets.
+	RouteNextHopTypeNone = RouteNextHopType("None")
+)
+
+// Route defines an Azure route of a route table.
+type Route struct {
+	// Name is a unique name within the route table.
+	Name string `json:"name"`
+	// AddressPrefix is the destination the route applies to, as a CIDR or a service tag such as 'AzureCloud'.
+	AddressPrefix string `json:"addressPrefix"`
+	// NextHopType is the type of Azure hop the packets are sent to. "VirtualNetworkGateway", "VnetLocal", "Internet",
+	// "VirtualAppliance", or "None".
+	// +kubebuilder:validation:Enum=VirtualNetworkGateway;VnetLocal;Internet;VirtualAppliance;None
+	NextHopType RouteNextHopType `json:"nextHopType"`
+	// NextHopIPAddress is the IP address packets are forwarded to. It is only set when NextHopType is "VirtualAppliance".
+	// +optional
+	NextHopIPAddress string `json:"nextHopIPAddress,omitempty"`
 }
 
+// Routes is a slice of Azure routes for route tables.
+// +listType=map
+// +listMapKey=name
+type Routes []Route
+
 // NatGateway defines an Azure NAT gateway.
 // NAT gateway resources are part of Vnet NAT and provide outbound Internet connectivity for subnets of a virtual network.
 type NatGateway struct {
@@ -308,6 +362,14 @@ type SecurityRule struct {
 	// Destination is the destination address prefix. CIDR or destination IP range. Asterix '*' can also be used to match all source IPs. Default tags such as 'VirtualNetwork', 'AzureLoadBalancer' and 'Internet' can also be used.
 	// +optional
 	Destination *string `json:"destination,omitempty"`
+	// SourceApplicationSecurityGroups is a list of application security group names to match as the source of the traffic.
+	// The application security groups must be declared in the network spec. Cannot be used together with Source.
+	// +optional
+	SourceApplicationSecurityGroups []string `json:"sourceApplicationSecurityGroups,omitempty"`
+	// DestinationApplicationSecurityGroups is a list of application security group names to match as the destination of the traffic.
+	// The application security groups must be declared in the network spec. Cannot be used together with Destination.
+	// +optional
+	DestinationApplicationSecurityGroups []string `json:"destinationApplicationSecurityGroups,omitempty"`
 	// Action specifies whether network traffic is allowed or denied. Can either be "Allow" or "Deny". Defaults to "Allow".
 	// +kubebuilder:default=Allow
 	// +kubebuilder:validation:Enum=Allow;Deny
@@ -741,6 +803,15 @@ type ServiceEndpointSpec struct {
 	Locations []string `json:"locations"`
 }
 
+// SubnetDelegation delegates a subnet to an Azure service.
+type SubnetDelegation struct {
+	// Name is the name of the delegation, unique within the subnet.
+	Name string `json:"name"`
+
+	// ServiceName is the name of the service the subnet is delegated to, e.g. Microsoft.ContainerInstance/containerGroups.
+	ServiceName string `json:"serviceName"`
+}
+
 // PrivateLinkServiceConnection defines the specification for a private link service connection associated with a private endpoint.
 type PrivateLinkServiceConnection struct {
 	// Name specifies the name of the private link service.
@@ -799,6 +870,29 @@ type NetworkInterface struct {
 	// +kubebuilder:validation:nullable
 	// +optional
 	AcceleratedNetworking *bool `json:"acceleratedNetworking,omitempty"`
+
+	// ApplicationSecurityGroups is a list of application security group names the network interface joins.
+	// The application security groups must exist in the cluster resource group.
+	// +optional
+	ApplicationSecurityGroups []string `json:"applicationSecurityGroups,omitempty"`
+
+	// SecurityGroup is the network security group attached to the network interface.
+	// +optional
+	SecurityGroup *NetworkInterfaceSecurityGroup `json:"securityGroup,omitempty"`
+}
+
+// NetworkInterfaceSecurityGroup defines a network security group attached directly to a network interface.
+// Exactly one of ID or SecurityRules must be set.
+type NetworkInterfaceSecurityGroup struct {
+	// ID is the resource ID of an existing network security group to attach to the network interface.
+	// CAPZ does not create, modify or delete a referenced security group.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// SecurityRules is the set of rules of a network security group created by CAPZ for the network interface.
+	// The security group is named after the network interface and is deleted along with it.
+	// +optional
+	SecurityRules SecurityRules `json:"securityRules,omitempty"`
 }
 
 // GetControlPlaneSubnet returns the cluster control plane subnet.
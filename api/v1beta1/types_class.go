@@ -56,6 +56,28 @@ type AzureClusterClassSpec struct {
 	// Note: All cloud provider config values can be customized by creating the secret beforehand. CloudProviderConfigOverrides is only used when the secret is managed by the Azure Provider.
 	// +optional
 	CloudProviderConfigOverrides *CloudProviderConfigOverrides `json:"cloudProviderConfigOverrides,omitempty"`
+
+	// Monitoring configures the monitoring of the machines of the cluster.
+	// +optional
+	Monitoring *AzureClusterMonitoring `json:"monitoring,omitempty"`
+}
+
+// AzureClusterMonitoring configures the monitoring of the machines of the cluster.
+type AzureClusterMonitoring struct {
+	// AzureMonitorAgent configures the Azure Monitor Agent VM extension on the machines of the cluster.
+	// +optional
+	AzureMonitorAgent *AzureMonitorAgent `json:"azureMonitorAgent,omitempty"`
+}
+
+// AzureMonitorAgent configures the Azure Monitor Agent VM extension, which sends logs and metrics of a machine to the
+// destinations, such as a Log Analytics workspace, of the data collection rules associated with the machine.
+// See also [Azure Monitor doc].
+//
+// [Azure Monitor doc]: https://learn.microsoft.com/azure/azure-monitor/agents/azure-monitor-agent-overview
+type AzureMonitorAgent struct {
+	// Enabled is whether the Azure Monitor Agent is installed on new machines of the cluster.
+	// Disabling it does not remove the agent from existing machines.
+	Enabled bool `json:"enabled"`
 }
 
 // ExtendedLocationSpec defines the ExtendedLocation properties to enable CAPZ for Azure public MEC.
@@ -73,8 +95,24 @@ type NetworkClassSpec struct {
 	// PrivateDNSZoneName defines the zone name for the Azure Private DNS.
 	// +optional
 	PrivateDNSZoneName string `json:"privateDNSZoneName,omitempty"`
+
+	// ApplicationSecurityGroups is the list of application security groups to create in the cluster resource group.
+	// Security rules and network interfaces can reference them by name.
+	// +optional
+	ApplicationSecurityGroups ApplicationSecurityGroups `json:"applicationSecurityGroups,omitempty"`
+}
+
+// ApplicationSecurityGroup defines an Azure application security group.
+type ApplicationSecurityGroup struct {
+	// Name is the name of the application security group.
+	Name string `json:"name"`
 }
 
+// ApplicationSecurityGroups is a slice of Azure application security groups.
+// +listType=map
+// +listMapKey=name
+type ApplicationSecurityGroups []ApplicationSecurityGroup
+
 // VnetClassSpec defines the VnetSpec properties that may be shared across several Azure clusters.
 type VnetClassSpec struct {
 	// CIDRBlocks defines the virtual network's address space, specified as one or more address prefixes in CIDR notation.
@@ -106,6 +144,17 @@ type SubnetClassSpec struct {
 	// PrivateEndpoints defines a list of private endpoints that should be attached to this subnet.
 	// +optional
 	PrivateEndpoints PrivateEndpoints `json:"privateEndpoints,omitempty"`
+
+	// Delegations is a list of Azure services the subnet is delegated to, e.g. Azure Container Instances.
+	// Delegations set outside of CAPZ are kept as long as none are specified.
+	// Control plane and node subnets can't be delegated.
+	// +optional
+	Delegations SubnetDelegations `json:"delegations,omitempty"`
+
+	// ServiceEndpointPolicies is a list of resource IDs of service endpoint policies to apply to the subnet.
+	// Service endpoint policies set outside of CAPZ are kept as long as none are specified.
+	// +optional
+	ServiceEndpointPolicies []string `json:"serviceEndpointPolicies,omitempty"`
 }
 
 // LoadBalancerClassSpec defines the LoadBalancerSpec properties that may be shared across several Azure clusters.
@@ -125,6 +174,46 @@ type SecurityGroupClass struct {
 	SecurityRules SecurityRules `json:"securityRules,omitempty"`
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
+	// FlowLogs configures Network Watcher flow logs for the security group.
+	// +optional
+	FlowLogs *FlowLogs `json:"flowLogs,omitempty"`
+}
+
+// FlowLogs defines the Network Watcher flow logs of a network security group.
+type FlowLogs struct {
+	// StorageAccountID is the resource ID of the storage account the flow logs are written to.
+	// The storage account must be in the same region as the security group.
+	StorageAccountID string `json:"storageAccountID"`
+	// RetentionDays is the number of days flow log records are kept in the storage account.
+	// Records are kept indefinitely if omitted or set to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=365
+	// +optional
+	RetentionDays int32 `json:"retentionDays,omitempty"`
+	// TrafficAnalytics enables traffic analytics on the flow logs.
+	// +optional
+	TrafficAnalytics *TrafficAnalytics `json:"trafficAnalytics,omitempty"`
+	// NetworkWatcherName is the name of the Network Watcher the flow logs are created in.
+	// Defaults to the Network Watcher Azure creates for the cluster location, NetworkWatcher_<location>.
+	// +optional
+	NetworkWatcherName string `json:"networkWatcherName,omitempty"`
+	// NetworkWatcherResourceGroup is the resource group of the Network Watcher. Defaults to NetworkWatcherRG.
+	// +optional
+	NetworkWatcherResourceGroup string `json:"networkWatcherResourceGroup,omitempty"`
+}
+
+// TrafficAnalytics defines the Log Analytics workspace traffic analytics are sent to.
+type TrafficAnalytics struct {
+	// WorkspaceResourceID is the resource ID of the Log Analytics workspace.
+	WorkspaceResourceID string `json:"workspaceResourceID"`
+	// WorkspaceID is the GUID of the Log Analytics workspace.
+	WorkspaceID string `json:"workspaceID"`
+	// WorkspaceRegion is the location of the Log Analytics workspace.
+	WorkspaceRegion string `json:"workspaceRegion"`
+	// IntervalInMinutes is how often traffic analytics processes the flow logs. Defaults to 60.
+	// +kubebuilder:validation:Enum=10;60
+	// +optional
+	IntervalInMinutes *int32 `json:"intervalInMinutes,omitempty"`
 }
 
 // FrontendIPClass defines the FrontendIP properties that may be shared across several Azure clusters.
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
 )
@@ -49,6 +50,68 @@ func (in *AADProfile) DeepCopy() *AADProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AKSExtension) DeepCopyInto(out *AKSExtension) {
+	*out = *in
+	if in.AutoUpgradeMinorVersion != nil {
+		in, out := &in.AutoUpgradeMinorVersion, &out.AutoUpgradeMinorVersion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReleaseTrain != nil {
+		in, out := &in.ReleaseTrain, &out.ReleaseTrain
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(ExtensionScope)
+		**out = **in
+	}
+	if in.ConfigurationSettings != nil {
+		in, out := &in.ConfigurationSettings, &out.ConfigurationSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(ExtensionPlan)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AKSExtension.
+func (in *AKSExtension) DeepCopy() *AKSExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(AKSExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AKSExtensionStatus) DeepCopyInto(out *AKSExtensionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AKSExtensionStatus.
+func (in *AKSExtensionStatus) DeepCopy() *AKSExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AKSExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AKSSku) DeepCopyInto(out *AKSSku) {
 	*out = *in
@@ -99,6 +162,21 @@ func (in *APIServerAccessProfile) DeepCopy() *APIServerAccessProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AbsoluteMonthlySchedule) DeepCopyInto(out *AbsoluteMonthlySchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AbsoluteMonthlySchedule.
+func (in *AbsoluteMonthlySchedule) DeepCopy() *AbsoluteMonthlySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AbsoluteMonthlySchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdditionalCapabilities) DeepCopyInto(out *AdditionalCapabilities) {
 	*out = *in
@@ -181,6 +259,40 @@ func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSecurityGroup) DeepCopyInto(out *ApplicationSecurityGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSecurityGroup.
+func (in *ApplicationSecurityGroup) DeepCopy() *ApplicationSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ApplicationSecurityGroups) DeepCopyInto(out *ApplicationSecurityGroups) {
+	{
+		in := &in
+		*out = make(ApplicationSecurityGroups, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSecurityGroups.
+func (in ApplicationSecurityGroups) DeepCopy() ApplicationSecurityGroups {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSecurityGroups)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalerProfile) DeepCopyInto(out *AutoScalerProfile) {
 	*out = *in
@@ -366,6 +478,11 @@ func (in *AzureClusterClassSpec) DeepCopyInto(out *AzureClusterClassSpec) {
 		*out = new(CloudProviderConfigOverrides)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(AzureClusterMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterClassSpec.
@@ -512,6 +629,26 @@ func (in *AzureClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureClusterMonitoring) DeepCopyInto(out *AzureClusterMonitoring) {
+	*out = *in
+	if in.AzureMonitorAgent != nil {
+		in, out := &in.AzureMonitorAgent, &out.AzureMonitorAgent
+		*out = new(AzureMonitorAgent)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterMonitoring.
+func (in *AzureClusterMonitoring) DeepCopy() *AzureClusterMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureClusterMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureClusterSpec) DeepCopyInto(out *AzureClusterSpec) {
 	*out = *in
@@ -703,6 +840,31 @@ func (in *AzureComputeGalleryImage) DeepCopy() *AzureComputeGalleryImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKeyVaultKms) DeepCopyInto(out *AzureKeyVaultKms) {
+	*out = *in
+	if in.KeyVaultNetworkAccess != nil {
+		in, out := &in.KeyVaultNetworkAccess, &out.KeyVaultNetworkAccess
+		*out = new(KeyVaultNetworkAccessTypes)
+		**out = **in
+	}
+	if in.KeyVaultResourceID != nil {
+		in, out := &in.KeyVaultResourceID, &out.KeyVaultResourceID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVaultKms.
+func (in *AzureKeyVaultKms) DeepCopy() *AzureKeyVaultKms {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKeyVaultKms)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachine) DeepCopyInto(out *AzureMachine) {
 	*out = *in
@@ -1089,6 +1251,111 @@ func (in *AzureManagedClusterStatus) DeepCopy() *AzureManagedClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedClusterTemplate) DeepCopyInto(out *AzureManagedClusterTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedClusterTemplate.
+func (in *AzureManagedClusterTemplate) DeepCopy() *AzureManagedClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureManagedClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedClusterTemplateList) DeepCopyInto(out *AzureManagedClusterTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AzureManagedClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedClusterTemplateList.
+func (in *AzureManagedClusterTemplateList) DeepCopy() *AzureManagedClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureManagedClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedClusterTemplateResource) DeepCopyInto(out *AzureManagedClusterTemplateResource) {
+	*out = *in
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedClusterTemplateResource.
+func (in *AzureManagedClusterTemplateResource) DeepCopy() *AzureManagedClusterTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedClusterTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedClusterTemplateResourceSpec) DeepCopyInto(out *AzureManagedClusterTemplateResourceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedClusterTemplateResourceSpec.
+func (in *AzureManagedClusterTemplateResourceSpec) DeepCopy() *AzureManagedClusterTemplateResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedClusterTemplateResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedClusterTemplateSpec) DeepCopyInto(out *AzureManagedClusterTemplateSpec) {
+	*out = *in
+	out.Template = in.Template
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedClusterTemplateSpec.
+func (in *AzureManagedClusterTemplateSpec) DeepCopy() *AzureManagedClusterTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedClusterTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureManagedControlPlane) DeepCopyInto(out *AzureManagedControlPlane) {
 	*out = *in
@@ -1175,6 +1442,26 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 		*out = new(string)
 		**out = **in
 	}
+	if in.NetworkDataplane != nil {
+		in, out := &in.NetworkDataplane, &out.NetworkDataplane
+		*out = new(NetworkDataplaneType)
+		**out = **in
+	}
+	if in.PodCIDR != nil {
+		in, out := &in.PodCIDR, &out.PodCIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceCIDR != nil {
+		in, out := &in.ServiceCIDR, &out.ServiceCIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]IPFamily, len(*in))
+		copy(*out, *in)
+	}
 	if in.OutboundType != nil {
 		in, out := &in.OutboundType, &out.OutboundType
 		*out = new(ManagedControlPlaneOutboundType)
@@ -1205,6 +1492,11 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 		*out = new(AADProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DisableLocalAccounts != nil {
+		in, out := &in.DisableLocalAccounts, &out.DisableLocalAccounts
+		*out = new(bool)
+		**out = **in
+	}
 	if in.AddonProfiles != nil {
 		in, out := &in.AddonProfiles, &out.AddonProfiles
 		*out = make([]AddonProfile, len(*in))
@@ -1247,6 +1539,50 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 		*out = new(OIDCIssuerProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(ManagedClusterSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(ManagedClusterMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerState != nil {
+		in, out := &in.PowerState, &out.PowerState
+		*out = new(PowerState)
+		**out = **in
+	}
+	if in.MaintenanceConfigurations != nil {
+		in, out := &in.MaintenanceConfigurations, &out.MaintenanceConfigurations
+		*out = make([]MaintenanceConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutoUpgradeProfile != nil {
+		in, out := &in.AutoUpgradeProfile, &out.AutoUpgradeProfile
+		*out = new(ManagedClusterAutoUpgradeProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]AKSExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdoptionMode != nil {
+		in, out := &in.AdoptionMode, &out.AdoptionMode
+		*out = new(AdoptionMode)
+		**out = **in
+	}
+	if in.DriftMode != nil {
+		in, out := &in.DriftMode, &out.DriftMode
+		*out = new(DriftMode)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneSpec.
@@ -1279,6 +1615,16 @@ func (in *AzureManagedControlPlaneStatus) DeepCopyInto(out *AzureManagedControlP
 		*out = new(OIDCIssuerProfileStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]AKSExtensionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(ManagedClusterMonitoringStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneStatus.
@@ -1292,26 +1638,25 @@ func (in *AzureManagedControlPlaneStatus) DeepCopy() *AzureManagedControlPlaneSt
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureManagedMachinePool) DeepCopyInto(out *AzureManagedMachinePool) {
+func (in *AzureManagedControlPlaneTemplate) DeepCopyInto(out *AzureManagedControlPlaneTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePool.
-func (in *AzureManagedMachinePool) DeepCopy() *AzureManagedMachinePool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneTemplate.
+func (in *AzureManagedControlPlaneTemplate) DeepCopy() *AzureManagedControlPlaneTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureManagedMachinePool)
+	out := new(AzureManagedControlPlaneTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AzureManagedMachinePool) DeepCopyObject() runtime.Object {
+func (in *AzureManagedControlPlaneTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1319,31 +1664,31 @@ func (in *AzureManagedMachinePool) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureManagedMachinePoolList) DeepCopyInto(out *AzureManagedMachinePoolList) {
+func (in *AzureManagedControlPlaneTemplateList) DeepCopyInto(out *AzureManagedControlPlaneTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]AzureManagedMachinePool, len(*in))
+		*out = make([]AzureManagedControlPlaneTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolList.
-func (in *AzureManagedMachinePoolList) DeepCopy() *AzureManagedMachinePoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneTemplateList.
+func (in *AzureManagedControlPlaneTemplateList) DeepCopy() *AzureManagedControlPlaneTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureManagedMachinePoolList)
+	out := new(AzureManagedControlPlaneTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AzureManagedMachinePoolList) DeepCopyObject() runtime.Object {
+func (in *AzureManagedControlPlaneTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1351,8 +1696,25 @@ func (in *AzureManagedMachinePoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureManagedMachinePoolSpec) DeepCopyInto(out *AzureManagedMachinePoolSpec) {
+func (in *AzureManagedControlPlaneTemplateResource) DeepCopyInto(out *AzureManagedControlPlaneTemplateResource) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneTemplateResource.
+func (in *AzureManagedControlPlaneTemplateResource) DeepCopy() *AzureManagedControlPlaneTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedControlPlaneTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedControlPlaneTemplateResourceSpec) DeepCopyInto(out *AzureManagedControlPlaneTemplateResourceSpec) {
 	*out = *in
+	in.VirtualNetwork.DeepCopyInto(&out.VirtualNetwork)
 	if in.AdditionalTags != nil {
 		in, out := &in.AdditionalTags, &out.AdditionalTags
 		*out = make(Tags, len(*in))
@@ -1360,7 +1722,245 @@ func (in *AzureManagedMachinePoolSpec) DeepCopyInto(out *AzureManagedMachinePool
 			(*out)[key] = val
 		}
 	}
-	if in.Name != nil {
+	if in.NetworkPlugin != nil {
+		in, out := &in.NetworkPlugin, &out.NetworkPlugin
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkPluginMode != nil {
+		in, out := &in.NetworkPluginMode, &out.NetworkPluginMode
+		*out = new(NetworkPluginMode)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkDataplane != nil {
+		in, out := &in.NetworkDataplane, &out.NetworkDataplane
+		*out = new(NetworkDataplaneType)
+		**out = **in
+	}
+	if in.PodCIDR != nil {
+		in, out := &in.PodCIDR, &out.PodCIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceCIDR != nil {
+		in, out := &in.ServiceCIDR, &out.ServiceCIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.OutboundType != nil {
+		in, out := &in.OutboundType, &out.OutboundType
+		*out = new(ManagedControlPlaneOutboundType)
+		**out = **in
+	}
+	if in.DNSServiceIP != nil {
+		in, out := &in.DNSServiceIP, &out.DNSServiceIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.LoadBalancerSKU != nil {
+		in, out := &in.LoadBalancerSKU, &out.LoadBalancerSKU
+		*out = new(string)
+		**out = **in
+	}
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.AADProfile != nil {
+		in, out := &in.AADProfile, &out.AADProfile
+		*out = new(AADProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisableLocalAccounts != nil {
+		in, out := &in.DisableLocalAccounts, &out.DisableLocalAccounts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AddonProfiles != nil {
+		in, out := &in.AddonProfiles, &out.AddonProfiles
+		*out = make([]AddonProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SKU != nil {
+		in, out := &in.SKU, &out.SKU
+		*out = new(AKSSku)
+		**out = **in
+	}
+	if in.LoadBalancerProfile != nil {
+		in, out := &in.LoadBalancerProfile, &out.LoadBalancerProfile
+		*out = new(LoadBalancerProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerAccessProfile != nil {
+		in, out := &in.APIServerAccessProfile, &out.APIServerAccessProfile
+		*out = new(APIServerAccessProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoScalerProfile != nil {
+		in, out := &in.AutoScalerProfile, &out.AutoScalerProfile
+		*out = new(AutoScalerProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(Identity)
+		**out = **in
+	}
+	if in.HTTPProxyConfig != nil {
+		in, out := &in.HTTPProxyConfig, &out.HTTPProxyConfig
+		*out = new(HTTPProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDCIssuerProfile != nil {
+		in, out := &in.OIDCIssuerProfile, &out.OIDCIssuerProfile
+		*out = new(OIDCIssuerProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(ManagedClusterSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(ManagedClusterMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceConfigurations != nil {
+		in, out := &in.MaintenanceConfigurations, &out.MaintenanceConfigurations
+		*out = make([]MaintenanceConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutoUpgradeProfile != nil {
+		in, out := &in.AutoUpgradeProfile, &out.AutoUpgradeProfile
+		*out = new(ManagedClusterAutoUpgradeProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]AKSExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DriftMode != nil {
+		in, out := &in.DriftMode, &out.DriftMode
+		*out = new(DriftMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneTemplateResourceSpec.
+func (in *AzureManagedControlPlaneTemplateResourceSpec) DeepCopy() *AzureManagedControlPlaneTemplateResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedControlPlaneTemplateResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedControlPlaneTemplateSpec) DeepCopyInto(out *AzureManagedControlPlaneTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneTemplateSpec.
+func (in *AzureManagedControlPlaneTemplateSpec) DeepCopy() *AzureManagedControlPlaneTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedControlPlaneTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedMachinePool) DeepCopyInto(out *AzureManagedMachinePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePool.
+func (in *AzureManagedMachinePool) DeepCopy() *AzureManagedMachinePool {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedMachinePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureManagedMachinePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedMachinePoolList) DeepCopyInto(out *AzureManagedMachinePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AzureManagedMachinePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolList.
+func (in *AzureManagedMachinePoolList) DeepCopy() *AzureManagedMachinePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedMachinePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureManagedMachinePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedMachinePoolSpec) DeepCopyInto(out *AzureManagedMachinePoolSpec) {
+	*out = *in
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make(Tags, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Name != nil {
 		in, out := &in.Name, &out.Name
 		*out = new(string)
 		**out = **in
@@ -1417,6 +2017,11 @@ func (in *AzureManagedMachinePoolSpec) DeepCopyInto(out *AzureManagedMachinePool
 		*out = new(string)
 		**out = **in
 	}
+	if in.OSSKU != nil {
+		in, out := &in.OSSKU, &out.OSSKU
+		*out = new(string)
+		**out = **in
+	}
 	if in.EnableNodePublicIP != nil {
 		in, out := &in.EnableNodePublicIP, &out.EnableNodePublicIP
 		*out = new(bool)
@@ -1462,11 +2067,51 @@ func (in *AzureManagedMachinePoolSpec) DeepCopyInto(out *AzureManagedMachinePool
 		*out = new(string)
 		**out = **in
 	}
+	if in.PodSubnetName != nil {
+		in, out := &in.PodSubnetName, &out.PodSubnetName
+		*out = new(string)
+		**out = **in
+	}
 	if in.EnableFIPS != nil {
 		in, out := &in.EnableFIPS, &out.EnableFIPS
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ProximityPlacementGroupID != nil {
+		in, out := &in.ProximityPlacementGroupID, &out.ProximityPlacementGroupID
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostGroupID != nil {
+		in, out := &in.HostGroupID, &out.HostGroupID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CapacityReservationGroupID != nil {
+		in, out := &in.CapacityReservationGroupID, &out.CapacityReservationGroupID
+		*out = new(string)
+		**out = **in
+	}
+	if in.GPUInstanceProfile != nil {
+		in, out := &in.GPUInstanceProfile, &out.GPUInstanceProfile
+		*out = new(GPUInstanceProfile)
+		**out = **in
+	}
+	if in.SkipGPUDriverInstall != nil {
+		in, out := &in.SkipGPUDriverInstall, &out.SkipGPUDriverInstall
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeImageVersion != nil {
+		in, out := &in.NodeImageVersion, &out.NodeImageVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.UpgradeSettings != nil {
+		in, out := &in.UpgradeSettings, &out.UpgradeSettings
+		*out = new(ManagedMachinePoolUpgradeSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolSpec.
@@ -1516,6 +2161,96 @@ func (in *AzureManagedMachinePoolStatus) DeepCopy() *AzureManagedMachinePoolStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedMachinePoolTemplate) DeepCopyInto(out *AzureManagedMachinePoolTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolTemplate.
+func (in *AzureManagedMachinePoolTemplate) DeepCopy() *AzureManagedMachinePoolTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedMachinePoolTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureManagedMachinePoolTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedMachinePoolTemplateList) DeepCopyInto(out *AzureManagedMachinePoolTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AzureManagedMachinePoolTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolTemplateList.
+func (in *AzureManagedMachinePoolTemplateList) DeepCopy() *AzureManagedMachinePoolTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedMachinePoolTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureManagedMachinePoolTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedMachinePoolTemplateResource) DeepCopyInto(out *AzureManagedMachinePoolTemplateResource) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolTemplateResource.
+func (in *AzureManagedMachinePoolTemplateResource) DeepCopy() *AzureManagedMachinePoolTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedMachinePoolTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureManagedMachinePoolTemplateSpec) DeepCopyInto(out *AzureManagedMachinePoolTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolTemplateSpec.
+func (in *AzureManagedMachinePoolTemplateSpec) DeepCopy() *AzureManagedMachinePoolTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureManagedMachinePoolTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMarketplaceImage) DeepCopyInto(out *AzureMarketplaceImage) {
 	*out = *in
@@ -1532,6 +2267,21 @@ func (in *AzureMarketplaceImage) DeepCopy() *AzureMarketplaceImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMonitorAgent) DeepCopyInto(out *AzureMonitorAgent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMonitorAgent.
+func (in *AzureMonitorAgent) DeepCopy() *AzureMonitorAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMonitorAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureSharedGalleryImage) DeepCopyInto(out *AzureSharedGalleryImage) {
 	*out = *in
@@ -1717,6 +2467,21 @@ func (in *CloudProviderConfigOverrides) DeepCopy() *CloudProviderConfigOverrides
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DailySchedule) DeepCopyInto(out *DailySchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DailySchedule.
+func (in *DailySchedule) DeepCopy() *DailySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(DailySchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataDisk) DeepCopyInto(out *DataDisk) {
 	*out = *in
@@ -1737,7 +2502,22 @@ func (in *DataDisk) DeepCopy() *DataDisk {
 	if in == nil {
 		return nil
 	}
-	out := new(DataDisk)
+	out := new(DataDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DateSpan) DeepCopyInto(out *DateSpan) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DateSpan.
+func (in *DateSpan) DeepCopy() *DateSpan {
+	if in == nil {
+		return nil
+	}
+	out := new(DateSpan)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1807,6 +2587,56 @@ func (in *ExtendedLocationSpec) DeepCopy() *ExtendedLocationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionPlan) DeepCopyInto(out *ExtensionPlan) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionPlan.
+func (in *ExtensionPlan) DeepCopy() *ExtensionPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionScope) DeepCopyInto(out *ExtensionScope) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionScope.
+func (in *ExtensionScope) DeepCopy() *ExtensionScope {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowLogs) DeepCopyInto(out *FlowLogs) {
+	*out = *in
+	if in.TrafficAnalytics != nil {
+		in, out := &in.TrafficAnalytics, &out.TrafficAnalytics
+		*out = new(TrafficAnalytics)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowLogs.
+func (in *FlowLogs) DeepCopy() *FlowLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendIP) DeepCopyInto(out *FrontendIP) {
 	*out = *in
@@ -2087,101 +2917,372 @@ func (in *LinuxOSConfig) DeepCopyInto(out *LinuxOSConfig) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxOSConfig.
-func (in *LinuxOSConfig) DeepCopy() *LinuxOSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxOSConfig.
+func (in *LinuxOSConfig) DeepCopy() *LinuxOSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LinuxOSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerClassSpec) DeepCopyInto(out *LoadBalancerClassSpec) {
+	*out = *in
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerClassSpec.
+func (in *LoadBalancerClassSpec) DeepCopy() *LoadBalancerClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerProfile) DeepCopyInto(out *LoadBalancerProfile) {
+	*out = *in
+	if in.ManagedOutboundIPs != nil {
+		in, out := &in.ManagedOutboundIPs, &out.ManagedOutboundIPs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OutboundIPPrefixes != nil {
+		in, out := &in.OutboundIPPrefixes, &out.OutboundIPPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OutboundIPs != nil {
+		in, out := &in.OutboundIPs, &out.OutboundIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllocatedOutboundPorts != nil {
+		in, out := &in.AllocatedOutboundPorts, &out.AllocatedOutboundPorts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerProfile.
+func (in *LoadBalancerProfile) DeepCopy() *LoadBalancerProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+	*out = *in
+	if in.FrontendIPs != nil {
+		in, out := &in.FrontendIPs, &out.FrontendIPs
+		*out = make([]FrontendIP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FrontendIPsCount != nil {
+		in, out := &in.FrontendIPsCount, &out.FrontendIPsCount
+		*out = new(int32)
+		**out = **in
+	}
+	out.BackendPool = in.BackendPool
+	in.LoadBalancerClassSpec.DeepCopyInto(&out.LoadBalancerClassSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
+func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceConfiguration) DeepCopyInto(out *MaintenanceConfiguration) {
+	*out = *in
+	if in.TimeInWeek != nil {
+		in, out := &in.TimeInWeek, &out.TimeInWeek
+		*out = make([]TimeInWeek, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NotAllowedTime != nil {
+		in, out := &in.NotAllowedTime, &out.NotAllowedTime
+		*out = make([]TimeSpan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceConfiguration.
+func (in *MaintenanceConfiguration) DeepCopy() *MaintenanceConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSchedule) DeepCopyInto(out *MaintenanceSchedule) {
+	*out = *in
+	if in.Daily != nil {
+		in, out := &in.Daily, &out.Daily
+		*out = new(DailySchedule)
+		**out = **in
+	}
+	if in.Weekly != nil {
+		in, out := &in.Weekly, &out.Weekly
+		*out = new(WeeklySchedule)
+		**out = **in
+	}
+	if in.AbsoluteMonthly != nil {
+		in, out := &in.AbsoluteMonthly, &out.AbsoluteMonthly
+		*out = new(AbsoluteMonthlySchedule)
+		**out = **in
+	}
+	if in.RelativeMonthly != nil {
+		in, out := &in.RelativeMonthly, &out.RelativeMonthly
+		*out = new(RelativeMonthlySchedule)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSchedule.
+func (in *MaintenanceSchedule) DeepCopy() *MaintenanceSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	in.Schedule.DeepCopyInto(&out.Schedule)
+	if in.NotAllowedDates != nil {
+		in, out := &in.NotAllowedDates, &out.NotAllowedDates
+		*out = make([]DateSpan, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterAutoUpgradeProfile) DeepCopyInto(out *ManagedClusterAutoUpgradeProfile) {
+	*out = *in
+	if in.UpgradeChannel != nil {
+		in, out := &in.UpgradeChannel, &out.UpgradeChannel
+		*out = new(UpgradeChannel)
+		**out = **in
+	}
+	if in.NodeOSUpgradeChannel != nil {
+		in, out := &in.NodeOSUpgradeChannel, &out.NodeOSUpgradeChannel
+		*out = new(NodeOSUpgradeChannel)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterAutoUpgradeProfile.
+func (in *ManagedClusterAutoUpgradeProfile) DeepCopy() *ManagedClusterAutoUpgradeProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterAutoUpgradeProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterMonitoring) DeepCopyInto(out *ManagedClusterMonitoring) {
+	*out = *in
+	if in.LogAnalyticsWorkspaceResourceID != nil {
+		in, out := &in.LogAnalyticsWorkspaceResourceID, &out.LogAnalyticsWorkspaceResourceID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ManagedPrometheus != nil {
+		in, out := &in.ManagedPrometheus, &out.ManagedPrometheus
+		*out = new(ManagedPrometheus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterMonitoring.
+func (in *ManagedClusterMonitoring) DeepCopy() *ManagedClusterMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterMonitoringStatus) DeepCopyInto(out *ManagedClusterMonitoringStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterMonitoringStatus.
+func (in *ManagedClusterMonitoringStatus) DeepCopy() *ManagedClusterMonitoringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterMonitoringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterSecurityProfile) DeepCopyInto(out *ManagedClusterSecurityProfile) {
+	*out = *in
+	if in.AzureKeyVaultKms != nil {
+		in, out := &in.AzureKeyVaultKms, &out.AzureKeyVaultKms
+		*out = new(AzureKeyVaultKms)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defender != nil {
+		in, out := &in.Defender, &out.Defender
+		*out = new(ManagedClusterSecurityProfileDefender)
+		**out = **in
+	}
+	if in.ImageCleaner != nil {
+		in, out := &in.ImageCleaner, &out.ImageCleaner
+		*out = new(ManagedClusterSecurityProfileImageCleaner)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(ManagedClusterSecurityProfileWorkloadIdentity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterSecurityProfile.
+func (in *ManagedClusterSecurityProfile) DeepCopy() *ManagedClusterSecurityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterSecurityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterSecurityProfileDefender) DeepCopyInto(out *ManagedClusterSecurityProfileDefender) {
+	*out = *in
+	out.SecurityMonitoring = in.SecurityMonitoring
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterSecurityProfileDefender.
+func (in *ManagedClusterSecurityProfileDefender) DeepCopy() *ManagedClusterSecurityProfileDefender {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterSecurityProfileDefender)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterSecurityProfileDefenderSecurityMonitoring) DeepCopyInto(out *ManagedClusterSecurityProfileDefenderSecurityMonitoring) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterSecurityProfileDefenderSecurityMonitoring.
+func (in *ManagedClusterSecurityProfileDefenderSecurityMonitoring) DeepCopy() *ManagedClusterSecurityProfileDefenderSecurityMonitoring {
 	if in == nil {
 		return nil
 	}
-	out := new(LinuxOSConfig)
+	out := new(ManagedClusterSecurityProfileDefenderSecurityMonitoring)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerClassSpec) DeepCopyInto(out *LoadBalancerClassSpec) {
+func (in *ManagedClusterSecurityProfileImageCleaner) DeepCopyInto(out *ManagedClusterSecurityProfileImageCleaner) {
 	*out = *in
-	if in.IdleTimeoutInMinutes != nil {
-		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+	if in.IntervalHours != nil {
+		in, out := &in.IntervalHours, &out.IntervalHours
 		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerClassSpec.
-func (in *LoadBalancerClassSpec) DeepCopy() *LoadBalancerClassSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterSecurityProfileImageCleaner.
+func (in *ManagedClusterSecurityProfileImageCleaner) DeepCopy() *ManagedClusterSecurityProfileImageCleaner {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerClassSpec)
+	out := new(ManagedClusterSecurityProfileImageCleaner)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerProfile) DeepCopyInto(out *LoadBalancerProfile) {
+func (in *ManagedClusterSecurityProfileWorkloadIdentity) DeepCopyInto(out *ManagedClusterSecurityProfileWorkloadIdentity) {
 	*out = *in
-	if in.ManagedOutboundIPs != nil {
-		in, out := &in.ManagedOutboundIPs, &out.ManagedOutboundIPs
-		*out = new(int32)
-		**out = **in
-	}
-	if in.OutboundIPPrefixes != nil {
-		in, out := &in.OutboundIPPrefixes, &out.OutboundIPPrefixes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.OutboundIPs != nil {
-		in, out := &in.OutboundIPs, &out.OutboundIPs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AllocatedOutboundPorts != nil {
-		in, out := &in.AllocatedOutboundPorts, &out.AllocatedOutboundPorts
-		*out = new(int32)
-		**out = **in
-	}
-	if in.IdleTimeoutInMinutes != nil {
-		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerProfile.
-func (in *LoadBalancerProfile) DeepCopy() *LoadBalancerProfile {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterSecurityProfileWorkloadIdentity.
+func (in *ManagedClusterSecurityProfileWorkloadIdentity) DeepCopy() *ManagedClusterSecurityProfileWorkloadIdentity {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerProfile)
+	out := new(ManagedClusterSecurityProfileWorkloadIdentity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+func (in *ManagedControlPlanePodSubnet) DeepCopyInto(out *ManagedControlPlanePodSubnet) {
 	*out = *in
-	if in.FrontendIPs != nil {
-		in, out := &in.FrontendIPs, &out.FrontendIPs
-		*out = make([]FrontendIP, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.FrontendIPsCount != nil {
-		in, out := &in.FrontendIPsCount, &out.FrontendIPsCount
-		*out = new(int32)
-		**out = **in
-	}
-	out.BackendPool = in.BackendPool
-	in.LoadBalancerClassSpec.DeepCopyInto(&out.LoadBalancerClassSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
-func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlanePodSubnet.
+func (in *ManagedControlPlanePodSubnet) DeepCopy() *ManagedControlPlanePodSubnet {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerSpec)
+	out := new(ManagedControlPlanePodSubnet)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2219,6 +3320,11 @@ func (in *ManagedControlPlaneSubnet) DeepCopy() *ManagedControlPlaneSubnet {
 func (in *ManagedControlPlaneVirtualNetwork) DeepCopyInto(out *ManagedControlPlaneVirtualNetwork) {
 	*out = *in
 	in.Subnet.DeepCopyInto(&out.Subnet)
+	if in.PodSubnets != nil {
+		in, out := &in.PodSubnets, &out.PodSubnets
+		*out = make([]ManagedControlPlanePodSubnet, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneVirtualNetwork.
@@ -2281,6 +3387,61 @@ func (in *ManagedMachinePoolScaling) DeepCopy() *ManagedMachinePoolScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedMachinePoolUpgradeSettings) DeepCopyInto(out *ManagedMachinePoolUpgradeSettings) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.DrainTimeoutInMinutes != nil {
+		in, out := &in.DrainTimeoutInMinutes, &out.DrainTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSoakDurationInMinutes != nil {
+		in, out := &in.NodeSoakDurationInMinutes, &out.NodeSoakDurationInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedMachinePoolUpgradeSettings.
+func (in *ManagedMachinePoolUpgradeSettings) DeepCopy() *ManagedMachinePoolUpgradeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedMachinePoolUpgradeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedPrometheus) DeepCopyInto(out *ManagedPrometheus) {
+	*out = *in
+	if in.MetricLabelsAllowlist != nil {
+		in, out := &in.MetricLabelsAllowlist, &out.MetricLabelsAllowlist
+		*out = new(string)
+		**out = **in
+	}
+	if in.MetricAnnotationsAllowList != nil {
+		in, out := &in.MetricAnnotationsAllowList, &out.MetricAnnotationsAllowList
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedPrometheus.
+func (in *ManagedPrometheus) DeepCopy() *ManagedPrometheus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedPrometheus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NatGateway) DeepCopyInto(out *NatGateway) {
 	*out = *in
@@ -2316,6 +3477,11 @@ func (in *NatGatewayClassSpec) DeepCopy() *NatGatewayClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkClassSpec) DeepCopyInto(out *NetworkClassSpec) {
 	*out = *in
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make(ApplicationSecurityGroups, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkClassSpec.
@@ -2336,6 +3502,16 @@ func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroup != nil {
+		in, out := &in.SecurityGroup, &out.SecurityGroup
+		*out = new(NetworkInterfaceSecurityGroup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterface.
@@ -2348,6 +3524,28 @@ func (in *NetworkInterface) DeepCopy() *NetworkInterface {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterfaceSecurityGroup) DeepCopyInto(out *NetworkInterfaceSecurityGroup) {
+	*out = *in
+	if in.SecurityRules != nil {
+		in, out := &in.SecurityRules, &out.SecurityRules
+		*out = make(SecurityRules, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceSecurityGroup.
+func (in *NetworkInterfaceSecurityGroup) DeepCopy() *NetworkInterfaceSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
@@ -2370,7 +3568,7 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(LoadBalancerSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.NetworkClassSpec = in.NetworkClassSpec
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -2386,7 +3584,7 @@ func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkTemplateSpec) DeepCopyInto(out *NetworkTemplateSpec) {
 	*out = *in
-	out.NetworkClassSpec = in.NetworkClassSpec
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 	in.Vnet.DeepCopyInto(&out.Vnet)
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
@@ -2622,9 +3820,44 @@ func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelativeMonthlySchedule) DeepCopyInto(out *RelativeMonthlySchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelativeMonthlySchedule.
+func (in *RelativeMonthlySchedule) DeepCopy() *RelativeMonthlySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(RelativeMonthlySchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make(Routes, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTable.
@@ -2637,6 +3870,25 @@ func (in *RouteTable) DeepCopy() *RouteTable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Routes) DeepCopyInto(out *Routes) {
+	{
+		in := &in
+		*out = make(Routes, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Routes.
+func (in Routes) DeepCopy() Routes {
+	if in == nil {
+		return nil
+	}
+	out := new(Routes)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
@@ -2670,6 +3922,11 @@ func (in *SecurityGroupClass) DeepCopyInto(out *SecurityGroupClass) {
 			(*out)[key] = val
 		}
 	}
+	if in.FlowLogs != nil {
+		in, out := &in.FlowLogs, &out.FlowLogs
+		*out = new(FlowLogs)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupClass.
@@ -2730,6 +3987,16 @@ func (in *SecurityRule) DeepCopyInto(out *SecurityRule) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SourceApplicationSecurityGroups != nil {
+		in, out := &in.SourceApplicationSecurityGroups, &out.SourceApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationApplicationSecurityGroups != nil {
+		in, out := &in.DestinationApplicationSecurityGroups, &out.DestinationApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityRule.
@@ -2851,6 +4118,16 @@ func (in *SubnetClassSpec) DeepCopyInto(out *SubnetClassSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Delegations != nil {
+		in, out := &in.Delegations, &out.Delegations
+		*out = make(SubnetDelegations, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceEndpointPolicies != nil {
+		in, out := &in.ServiceEndpointPolicies, &out.ServiceEndpointPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetClassSpec.
@@ -2863,11 +4140,45 @@ func (in *SubnetClassSpec) DeepCopy() *SubnetClassSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetDelegation) DeepCopyInto(out *SubnetDelegation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetDelegation.
+func (in *SubnetDelegation) DeepCopy() *SubnetDelegation {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetDelegation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in SubnetDelegations) DeepCopyInto(out *SubnetDelegations) {
+	{
+		in := &in
+		*out = make(SubnetDelegations, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetDelegations.
+func (in SubnetDelegations) DeepCopy() SubnetDelegations {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetDelegations)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
 	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
-	out.RouteTable = in.RouteTable
+	in.RouteTable.DeepCopyInto(&out.RouteTable)
 	in.NatGateway.DeepCopyInto(&out.NatGateway)
 	in.SubnetClassSpec.DeepCopyInto(&out.SubnetClassSpec)
 }
@@ -3167,6 +4478,63 @@ func (in Taints) DeepCopy() Taints {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeInWeek) DeepCopyInto(out *TimeInWeek) {
+	*out = *in
+	if in.HourSlots != nil {
+		in, out := &in.HourSlots, &out.HourSlots
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeInWeek.
+func (in *TimeInWeek) DeepCopy() *TimeInWeek {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeInWeek)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeSpan) DeepCopyInto(out *TimeSpan) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSpan.
+func (in *TimeSpan) DeepCopy() *TimeSpan {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSpan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficAnalytics) DeepCopyInto(out *TrafficAnalytics) {
+	*out = *in
+	if in.IntervalInMinutes != nil {
+		in, out := &in.IntervalInMinutes, &out.IntervalInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficAnalytics.
+func (in *TrafficAnalytics) DeepCopy() *TrafficAnalytics {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficAnalytics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UefiSettings) DeepCopyInto(out *UefiSettings) {
 	*out = *in
@@ -3453,3 +4821,18 @@ func (in *VnetTemplateSpec) DeepCopy() *VnetTemplateSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeeklySchedule) DeepCopyInto(out *WeeklySchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeeklySchedule.
+func (in *WeeklySchedule) DeepCopy() *WeeklySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(WeeklySchedule)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -35,12 +35,54 @@ const (
 	// for annotation formatting rules.
 	ManagedClusterTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-tags-managedcluster"
 
+	// MaintenanceConfigurationsLastAppliedAnnotation is the key for the AzureManagedControlPlane
+	// object annotation which tracks the maintenance configurations applied to managed clusters.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	MaintenanceConfigurationsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-maintenance-configurations"
+
+	// AKSExtensionsLastAppliedAnnotation is the key for the AzureManagedControlPlane
+	// object annotation which tracks the extensions installed on managed clusters.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	AKSExtensionsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-aks-extensions"
+
+	// NodeLabelsLastAppliedAnnotation is the key for the AzureManagedMachinePool
+	// object annotation which tracks the node labels set on agent pools.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	NodeLabelsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-node-labels"
+
+	// NodeTaintsLastAppliedAnnotation is the key for the AzureManagedMachinePool
+	// object annotation which tracks the node taints set on agent pools.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	NodeTaintsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-node-taints"
+
+	// ApplicationSecurityGroupsLastAppliedAnnotation is the key for the Azure Cluster
+	// object annotation which tracks the application security groups created for the cluster.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	ApplicationSecurityGroupsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-application-security-groups"
+
+	// FlowLogsLastAppliedAnnotation is the key for the Azure Cluster
+	// object annotation which tracks the network security group flow logs created for the cluster.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	FlowLogsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-flow-logs"
+
 	// SecurityRuleLastAppliedAnnotation is the key for the Azure Cluster
 	// object annotation which tracks the security rules for security groups.
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	SecurityRuleLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-security-rules"
 
+	// RoutesLastAppliedAnnotation is the key for the Azure Cluster
+	// object annotation which tracks the routes of route tables.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	RoutesLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-routes"
+
 	// CustomDataHashAnnotation is the key for the machine object annotation
 	// which tracks the hash of the custom data.
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
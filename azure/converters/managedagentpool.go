@@ -17,43 +17,173 @@ limitations under the License.
 package converters
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 )
 
 // AgentPoolToManagedClusterAgentPoolProfile converts a AgentPoolSpec to an Azure SDK ManagedClusterAgentPoolProfile used in managedcluster reconcile.
 func AgentPoolToManagedClusterAgentPoolProfile(pool armcontainerservice.AgentPool) armcontainerservice.ManagedClusterAgentPoolProfile {
 	properties := pool.Properties
 	agentPool := armcontainerservice.ManagedClusterAgentPoolProfile{
-		Name:                 pool.Name, // Note: if converting from agentPoolSpec.Parameters(), this field will not be set
-		VMSize:               properties.VMSize,
-		OSType:               properties.OSType,
-		OSDiskSizeGB:         properties.OSDiskSizeGB,
-		Count:                properties.Count,
-		Type:                 properties.Type,
-		OrchestratorVersion:  properties.OrchestratorVersion,
-		VnetSubnetID:         properties.VnetSubnetID,
-		Mode:                 properties.Mode,
-		EnableAutoScaling:    properties.EnableAutoScaling,
-		MaxCount:             properties.MaxCount,
-		MinCount:             properties.MinCount,
-		NodeTaints:           properties.NodeTaints,
-		AvailabilityZones:    properties.AvailabilityZones,
-		MaxPods:              properties.MaxPods,
-		OSDiskType:           properties.OSDiskType,
-		NodeLabels:           properties.NodeLabels,
-		EnableUltraSSD:       properties.EnableUltraSSD,
-		EnableNodePublicIP:   properties.EnableNodePublicIP,
-		NodePublicIPPrefixID: properties.NodePublicIPPrefixID,
-		ScaleSetPriority:     properties.ScaleSetPriority,
-		ScaleDownMode:        properties.ScaleDownMode,
-		SpotMaxPrice:         properties.SpotMaxPrice,
-		Tags:                 properties.Tags,
-		KubeletDiskType:      properties.KubeletDiskType,
-		LinuxOSConfig:        properties.LinuxOSConfig,
-		EnableFIPS:           properties.EnableFIPS,
+		Name:                       pool.Name, // Note: if converting from agentPoolSpec.Parameters(), this field will not be set
+		VMSize:                     properties.VMSize,
+		OSType:                     properties.OSType,
+		OSSKU:                      properties.OSSKU,
+		OSDiskSizeGB:               properties.OSDiskSizeGB,
+		Count:                      properties.Count,
+		Type:                       properties.Type,
+		OrchestratorVersion:        properties.OrchestratorVersion,
+		VnetSubnetID:               properties.VnetSubnetID,
+		PodSubnetID:                properties.PodSubnetID,
+		ProximityPlacementGroupID:  properties.ProximityPlacementGroupID,
+		HostGroupID:                properties.HostGroupID,
+		CapacityReservationGroupID: properties.CapacityReservationGroupID,
+		GpuInstanceProfile:         properties.GpuInstanceProfile,
+		Mode:                       properties.Mode,
+		EnableAutoScaling:          properties.EnableAutoScaling,
+		MaxCount:                   properties.MaxCount,
+		MinCount:                   properties.MinCount,
+		NodeTaints:                 properties.NodeTaints,
+		AvailabilityZones:          properties.AvailabilityZones,
+		MaxPods:                    properties.MaxPods,
+		OSDiskType:                 properties.OSDiskType,
+		NodeLabels:                 properties.NodeLabels,
+		EnableUltraSSD:             properties.EnableUltraSSD,
+		EnableNodePublicIP:         properties.EnableNodePublicIP,
+		NodePublicIPPrefixID:       properties.NodePublicIPPrefixID,
+		ScaleSetPriority:           properties.ScaleSetPriority,
+		ScaleDownMode:              properties.ScaleDownMode,
+		SpotMaxPrice:               properties.SpotMaxPrice,
+		Tags:                       properties.Tags,
+		KubeletDiskType:            properties.KubeletDiskType,
+		LinuxOSConfig:              properties.LinuxOSConfig,
+		EnableFIPS:                 properties.EnableFIPS,
+		UpgradeSettings:            properties.UpgradeSettings,
 	}
 	if properties.KubeletConfig != nil {
 		agentPool.KubeletConfig = properties.KubeletConfig
 	}
 	return agentPool
 }
+
+// ManagedClusterAgentPoolProfileToAdoptedAgentPool converts an agent pool profile of an existing managed cluster to
+// the AzureManagedMachinePool spec, replicas and version which describe it.
+func ManagedClusterAgentPoolProfileToAdoptedAgentPool(profile armcontainerservice.ManagedClusterAgentPoolProfile) azure.AdoptedAgentPool {
+	spec := infrav1.AzureManagedMachinePoolSpec{
+		Name:                       profile.Name,
+		Mode:                       string(ptr.Deref(profile.Mode, "")),
+		SKU:                        ptr.Deref(profile.VMSize, ""),
+		OSDiskSizeGB:               profile.OSDiskSizeGB,
+		AvailabilityZones:          azure.DerefSlice(profile.AvailabilityZones),
+		MaxPods:                    profile.MaxPods,
+		OsDiskType:                 (*string)(profile.OSDiskType),
+		EnableUltraSSD:             profile.EnableUltraSSD,
+		OSType:                     (*string)(profile.OSType),
+		OSSKU:                      (*string)(profile.OSSKU),
+		EnableNodePublicIP:         profile.EnableNodePublicIP,
+		NodePublicIPPrefixID:       profile.NodePublicIPPrefixID,
+		ScaleSetPriority:           (*string)(profile.ScaleSetPriority),
+		ScaleDownMode:              (*string)(profile.ScaleDownMode),
+		AdditionalTags:             MapToTags(profile.Tags),
+		KubeletDiskType:            (*infrav1.KubeletDiskType)(profile.KubeletDiskType),
+		EnableFIPS:                 profile.EnableFIPS,
+		ProximityPlacementGroupID:  profile.ProximityPlacementGroupID,
+		HostGroupID:                profile.HostGroupID,
+		CapacityReservationGroupID: profile.CapacityReservationGroupID,
+		GPUInstanceProfile:         (*infrav1.GPUInstanceProfile)(profile.GpuInstanceProfile),
+	}
+
+	if profile.VnetSubnetID != nil {
+		spec.SubnetName = ptr.To(lastSegment(*profile.VnetSubnetID))
+	}
+	if profile.PodSubnetID != nil {
+		spec.PodSubnetName = ptr.To(lastSegment(*profile.PodSubnetID))
+	}
+
+	// System labels are set by AKS and cannot be set through the spec.
+	for key, value := range profile.NodeLabels {
+		if azureutil.IsAzureSystemNodeLabelKey(key) {
+			continue
+		}
+		if spec.NodeLabels == nil {
+			spec.NodeLabels = map[string]string{}
+		}
+		spec.NodeLabels[key] = ptr.Deref(value, "")
+	}
+
+	for _, taint := range azure.DerefSlice(profile.NodeTaints) {
+		spec.Taints = append(spec.Taints, nodeTaintToTaint(taint))
+	}
+
+	if ptr.Deref(profile.EnableAutoScaling, false) {
+		spec.Scaling = &infrav1.ManagedMachinePoolScaling{
+			MinSize: profile.MinCount,
+			MaxSize: profile.MaxCount,
+		}
+	}
+
+	if profile.SpotMaxPrice != nil {
+		spotMaxPrice := resource.MustParse(strconv.FormatFloat(float64(*profile.SpotMaxPrice), 'f', -1, 32))
+		spec.SpotMaxPrice = &spotMaxPrice
+	}
+
+	if settings := profile.UpgradeSettings; settings != nil &&
+		(settings.MaxSurge != nil || settings.DrainTimeoutInMinutes != nil || settings.NodeSoakDurationInMinutes != nil) {
+		spec.UpgradeSettings = &infrav1.ManagedMachinePoolUpgradeSettings{
+			DrainTimeoutInMinutes:     settings.DrainTimeoutInMinutes,
+			NodeSoakDurationInMinutes: settings.NodeSoakDurationInMinutes,
+		}
+		if settings.MaxSurge != nil {
+			spec.UpgradeSettings.MaxSurge = ptr.To(intstr.Parse(*settings.MaxSurge))
+		}
+	}
+
+	if config := profile.KubeletConfig; config != nil {
+		spec.KubeletConfig = &infrav1.KubeletConfig{
+			CPUManagerPolicy:      (*infrav1.CPUManagerPolicy)(config.CPUManagerPolicy),
+			CPUCfsQuota:           config.CPUCfsQuota,
+			CPUCfsQuotaPeriod:     config.CPUCfsQuotaPeriod,
+			ImageGcHighThreshold:  config.ImageGcHighThreshold,
+			ImageGcLowThreshold:   config.ImageGcLowThreshold,
+			TopologyManagerPolicy: (*infrav1.TopologyManagerPolicy)(config.TopologyManagerPolicy),
+			AllowedUnsafeSysctls:  azure.DerefSlice(config.AllowedUnsafeSysctls),
+			FailSwapOn:            config.FailSwapOn,
+			ContainerLogMaxSizeMB: config.ContainerLogMaxSizeMB,
+			ContainerLogMaxFiles:  config.ContainerLogMaxFiles,
+			PodMaxPids:            config.PodMaxPids,
+		}
+	}
+
+	adopted := azure.AdoptedAgentPool{
+		Spec:     spec,
+		Replicas: ptr.Deref(profile.Count, 0),
+	}
+	if profile.OrchestratorVersion != nil {
+		adopted.Version = ptr.To("v" + *profile.OrchestratorVersion)
+	}
+	return adopted
+}
+
+// nodeTaintToTaint parses an AKS node taint of the form key=value:effect.
+func nodeTaintToTaint(nodeTaint string) infrav1.Taint {
+	keyValue, effect, _ := strings.Cut(nodeTaint, ":")
+	key, value, _ := strings.Cut(keyValue, "=")
+	return infrav1.Taint{
+		Key:    key,
+		Value:  value,
+		Effect: infrav1.TaintEffect(effect),
+	}
+}
+
+// lastSegment returns the last segment of an Azure resource ID.
+func lastSegment(id string) string {
+	return id[strings.LastIndex(id, "/")+1:]
+}
@@ -21,7 +21,11 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
 func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
@@ -37,6 +41,7 @@ func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
 				Properties: &armcontainerservice.ManagedClusterAgentPoolProfileProperties{
 					VMSize:              ptr.To("Standard_D2s_v3"),
 					OSType:              ptr.To(armcontainerservice.OSTypeLinux),
+					OSSKU:               ptr.To(armcontainerservice.OSSKUAzureLinux),
 					OSDiskSizeGB:        ptr.To[int32](100),
 					Count:               ptr.To[int32](2),
 					Type:                ptr.To(armcontainerservice.AgentPoolTypeVirtualMachineScaleSets),
@@ -56,7 +61,15 @@ func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
 					Tags: map[string]*string{
 						"custom": ptr.To("default"),
 					},
-					EnableFIPS: ptr.To(true),
+					EnableFIPS:                 ptr.To(true),
+					ProximityPlacementGroupID:  ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-123/providers/Microsoft.Compute/proximityPlacementGroups/ppg-123"),
+					HostGroupID:                ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-123/providers/Microsoft.Compute/hostGroups/hg-123"),
+					CapacityReservationGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-123/providers/Microsoft.Compute/capacityReservationGroups/crg-123"),
+					GpuInstanceProfile:         ptr.To(armcontainerservice.GPUInstanceProfileMIG1G),
+					UpgradeSettings: &armcontainerservice.AgentPoolUpgradeSettings{
+						MaxSurge:              ptr.To("33%"),
+						DrainTimeoutInMinutes: ptr.To[int32](60),
+					},
 				},
 			},
 
@@ -65,6 +78,7 @@ func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
 					Name:                ptr.To("agentpool1"),
 					VMSize:              ptr.To("Standard_D2s_v3"),
 					OSType:              ptr.To(armcontainerservice.OSTypeLinux),
+					OSSKU:               ptr.To(armcontainerservice.OSSKUAzureLinux),
 					OSDiskSizeGB:        ptr.To[int32](100),
 					Count:               ptr.To[int32](2),
 					Type:                ptr.To(armcontainerservice.AgentPoolTypeVirtualMachineScaleSets),
@@ -84,7 +98,15 @@ func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
 					Tags: map[string]*string{
 						"custom": ptr.To("default"),
 					},
-					EnableFIPS: ptr.To(true),
+					EnableFIPS:                 ptr.To(true),
+					ProximityPlacementGroupID:  ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-123/providers/Microsoft.Compute/proximityPlacementGroups/ppg-123"),
+					HostGroupID:                ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-123/providers/Microsoft.Compute/hostGroups/hg-123"),
+					CapacityReservationGroupID: ptr.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-123/providers/Microsoft.Compute/capacityReservationGroups/crg-123"),
+					GpuInstanceProfile:         ptr.To(armcontainerservice.GPUInstanceProfileMIG1G),
+					UpgradeSettings: &armcontainerservice.AgentPoolUpgradeSettings{
+						MaxSurge:              ptr.To("33%"),
+						DrainTimeoutInMinutes: ptr.To[int32](60),
+					},
 				}))
 			},
 		},
@@ -100,3 +122,124 @@ func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
 		})
 	}
 }
+
+func Test_ManagedClusterAgentPoolProfileToAdoptedAgentPool(t *testing.T) {
+	cases := []struct {
+		name    string
+		profile armcontainerservice.ManagedClusterAgentPoolProfile
+		expect  func(*GomegaWithT, azure.AdoptedAgentPool)
+	}{
+		{
+			name: "Should convert all values correctly",
+			profile: armcontainerservice.ManagedClusterAgentPoolProfile{
+				Name:                ptr.To("pool1"),
+				Mode:                ptr.To(armcontainerservice.AgentPoolModeUser),
+				VMSize:              ptr.To("Standard_D2s_v3"),
+				OSType:              ptr.To(armcontainerservice.OSTypeLinux),
+				OSSKU:               ptr.To(armcontainerservice.OSSKUUbuntu),
+				OSDiskSizeGB:        ptr.To[int32](100),
+				OSDiskType:          ptr.To(armcontainerservice.OSDiskTypeEphemeral),
+				Count:               ptr.To[int32](3),
+				OrchestratorVersion: ptr.To("1.27.3"),
+				VnetSubnetID:        ptr.To("/subscriptions/123/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/nodes"),
+				PodSubnetID:         ptr.To("/subscriptions/123/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/pods"),
+				EnableAutoScaling:   ptr.To(true),
+				MinCount:            ptr.To[int32](1),
+				MaxCount:            ptr.To[int32](5),
+				AvailabilityZones:   []*string{ptr.To("1"), ptr.To("2")},
+				MaxPods:             ptr.To[int32](30),
+				NodeLabels: map[string]*string{
+					"team":                                  ptr.To("payments"),
+					"kubernetes.azure.com/scalesetpriority": ptr.To("spot"),
+				},
+				NodeTaints:       []*string{ptr.To("dedicated=payments:NoSchedule"), ptr.To("gpu:NoExecute")},
+				ScaleSetPriority: ptr.To(armcontainerservice.ScaleSetPrioritySpot),
+				ScaleDownMode:    ptr.To(armcontainerservice.ScaleDownModeDelete),
+				SpotMaxPrice:     ptr.To[float32](0.5),
+				Tags:             map[string]*string{"env": ptr.To("prod")},
+				UpgradeSettings: &armcontainerservice.AgentPoolUpgradeSettings{
+					MaxSurge:                  ptr.To("33%"),
+					DrainTimeoutInMinutes:     ptr.To[int32](60),
+					NodeSoakDurationInMinutes: ptr.To[int32](5),
+				},
+				KubeletConfig: &armcontainerservice.KubeletConfig{
+					CPUManagerPolicy:     ptr.To("static"),
+					AllowedUnsafeSysctls: []*string{ptr.To("net.*")},
+				},
+			},
+			expect: func(g *GomegaWithT, result azure.AdoptedAgentPool) {
+				g.Expect(result).To(Equal(azure.AdoptedAgentPool{
+					Spec: infrav1.AzureManagedMachinePoolSpec{
+						Name:              ptr.To("pool1"),
+						Mode:              "User",
+						SKU:               "Standard_D2s_v3",
+						OSType:            ptr.To("Linux"),
+						OSSKU:             ptr.To("Ubuntu"),
+						OSDiskSizeGB:      ptr.To[int32](100),
+						OsDiskType:        ptr.To("Ephemeral"),
+						SubnetName:        ptr.To("nodes"),
+						PodSubnetName:     ptr.To("pods"),
+						AvailabilityZones: []string{"1", "2"},
+						MaxPods:           ptr.To[int32](30),
+						NodeLabels:        map[string]string{"team": "payments"},
+						Taints: infrav1.Taints{
+							{Key: "dedicated", Value: "payments", Effect: infrav1.TaintEffect("NoSchedule")},
+							{Key: "gpu", Effect: infrav1.TaintEffect("NoExecute")},
+						},
+						Scaling: &infrav1.ManagedMachinePoolScaling{
+							MinSize: ptr.To[int32](1),
+							MaxSize: ptr.To[int32](5),
+						},
+						ScaleSetPriority: ptr.To("Spot"),
+						ScaleDownMode:    ptr.To("Delete"),
+						SpotMaxPrice:     ptr.To(resource.MustParse("0.5")),
+						AdditionalTags:   infrav1.Tags{"env": "prod"},
+						UpgradeSettings: &infrav1.ManagedMachinePoolUpgradeSettings{
+							MaxSurge:                  ptr.To(intstr.FromString("33%")),
+							DrainTimeoutInMinutes:     ptr.To[int32](60),
+							NodeSoakDurationInMinutes: ptr.To[int32](5),
+						},
+						KubeletConfig: &infrav1.KubeletConfig{
+							CPUManagerPolicy:     ptr.To(infrav1.CPUManagerPolicyStatic),
+							AllowedUnsafeSysctls: []string{"net.*"},
+						},
+					},
+					Replicas: 3,
+					Version:  ptr.To("v1.27.3"),
+				}))
+			},
+		},
+		{
+			name: "Should leave unset values empty",
+			profile: armcontainerservice.ManagedClusterAgentPoolProfile{
+				Name:   ptr.To("pool0"),
+				Mode:   ptr.To(armcontainerservice.AgentPoolModeSystem),
+				VMSize: ptr.To("Standard_D2s_v3"),
+				Count:  ptr.To[int32](1),
+				NodeLabels: map[string]*string{
+					"kubernetes.azure.com/mode": ptr.To("system"),
+				},
+			},
+			expect: func(g *GomegaWithT, result azure.AdoptedAgentPool) {
+				g.Expect(result).To(Equal(azure.AdoptedAgentPool{
+					Spec: infrav1.AzureManagedMachinePoolSpec{
+						Name: ptr.To("pool0"),
+						Mode: "System",
+						SKU:  "Standard_D2s_v3",
+					},
+					Replicas: 1,
+				}))
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewGomegaWithT(t)
+			result := ManagedClusterAgentPoolProfileToAdoptedAgentPool(c.profile)
+			c.expect(g, result)
+		})
+	}
+}
@@ -64,6 +64,16 @@ const (
 	BootstrappingExtensionLinux = "CAPZ.Linux.Bootstrapping"
 	// BootstrappingExtensionWindows is the name of the Windows CAPZ bootstrapping VM extension.
 	BootstrappingExtensionWindows = "CAPZ.Windows.Bootstrapping"
+	// AzureMonitorAgentExtensionLinux is the name of the Linux Azure Monitor Agent VM extension.
+	AzureMonitorAgentExtensionLinux = "AzureMonitorLinuxAgent"
+	// AzureMonitorAgentExtensionWindows is the name of the Windows Azure Monitor Agent VM extension.
+	AzureMonitorAgentExtensionWindows = "AzureMonitorWindowsAgent"
+	// AzureMonitorAgentExtensionLinuxVersion is the major.minor version of the Linux Azure Monitor Agent VM extension installed
+	// when the agent is enabled on a cluster. Another version can be used by adding the extension to the vmExtensions of the machines.
+	AzureMonitorAgentExtensionLinuxVersion = "1.27"
+	// AzureMonitorAgentExtensionWindowsVersion is the major.minor version of the Windows Azure Monitor Agent VM extension installed
+	// when the agent is enabled on a cluster.
+	AzureMonitorAgentExtensionWindowsVersion = "1.18"
 )
 
 const (
@@ -77,6 +87,11 @@ const (
 	Global = "global"
 )
 
+const (
+	// DefaultNetworkWatcherResourceGroup is the resource group in which Azure creates Network Watchers.
+	DefaultNetworkWatcherResourceGroup = "NetworkWatcherRG"
+)
+
 const (
 	// PrivateAPIServerHostname will be used as the api server hostname for private clusters.
 	PrivateAPIServerHostname = "apiserver"
@@ -172,6 +187,21 @@ func GenerateNICName(machineName string, multiNIC bool, index int) string {
 	return fmt.Sprintf("%s-nic", machineName)
 }
 
+// GenerateNICSecurityGroupName generates the name of a network security group attached to a network interface.
+func GenerateNICSecurityGroupName(nicName string) string {
+	return fmt.Sprintf("%s-nsg", nicName)
+}
+
+// GenerateNetworkWatcherName generates the name of the Network Watcher Azure creates for a location.
+func GenerateNetworkWatcherName(location string) string {
+	return fmt.Sprintf("NetworkWatcher_%s", location)
+}
+
+// GenerateFlowLogName generates the name of the flow log of a network security group.
+func GenerateFlowLogName(nsgName string) string {
+	return fmt.Sprintf("%s-flowlog", nsgName)
+}
+
 // GeneratePublicNICName generates the name of a public network interface based on the name of a VM.
 func GeneratePublicNICName(machineName string) string {
 	return fmt.Sprintf("%s-public-nic", machineName)
@@ -240,6 +270,16 @@ func SecurityGroupID(subscriptionID, resourceGroup, nsgName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkSecurityGroups/%s", subscriptionID, resourceGroup, nsgName)
 }
 
+// ApplicationSecurityGroupID returns the azure resource ID for a given application security group.
+func ApplicationSecurityGroupID(subscriptionID, resourceGroup, asgName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationSecurityGroups/%s", subscriptionID, resourceGroup, asgName)
+}
+
+// FlowLogID returns the azure resource ID for a given flow log.
+func FlowLogID(subscriptionID, resourceGroup, networkWatcherName, flowLogName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkWatchers/%s/flowLogs/%s", subscriptionID, resourceGroup, networkWatcherName, flowLogName)
+}
+
 // NatGatewayID returns the azure resource ID for a given NAT gateway.
 func NatGatewayID(subscriptionID, resourceGroup, natgatewayName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/natGateways/%s", subscriptionID, resourceGroup, natgatewayName)
@@ -332,6 +372,30 @@ func GetBootstrappingVMExtension(osType string, cloud string, vmName string, cpu
 	return nil
 }
 
+// GetAzureMonitorAgentVMExtension returns the Azure Monitor Agent VM extension for the OS of a VM.
+// See https://learn.microsoft.com/azure/azure-monitor/agents/azure-monitor-agent-manage for details.
+// The agent sends logs and metrics to the destinations of the data collection rules associated with the VM.
+func GetAzureMonitorAgentVMExtension(osType string, vmName string) *ExtensionSpec {
+	switch osType {
+	case LinuxOS:
+		return &ExtensionSpec{
+			Name:      AzureMonitorAgentExtensionLinux,
+			VMName:    vmName,
+			Publisher: "Microsoft.Azure.Monitor",
+			Version:   AzureMonitorAgentExtensionLinuxVersion,
+		}
+	case WindowsOS:
+		return &ExtensionSpec{
+			Name:      AzureMonitorAgentExtensionWindows,
+			VMName:    vmName,
+			Publisher: "Microsoft.Azure.Monitor",
+			Version:   AzureMonitorAgentExtensionWindowsVersion,
+		}
+	}
+
+	return nil
+}
+
 // UserAgent specifies a string to append to the agent identifier.
 func UserAgent() string {
 	return fmt.Sprintf("cluster-api-provider-azure/%s", version.Get().String())
@@ -93,6 +93,12 @@ type ClusterDescriber interface {
 	FailureDomains() []*string
 }
 
+// AzureMonitorAgentDescriber is an interface which can tell whether the Azure Monitor Agent is to be installed on the
+// machines of a cluster.
+type AzureMonitorAgentDescriber interface {
+	AzureMonitorAgentEnabled() bool
+}
+
 // AsyncStatusUpdater is an interface used to keep track of long running operations in Status that has Conditions and Futures.
 type AsyncStatusUpdater interface {
 	SetLongRunningOperationState(*infrav1.Future)
@@ -887,6 +887,43 @@ func (mr *MockClusterDescriberMockRecorder) Token() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockClusterDescriber)(nil).Token))
 }
 
+// MockAzureMonitorAgentDescriber is a mock of AzureMonitorAgentDescriber interface.
+type MockAzureMonitorAgentDescriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockAzureMonitorAgentDescriberMockRecorder
+}
+
+// MockAzureMonitorAgentDescriberMockRecorder is the mock recorder for MockAzureMonitorAgentDescriber.
+type MockAzureMonitorAgentDescriberMockRecorder struct {
+	mock *MockAzureMonitorAgentDescriber
+}
+
+// NewMockAzureMonitorAgentDescriber creates a new mock instance.
+func NewMockAzureMonitorAgentDescriber(ctrl *gomock.Controller) *MockAzureMonitorAgentDescriber {
+	mock := &MockAzureMonitorAgentDescriber{ctrl: ctrl}
+	mock.recorder = &MockAzureMonitorAgentDescriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAzureMonitorAgentDescriber) EXPECT() *MockAzureMonitorAgentDescriberMockRecorder {
+	return m.recorder
+}
+
+// AzureMonitorAgentEnabled mocks base method.
+func (m *MockAzureMonitorAgentDescriber) AzureMonitorAgentEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureMonitorAgentEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// AzureMonitorAgentEnabled indicates an expected call of AzureMonitorAgentEnabled.
+func (mr *MockAzureMonitorAgentDescriberMockRecorder) AzureMonitorAgentEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureMonitorAgentEnabled", reflect.TypeOf((*MockAzureMonitorAgentDescriber)(nil).AzureMonitorAgentEnabled))
+}
+
 // MockAsyncStatusUpdater is a mock of AsyncStatusUpdater interface.
 type MockAsyncStatusUpdater struct {
 	ctrl     *gomock.Controller
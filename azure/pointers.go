@@ -40,6 +40,21 @@ func PtrSlice[T any](p *[]T) []*T {
 	return s
 }
 
+// DerefSlice returns a slice of values from a slice of pointers, skipping nil pointers. It returns nil if
+// the slice is empty.
+func DerefSlice[T any](s []*T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	d := make([]T, 0, len(s))
+	for _, v := range s {
+		if v != nil {
+			d = append(d, *v)
+		}
+	}
+	return d
+}
+
 // AliasOrNil returns a pointer to a string-derived type from a passed string pointer,
 // or nil if the pointer is nil or an empty string.
 func AliasOrNil[T ~string](s *string) *T {
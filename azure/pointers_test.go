@@ -108,6 +108,37 @@ func TestPtrSlice(t *testing.T) {
 	}
 }
 
+func TestDerefSlice(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Arg      []*string
+		Expected []string
+	}{
+		{
+			Name:     "Should return nil if the slice is nil",
+			Arg:      nil,
+			Expected: nil,
+		},
+		{
+			Name:     "Should return nil if the slice is empty",
+			Arg:      []*string{},
+			Expected: nil,
+		},
+		{
+			Name:     "Should return slice of values from a slice of pointers, skipping nil pointers",
+			Arg:      []*string{ptr.To("foo"), nil, ptr.To("bar")},
+			Expected: []string{"foo", "bar"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			actual := DerefSlice(tc.Arg)
+			g.Expect(tc.Expected).To(Equal(actual))
+		})
+	}
+}
+
 func TestAliasOrNil(t *testing.T) {
 	type TestAlias string
 	cases := []struct {
@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+)
+
+func TestAnnotationJSON(t *testing.T) {
+	g := NewWithT(t)
+
+	amp := &infrav1exp.AzureMachinePool{}
+	out, err := annotationJSON(amp, "foo")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(BeEmpty())
+
+	g.Expect(updateAnnotationJSON(amp, "foo", map[string]interface{}{"bar": "baz"})).To(Succeed())
+	g.Expect(amp.Annotations).To(HaveKeyWithValue("foo", `{"bar":"baz"}`))
+
+	out, err = annotationJSON(amp, "foo")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(Equal(map[string]interface{}{"bar": "baz"}))
+
+	amp.Annotations["foo"] = "not json"
+	_, err = annotationJSON(amp, "foo")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestLastAppliedSecurityRules(t *testing.T) {
+	g := NewWithT(t)
+
+	azureMachine := &infrav1.AzureMachine{}
+	g.Expect(lastAppliedSecurityRules(azureMachine, "my-nsg")).To(BeEmpty())
+
+	g.Expect(updateAnnotationJSON(azureMachine, azure.SecurityRuleLastAppliedAnnotation, map[string]interface{}{
+		"my-nsg": map[string]interface{}{"allow_ssh": "ssh"},
+	})).To(Succeed())
+	g.Expect(lastAppliedSecurityRules(azureMachine, "my-nsg")).To(Equal(map[string]interface{}{"allow_ssh": "ssh"}))
+	g.Expect(lastAppliedSecurityRules(azureMachine, "other-nsg")).To(BeEmpty())
+}
@@ -18,7 +18,6 @@ package scope
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"hash/fnv"
 	"sort"
@@ -32,7 +31,9 @@ import (
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
@@ -312,11 +313,13 @@ func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		if subnet.RouteTable.Name != "" {
 			specs = append(specs, &routetables.RouteTableSpec{
-				Name:           subnet.RouteTable.Name,
-				Location:       s.Location(),
-				ResourceGroup:  s.ResourceGroup(),
-				ClusterName:    s.ClusterName(),
-				AdditionalTags: s.AdditionalTags(),
+				Name:              subnet.RouteTable.Name,
+				Location:          s.Location(),
+				ResourceGroup:     s.ResourceGroup(),
+				ClusterName:       s.ClusterName(),
+				AdditionalTags:    s.AdditionalTags(),
+				Routes:            subnet.RouteTable.Routes,
+				LastAppliedRoutes: s.getLastAppliedRoutes(subnet.RouteTable.Name),
 			})
 		}
 	}
@@ -324,6 +327,22 @@ func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	return specs
 }
 
+// ApplicationSecurityGroupSpecs returns the application security groups specs.
+func (s *ClusterScope) ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter {
+	var specs []azure.ResourceSpecGetter
+	for _, asg := range s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups {
+		specs = append(specs, &applicationsecuritygroups.ASGSpec{
+			Name:           asg.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+		})
+	}
+
+	return specs
+}
+
 // NatGatewaySpecs returns the node NAT gateway.
 func (s *ClusterScope) NatGatewaySpecs() []azure.ResourceSpecGetter {
 	natGatewaySet := make(map[string]struct{})
@@ -363,13 +382,44 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 			Location:                 s.Location(),
 			ClusterName:              s.ClusterName(),
 			AdditionalTags:           s.AdditionalTags(),
-			LastAppliedSecurityRules: s.getLastAppliedSecurityRules(subnet.SecurityGroup.Name),
+			LastAppliedSecurityRules: lastAppliedSecurityRules(s.AzureCluster, subnet.SecurityGroup.Name),
+			SubscriptionID:           s.SubscriptionID(),
 		}
 	}
 
 	return nsgspecs
 }
 
+// FlowLogSpecs returns the flow log specs of the subnet security groups that have flow logs enabled.
+func (s *ClusterScope) FlowLogSpecs() []azure.ResourceSpecGetter {
+	var specs []azure.ResourceSpecGetter
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		flowLogs := subnet.SecurityGroup.FlowLogs
+		if flowLogs == nil || subnet.SecurityGroup.Name == "" {
+			continue
+		}
+		networkWatcherName := flowLogs.NetworkWatcherName
+		networkWatcherResourceGroup := flowLogs.NetworkWatcherResourceGroup
+		if networkWatcherName == "" {
+			networkWatcherName = azure.GenerateNetworkWatcherName(s.Location())
+			networkWatcherResourceGroup = azure.DefaultNetworkWatcherResourceGroup
+		}
+		specs = append(specs, &flowlogs.FlowLogSpec{
+			Name:                        azure.GenerateFlowLogName(subnet.SecurityGroup.Name),
+			NetworkWatcherName:          networkWatcherName,
+			NetworkWatcherResourceGroup: networkWatcherResourceGroup,
+			Location:                    s.Location(),
+			SecurityGroupID:             azure.SecurityGroupID(s.SubscriptionID(), s.ResourceGroup(), subnet.SecurityGroup.Name),
+			StorageAccountID:            flowLogs.StorageAccountID,
+			RetentionDays:               flowLogs.RetentionDays,
+			TrafficAnalytics:            flowLogs.TrafficAnalytics,
+			ClusterName:                 s.ClusterName(),
+			AdditionalTags:              s.AdditionalTags(),
+		})
+	}
+	return specs
+}
+
 // SubnetSpecs returns the subnets specs.
 func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
@@ -381,18 +431,20 @@ func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 
 	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		subnetSpec := &subnets.SubnetSpec{
-			Name:              subnet.Name,
-			ResourceGroup:     s.ResourceGroup(),
-			SubscriptionID:    s.SubscriptionID(),
-			CIDRs:             subnet.CIDRBlocks,
-			VNetName:          s.Vnet().Name,
-			VNetResourceGroup: s.Vnet().ResourceGroup,
-			IsVNetManaged:     s.IsVnetManaged(),
-			RouteTableName:    subnet.RouteTable.Name,
-			SecurityGroupName: subnet.SecurityGroup.Name,
-			Role:              subnet.Role,
-			NatGatewayName:    subnet.NatGateway.Name,
-			ServiceEndpoints:  subnet.ServiceEndpoints,
+			Name:                    subnet.Name,
+			ResourceGroup:           s.ResourceGroup(),
+			SubscriptionID:          s.SubscriptionID(),
+			CIDRs:                   subnet.CIDRBlocks,
+			VNetName:                s.Vnet().Name,
+			VNetResourceGroup:       s.Vnet().ResourceGroup,
+			IsVNetManaged:           s.IsVnetManaged(),
+			RouteTableName:          subnet.RouteTable.Name,
+			SecurityGroupName:       subnet.SecurityGroup.Name,
+			Role:                    subnet.Role,
+			NatGatewayName:          subnet.NatGateway.Name,
+			ServiceEndpoints:        subnet.ServiceEndpoints,
+			Delegations:             subnet.Delegations,
+			ServiceEndpointPolicies: subnet.ServiceEndpointPolicies,
 		}
 		subnetSpecs = append(subnetSpecs, subnetSpec)
 	}
@@ -573,6 +625,12 @@ func (s *ClusterScope) IsVnetManaged() bool {
 	return isVnetManaged
 }
 
+// IsNSGManaged returns true if the cluster network security groups are managed.
+// They are only managed along with the vnet they belong to.
+func (s *ClusterScope) IsNSGManaged() bool {
+	return s.IsVnetManaged()
+}
+
 // IsIPv6Enabled returns true if IPv6 is enabled.
 func (s *ClusterScope) IsIPv6Enabled() bool {
 	for _, cidr := range s.AzureCluster.Spec.NetworkSpec.Vnet.CIDRBlocks {
@@ -764,6 +822,12 @@ func (s *ClusterScope) CloudProviderConfigOverrides() *infrav1.CloudProviderConf
 	return s.AzureCluster.Spec.CloudProviderConfigOverrides
 }
 
+// AzureMonitorAgentEnabled returns true if the Azure Monitor Agent is to be installed on the machines of the cluster.
+func (s *ClusterScope) AzureMonitorAgentEnabled() bool {
+	monitoring := s.AzureCluster.Spec.Monitoring
+	return monitoring != nil && monitoring.AzureMonitorAgent != nil && monitoring.AzureMonitorAgent.Enabled
+}
+
 // ExtendedLocationName returns ExtendedLocation name for the cluster.
 func (s *ClusterScope) ExtendedLocationName() string {
 	if s.ExtendedLocation() == nil {
@@ -828,6 +892,8 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			clusterv1.ReadyCondition,
 			infrav1.ResourceGroupReadyCondition,
 			infrav1.RouteTablesReadyCondition,
+			infrav1.ApplicationSecurityGroupsReadyCondition,
+			infrav1.FlowLogsReadyCondition,
 			infrav1.NetworkInfrastructureReadyCondition,
 			infrav1.VnetPeeringReadyCondition,
 			infrav1.DisksReadyCondition,
@@ -1021,16 +1087,7 @@ func (s *ClusterScope) UpdatePatchStatus(condition clusterv1.ConditionType, serv
 
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (s *ClusterScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
-	out := map[string]interface{}{}
-	jsonAnnotation := s.AzureCluster.GetAnnotations()[annotation]
-	if jsonAnnotation == "" {
-		return out, nil
-	}
-	err := json.Unmarshal([]byte(jsonAnnotation), &out)
-	if err != nil {
-		return out, err
-	}
-	return out, nil
+	return annotationJSON(s.AzureCluster, annotation)
 }
 
 // UpdateAnnotationJSON updates the `annotation` with
@@ -1038,20 +1095,12 @@ func (s *ClusterScope) AnnotationJSON(annotation string) (map[string]interface{}
 // suitable for turning into JSON. This `content` map will be marshalled into a
 // JSON string before being set as the given `annotation`.
 func (s *ClusterScope) UpdateAnnotationJSON(annotation string, content map[string]interface{}) error {
-	b, err := json.Marshal(content)
-	if err != nil {
-		return err
-	}
-	s.SetAnnotation(annotation, string(b))
-	return nil
+	return updateAnnotationJSON(s.AzureCluster, annotation, content)
 }
 
 // SetAnnotation sets a key value annotation on the AzureCluster.
 func (s *ClusterScope) SetAnnotation(key, value string) {
-	if s.AzureCluster.Annotations == nil {
-		s.AzureCluster.Annotations = map[string]string{}
-	}
-	s.AzureCluster.Annotations[key] = value
+	setAnnotation(s.AzureCluster, key, value)
 }
 
 // PrivateEndpointSpecs returns the private endpoint specs.
@@ -1108,17 +1157,16 @@ func (s *ClusterScope) getPrivateEndpoints(subnet infrav1.SubnetSpec) []azure.Re
 	return privateEndpointSpecs
 }
 
-func (s *ClusterScope) getLastAppliedSecurityRules(nsgName string) map[string]interface{} {
-	// Retrieve the last applied security rules for all NSGs.
-	lastAppliedSecurityRulesAll, err := s.AnnotationJSON(azure.SecurityRuleLastAppliedAnnotation)
+// getLastAppliedRoutes returns the routes last applied by CAPZ to the route table with the given name.
+func (s *ClusterScope) getLastAppliedRoutes(routeTableName string) map[string]interface{} {
+	lastAppliedRoutesAll, err := s.AnnotationJSON(azure.RoutesLastAppliedAnnotation)
 	if err != nil {
 		return map[string]interface{}{}
 	}
 
-	// Retrieve the last applied security rules for this NSG.
-	lastAppliedSecurityRules, ok := lastAppliedSecurityRulesAll[nsgName].(map[string]interface{})
+	lastAppliedRoutes, ok := lastAppliedRoutesAll[routeTableName].(map[string]interface{})
 	if !ok {
-		lastAppliedSecurityRules = map[string]interface{}{}
+		lastAppliedRoutes = map[string]interface{}{}
 	}
-	return lastAppliedSecurityRules
+	return lastAppliedRoutes
 }
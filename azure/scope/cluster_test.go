@@ -32,7 +32,9 @@ import (
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
@@ -808,6 +810,11 @@ func TestRouteTableSpecs(t *testing.T) {
 					},
 				},
 				AzureCluster: &infrav1.AzureCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							azure.RoutesLastAppliedAnnotation: `{"fake-route-table-1":{"to-firewall":"0.0.0.0/0","to-on-prem":"192.168.0.0/16"}}`,
+						},
+					},
 					Spec: infrav1.AzureClusterSpec{
 						ResourceGroup: "my-rg",
 						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
@@ -819,6 +826,14 @@ func TestRouteTableSpecs(t *testing.T) {
 									RouteTable: infrav1.RouteTable{
 										ID:   "fake-route-table-id-1",
 										Name: "fake-route-table-1",
+										Routes: infrav1.Routes{
+											{
+												Name:             "to-firewall",
+												AddressPrefix:    "0.0.0.0/0",
+												NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+												NextHopIPAddress: "10.0.0.4",
+											},
+										},
 									},
 								},
 								{
@@ -840,9 +855,92 @@ func TestRouteTableSpecs(t *testing.T) {
 					Location:       "centralIndia",
 					ClusterName:    "my-cluster",
 					AdditionalTags: make(infrav1.Tags),
+					Routes: infrav1.Routes{
+						{
+							Name:             "to-firewall",
+							AddressPrefix:    "0.0.0.0/0",
+							NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+							NextHopIPAddress: "10.0.0.4",
+						},
+					},
+					LastAppliedRoutes: map[string]interface{}{
+						"to-firewall": "0.0.0.0/0",
+						"to-on-prem":  "192.168.0.0/16",
+					},
 				},
 				&routetables.RouteTableSpec{
-					Name:           "fake-route-table-2",
+					Name:              "fake-route-table-2",
+					ResourceGroup:     "my-rg",
+					Location:          "centralIndia",
+					ClusterName:       "my-cluster",
+					AdditionalTags:    make(infrav1.Tags),
+					LastAppliedRoutes: map[string]interface{}{},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.RouteTableSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RouteTableSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestApplicationSecurityGroupSpecs(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no application security groups are specified",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{},
+				cache:        &ClusterCache{},
+			},
+			want: nil,
+		},
+		{
+			name: "returns specified application security groups if present",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								ApplicationSecurityGroups: infrav1.ApplicationSecurityGroups{
+									{Name: "web"},
+									{Name: "db"},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&applicationsecuritygroups.ASGSpec{
+					Name:           "web",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&applicationsecuritygroups.ASGSpec{
+					Name:           "db",
 					ResourceGroup:  "my-rg",
 					Location:       "centralIndia",
 					ClusterName:    "my-cluster",
@@ -856,8 +954,122 @@ func TestRouteTableSpecs(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := tt.clusterScope.RouteTableSpecs(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("RouteTableSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			if got := tt.clusterScope.ApplicationSecurityGroupSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplicationSecurityGroupSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestFlowLogSpecs(t *testing.T) {
+	storageAccountID := "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs"
+
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no security group has flow logs",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{SecurityGroup: infrav1.SecurityGroup{Name: "node-nsg"}},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: nil,
+		},
+		{
+			name: "returns flow logs in the default and custom Network Watchers",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							SubscriptionID: "123",
+							Location:       "westus2",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "control-plane-nsg",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											FlowLogs: &infrav1.FlowLogs{
+												StorageAccountID: storageAccountID,
+												RetentionDays:    7,
+											},
+										},
+									},
+								},
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "node-nsg",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											FlowLogs: &infrav1.FlowLogs{
+												StorageAccountID:            storageAccountID,
+												NetworkWatcherName:          "my-watcher",
+												NetworkWatcherResourceGroup: "my-watcher-rg",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&flowlogs.FlowLogSpec{
+					Name:                        "control-plane-nsg-flowlog",
+					NetworkWatcherName:          "NetworkWatcher_westus2",
+					NetworkWatcherResourceGroup: "NetworkWatcherRG",
+					Location:                    "westus2",
+					SecurityGroupID:             "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/control-plane-nsg",
+					StorageAccountID:            storageAccountID,
+					RetentionDays:               7,
+					ClusterName:                 "my-cluster",
+					AdditionalTags:              make(infrav1.Tags),
+				},
+				&flowlogs.FlowLogSpec{
+					Name:                        "node-nsg-flowlog",
+					NetworkWatcherName:          "my-watcher",
+					NetworkWatcherResourceGroup: "my-watcher-rg",
+					Location:                    "westus2",
+					SecurityGroupID:             "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/node-nsg",
+					StorageAccountID:            storageAccountID,
+					ClusterName:                 "my-cluster",
+					AdditionalTags:              make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.FlowLogSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FlowLogSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
 			}
 		})
 	}
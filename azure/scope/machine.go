@@ -19,7 +19,6 @@ package scope
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
@@ -36,6 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
@@ -256,30 +256,69 @@ func (m *MachineScope) NICSpecs() []azure.ResourceSpecGetter {
 	return nicSpecs
 }
 
+// NSGSpecs returns the specs of the network security groups CAPZ creates for the network interfaces of the machine.
+// Security groups referenced by ID are not managed and have no spec.
+func (m *MachineScope) NSGSpecs() []azure.ResourceSpecGetter {
+	nsgSpecs := []azure.ResourceSpecGetter{}
+
+	isMultiNIC := len(m.AzureMachine.Spec.NetworkInterfaces) > 1
+
+	for i, nic := range m.AzureMachine.Spec.NetworkInterfaces {
+		if nic.SecurityGroup == nil || nic.SecurityGroup.ID != "" {
+			continue
+		}
+		nsgName := azure.GenerateNICSecurityGroupName(azure.GenerateNICName(m.Name(), isMultiNIC, i))
+		nsgSpecs = append(nsgSpecs, &securitygroups.NSGSpec{
+			Name:                     nsgName,
+			SecurityRules:            nic.SecurityGroup.SecurityRules,
+			ResourceGroup:            m.ResourceGroup(),
+			Location:                 m.Location(),
+			ClusterName:              m.ClusterName(),
+			AdditionalTags:           m.AdditionalTags(),
+			LastAppliedSecurityRules: lastAppliedSecurityRules(m.AzureMachine, nsgName),
+			SubscriptionID:           m.SubscriptionID(),
+		})
+	}
+	return nsgSpecs
+}
+
+// IsNSGManaged returns true as the network security groups of a machine are always managed.
+func (m *MachineScope) IsNSGManaged() bool {
+	return true
+}
+
 // BuildNICSpec takes a NetworkInterface from the AzureMachineSpec and returns a NICSpec for use by the networkinterfaces service.
 func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infrav1.NetworkInterface, primaryNetworkInterface bool) *networkinterfaces.NICSpec {
 	spec := &networkinterfaces.NICSpec{
-		Name:                  nicName,
-		ResourceGroup:         m.ResourceGroup(),
-		Location:              m.Location(),
-		ExtendedLocation:      m.ExtendedLocation(),
-		SubscriptionID:        m.SubscriptionID(),
-		MachineName:           m.Name(),
-		VNetName:              m.Vnet().Name,
-		VNetResourceGroup:     m.Vnet().ResourceGroup,
-		AcceleratedNetworking: infrav1NetworkInterface.AcceleratedNetworking,
-		IPv6Enabled:           m.IsIPv6Enabled(),
-		EnableIPForwarding:    m.AzureMachine.Spec.EnableIPForwarding,
-		SubnetName:            infrav1NetworkInterface.SubnetName,
-		AdditionalTags:        m.AdditionalTags(),
-		ClusterName:           m.ClusterName(),
-		IPConfigs:             []networkinterfaces.IPConfig{},
+		Name:                      nicName,
+		ResourceGroup:             m.ResourceGroup(),
+		Location:                  m.Location(),
+		ExtendedLocation:          m.ExtendedLocation(),
+		SubscriptionID:            m.SubscriptionID(),
+		MachineName:               m.Name(),
+		VNetName:                  m.Vnet().Name,
+		VNetResourceGroup:         m.Vnet().ResourceGroup,
+		AcceleratedNetworking:     infrav1NetworkInterface.AcceleratedNetworking,
+		IPv6Enabled:               m.IsIPv6Enabled(),
+		EnableIPForwarding:        m.AzureMachine.Spec.EnableIPForwarding,
+		SubnetName:                infrav1NetworkInterface.SubnetName,
+		AdditionalTags:            m.AdditionalTags(),
+		ClusterName:               m.ClusterName(),
+		IPConfigs:                 []networkinterfaces.IPConfig{},
+		ApplicationSecurityGroups: infrav1NetworkInterface.ApplicationSecurityGroups,
 	}
 
 	if m.cache != nil {
 		spec.SKU = &m.cache.VMSKU
 	}
 
+	if sg := infrav1NetworkInterface.SecurityGroup; sg != nil {
+		spec.SecurityGroupID = sg.ID
+		if spec.SecurityGroupID == "" {
+			spec.SecurityGroupID = azure.SecurityGroupID(m.SubscriptionID(), m.ResourceGroup(), azure.GenerateNICSecurityGroupName(nicName))
+		}
+	}
+
 	for i := 0; i < infrav1NetworkInterface.PrivateIPConfigs; i++ {
 		spec.IPConfigs = append(spec.IPConfigs, networkinterfaces.IPConfig{})
 	}
@@ -398,9 +437,36 @@ func (m *MachineScope) VMExtensionSpecs() []azure.ResourceSpecGetter {
 		})
 	}
 
+	if azureMonitorAgentEnabled(m.ClusterScoper) {
+		if monitorAgentExtensionSpec := azure.GetAzureMonitorAgentVMExtension(m.AzureMachine.Spec.OSDisk.OSType, m.Name()); monitorAgentExtensionSpec != nil && !hasExtension(extensionSpecs, monitorAgentExtensionSpec.Name) {
+			extensionSpecs = append(extensionSpecs, &vmextensions.VMExtensionSpec{
+				ExtensionSpec: *monitorAgentExtensionSpec,
+				ResourceGroup: m.ResourceGroup(),
+				Location:      m.Location(),
+			})
+		}
+	}
+
 	return extensionSpecs
 }
 
+// azureMonitorAgentEnabled returns true if the Azure Monitor Agent is to be installed on the machines of the cluster.
+// Only the scopes of clusters which support installing the agent on their machines implement azure.AzureMonitorAgentDescriber.
+func azureMonitorAgentEnabled(clusterScoper azure.ClusterScoper) bool {
+	describer, ok := clusterScoper.(azure.AzureMonitorAgentDescriber)
+	return ok && describer.AzureMonitorAgentEnabled()
+}
+
+// hasExtension returns true if an extension with the given name is part of the extension specs.
+func hasExtension(extensionSpecs []azure.ResourceSpecGetter, name string) bool {
+	for _, spec := range extensionSpecs {
+		if spec.ResourceName() == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Subnet returns the machine's subnet.
 func (m *MachineScope) Subnet() infrav1.SubnetSpec {
 	for _, subnet := range m.Subnets() {
@@ -598,24 +664,12 @@ func (m *MachineScope) SetConditionFalse(conditionType clusterv1.ConditionType,
 
 // SetAnnotation sets a key value annotation on the AzureMachine.
 func (m *MachineScope) SetAnnotation(key, value string) {
-	if m.AzureMachine.Annotations == nil {
-		m.AzureMachine.Annotations = map[string]string{}
-	}
-	m.AzureMachine.Annotations[key] = value
+	setAnnotation(m.AzureMachine, key, value)
 }
 
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (m *MachineScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
-	out := map[string]interface{}{}
-	jsonAnnotation := m.AzureMachine.GetAnnotations()[annotation]
-	if jsonAnnotation == "" {
-		return out, nil
-	}
-	err := json.Unmarshal([]byte(jsonAnnotation), &out)
-	if err != nil {
-		return out, err
-	}
-	return out, nil
+	return annotationJSON(m.AzureMachine, annotation)
 }
 
 // UpdateAnnotationJSON updates the `annotation` with
@@ -623,12 +677,7 @@ func (m *MachineScope) AnnotationJSON(annotation string) (map[string]interface{}
 // suitable for turning into JSON. This `content` map will be marshalled into a
 // JSON string before being set as the given `annotation`.
 func (m *MachineScope) UpdateAnnotationJSON(annotation string, content map[string]interface{}) error {
-	b, err := json.Marshal(content)
-	if err != nil {
-		return err
-	}
-	m.SetAnnotation(annotation, string(b))
-	return nil
+	return updateAnnotationJSON(m.AzureMachine, annotation, content)
 }
 
 // SetAddresses sets the Azure address status.
@@ -648,6 +697,7 @@ func (m *MachineScope) PatchObject(ctx context.Context) error {
 			infrav1.VMRunningCondition,
 			infrav1.AvailabilitySetReadyCondition,
 			infrav1.NetworkInterfaceReadyCondition,
+			infrav1.SecurityGroupsReadyCondition,
 		}})
 }
 
@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
@@ -419,6 +420,121 @@ func TestMachineScope_InboundNatSpecs(t *testing.T) {
 	}
 }
 
+func TestMachineScope_NSGSpecs(t *testing.T) {
+	sshRule := infrav1.SecurityRule{
+		Name:             "allow_ssh",
+		Description:      "Allow SSH",
+		Protocol:         infrav1.SecurityGroupProtocolTCP,
+		Direction:        infrav1.SecurityRuleDirectionInbound,
+		Priority:         2200,
+		SourcePorts:      ptr.To("*"),
+		DestinationPorts: ptr.To("22"),
+		Source:           ptr.To("*"),
+		Destination:      ptr.To("*"),
+	}
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					SubscriptionID: "123",
+					Location:       "westus",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		machineScope MachineScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns empty when no network interface has a security group",
+			machineScope: MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						NetworkInterfaces: []infrav1.NetworkInterface{{SubnetName: "subnet1", PrivateIPConfigs: 1}},
+					},
+				},
+				ClusterScoper: clusterScope,
+			},
+			want: []azure.ResourceSpecGetter{},
+		},
+		{
+			name: "returns a spec only for security groups created by CAPZ",
+			machineScope: MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+						Annotations: map[string]string{
+							azure.SecurityRuleLastAppliedAnnotation: `{"machine-name-nic-0-nsg":{"allow_ssh":"Allow SSH"}}`,
+						},
+					},
+					Spec: infrav1.AzureMachineSpec{
+						NetworkInterfaces: []infrav1.NetworkInterface{
+							{
+								SubnetName:       "subnet1",
+								PrivateIPConfigs: 1,
+								SecurityGroup: &infrav1.NetworkInterfaceSecurityGroup{
+									SecurityRules: infrav1.SecurityRules{sshRule},
+								},
+							},
+							{
+								SubnetName:       "subnet2",
+								PrivateIPConfigs: 1,
+								SecurityGroup: &infrav1.NetworkInterfaceSecurityGroup{
+									ID: "/subscriptions/123/resourceGroups/other-rg/providers/Microsoft.Network/networkSecurityGroups/other-nsg",
+								},
+							},
+						},
+					},
+				},
+				ClusterScoper: clusterScope,
+			},
+			want: []azure.ResourceSpecGetter{
+				&securitygroups.NSGSpec{
+					Name:          "machine-name-nic-0-nsg",
+					SecurityRules: infrav1.SecurityRules{sshRule},
+					ResourceGroup: "my-rg",
+					Location:      "westus",
+					ClusterName:   "my-cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_my-cluster": "owned",
+					},
+					LastAppliedSecurityRules: map[string]interface{}{
+						"allow_ssh": "Allow SSH",
+					},
+					SubscriptionID: "123",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			g.Expect(tt.machineScope.NSGSpecs()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestMachineScope_RoleAssignmentSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -594,6 +710,115 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "If the Azure Monitor Agent is enabled, it returns its ExtensionSpec",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							OSType: "Linux",
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.USGovernmentCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+								Monitoring: &infrav1.AzureClusterMonitoring{
+									AzureMonitorAgent: &infrav1.AzureMonitorAgent{Enabled: true},
+								},
+							},
+						},
+					},
+				},
+				cache: &MachineCache{
+					VMSKU: resourceskus.SKU{},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vmextensions.VMExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "AzureMonitorLinuxAgent",
+						VMName:    "machine-name",
+						Publisher: "Microsoft.Azure.Monitor",
+						Version:   "1.27",
+					},
+					ResourceGroup: "my-rg",
+					Location:      "westus",
+				},
+			},
+		},
+		{
+			name: "If the Azure Monitor Agent is enabled and set in the VM extensions, it returns the VM extension",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							OSType: "Windows",
+						},
+						VMExtensions: []infrav1.VMExtension{
+							{
+								Name:      "AzureMonitorWindowsAgent",
+								Publisher: "Microsoft.Azure.Monitor",
+								Version:   "1.20",
+							},
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.USGovernmentCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+								Monitoring: &infrav1.AzureClusterMonitoring{
+									AzureMonitorAgent: &infrav1.AzureMonitorAgent{Enabled: true},
+								},
+							},
+						},
+					},
+				},
+				cache: &MachineCache{
+					VMSKU: resourceskus.SKU{},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vmextensions.VMExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "AzureMonitorWindowsAgent",
+						VMName:    "machine-name",
+						Publisher: "Microsoft.Azure.Monitor",
+						Version:   "1.20",
+					},
+					ResourceGroup: "my-rg",
+					Location:      "westus",
+				},
+			},
+		},
 		{
 			name: "If OS type is Linux and cloud is not AzurePublicCloud, it returns empty",
 			machineScope: MachineScope{
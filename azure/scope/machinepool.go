@@ -22,6 +22,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -35,6 +36,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
@@ -221,6 +223,34 @@ func (m *MachinePoolScope) ScaleSetSpec(ctx context.Context) azure.ResourceSpecG
 	}
 }
 
+// NSGSpecs returns the specs of the network security groups CAPZ creates for the network interfaces of the scale set.
+// Security groups referenced by ID are not managed and have no spec.
+func (m *MachinePoolScope) NSGSpecs() []azure.ResourceSpecGetter {
+	nsgSpecs := []azure.ResourceSpecGetter{}
+	for i, nic := range m.AzureMachinePool.Spec.Template.NetworkInterfaces {
+		if nic.SecurityGroup == nil || nic.SecurityGroup.ID != "" {
+			continue
+		}
+		nsgName := azure.GenerateNICSecurityGroupName(m.Name() + "-nic-" + strconv.Itoa(i))
+		nsgSpecs = append(nsgSpecs, &securitygroups.NSGSpec{
+			Name:                     nsgName,
+			SecurityRules:            nic.SecurityGroup.SecurityRules,
+			ResourceGroup:            m.ResourceGroup(),
+			Location:                 m.Location(),
+			ClusterName:              m.ClusterName(),
+			AdditionalTags:           m.AdditionalTags(),
+			LastAppliedSecurityRules: lastAppliedSecurityRules(m.AzureMachinePool, nsgName),
+			SubscriptionID:           m.SubscriptionID(),
+		})
+	}
+	return nsgSpecs
+}
+
+// IsNSGManaged returns true as the network security groups of a machine pool are always managed.
+func (m *MachinePoolScope) IsNSGManaged() bool {
+	return true
+}
+
 // Name returns the Azure Machine Pool Name.
 func (m *MachinePoolScope) Name() string {
 	// Windows Machine pools names cannot be longer than 9 chars
@@ -581,10 +611,20 @@ func (m *MachinePoolScope) AdditionalTags() infrav1.Tags {
 
 // SetAnnotation sets a key value annotation on the AzureMachinePool.
 func (m *MachinePoolScope) SetAnnotation(key, value string) {
-	if m.AzureMachinePool.Annotations == nil {
-		m.AzureMachinePool.Annotations = map[string]string{}
-	}
-	m.AzureMachinePool.Annotations[key] = value
+	setAnnotation(m.AzureMachinePool, key, value)
+}
+
+// AnnotationJSON returns a map[string]interface from a JSON annotation.
+func (m *MachinePoolScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
+	return annotationJSON(m.AzureMachinePool, annotation)
+}
+
+// UpdateAnnotationJSON updates the `annotation` with
+// `content`. `content` in this case should be a `map[string]interface{}`
+// suitable for turning into JSON. This `content` map will be marshalled into a
+// JSON string before being set as the given `annotation`.
+func (m *MachinePoolScope) UpdateAnnotationJSON(annotation string, content map[string]interface{}) error {
+	return updateAnnotationJSON(m.AzureMachinePool, annotation, content)
 }
 
 // PatchObject persists the AzureMachinePool spec and status.
@@ -602,6 +642,7 @@ func (m *MachinePoolScope) PatchObject(ctx context.Context) error {
 			infrav1.ScaleSetDesiredReplicasCondition,
 			infrav1.ScaleSetModelUpdatedCondition,
 			infrav1.ScaleSetRunningCondition,
+			infrav1.SecurityGroupsReadyCondition,
 		}})
 }
 
@@ -787,6 +828,15 @@ func (m *MachinePoolScope) VMSSExtensionSpecs() []azure.ResourceSpecGetter {
 		})
 	}
 
+	if azureMonitorAgentEnabled(m.ClusterScoper) {
+		if monitorAgentExtensionSpec := azure.GetAzureMonitorAgentVMExtension(m.AzureMachinePool.Spec.Template.OSDisk.OSType, m.Name()); monitorAgentExtensionSpec != nil && !hasExtension(extensionSpecs, monitorAgentExtensionSpec.Name) {
+			extensionSpecs = append(extensionSpecs, &scalesets.VMSSExtensionSpec{
+				ExtensionSpec: *monitorAgentExtensionSpec,
+				ResourceGroup: m.ResourceGroup(),
+			})
+		}
+	}
+
 	return extensionSpecs
 }
 
@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -853,6 +854,93 @@ func TestMachinePoolScope_RoleAssignmentSpecs(t *testing.T) {
 	}
 }
 
+func TestMachinePoolScope_NSGSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	sshRule := infrav1.SecurityRule{
+		Name:             "allow_ssh",
+		Description:      "Allow SSH",
+		Protocol:         infrav1.SecurityGroupProtocolTCP,
+		Direction:        infrav1.SecurityRuleDirectionInbound,
+		Priority:         2200,
+		SourcePorts:      ptr.To("*"),
+		DestinationPorts: ptr.To("22"),
+		Source:           ptr.To("*"),
+		Destination:      ptr.To("*"),
+	}
+	mps := MachinePoolScope{
+		AzureMachinePool: &infrav1exp.AzureMachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pool1",
+				Annotations: map[string]string{
+					azure.SecurityRuleLastAppliedAnnotation: `{"pool1-nic-1-nsg":{"allow_ssh":"Allow SSH"}}`,
+				},
+			},
+			Spec: infrav1exp.AzureMachinePoolSpec{
+				Template: infrav1exp.AzureMachinePoolMachineTemplate{
+					NetworkInterfaces: []infrav1.NetworkInterface{
+						{
+							SubnetName: "subnet1",
+							SecurityGroup: &infrav1.NetworkInterfaceSecurityGroup{
+								ID: "/subscriptions/123/resourceGroups/other-rg/providers/Microsoft.Network/networkSecurityGroups/other-nsg",
+							},
+						},
+						{
+							SubnetName: "subnet2",
+							SecurityGroup: &infrav1.NetworkInterfaceSecurityGroup{
+								SecurityRules: infrav1.SecurityRules{sshRule},
+							},
+						},
+						{
+							SubnetName: "subnet3",
+						},
+					},
+				},
+			},
+		},
+		ClusterScoper: &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+			},
+			AzureClients: AzureClients{
+				EnvironmentSettings: auth.EnvironmentSettings{
+					Values: map[string]string{
+						auth.SubscriptionID: "123",
+					},
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "westus",
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(mps.NSGSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&securitygroups.NSGSpec{
+			Name:          "pool1-nic-1-nsg",
+			SecurityRules: infrav1.SecurityRules{sshRule},
+			ResourceGroup: "my-rg",
+			Location:      "westus",
+			ClusterName:   "my-cluster",
+			AdditionalTags: infrav1.Tags{
+				"kubernetes.io_cluster_my-cluster": "owned",
+			},
+			LastAppliedSecurityRules: map[string]interface{}{
+				"allow_ssh": "Allow SSH",
+			},
+			SubscriptionID: "123",
+		},
+	}))
+}
+
 func TestMachinePoolScope_VMSSExtensionSpecs(t *testing.T) {
 	tests := []struct {
 		name             string
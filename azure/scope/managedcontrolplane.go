@@ -18,7 +18,7 @@ package scope
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -26,11 +26,14 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/mod/semver"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/aksextensions"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
@@ -39,6 +42,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/maps"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/secret"
@@ -47,6 +51,9 @@ import (
 
 const resourceHealthWarningInitialGracePeriod = 1 * time.Hour
 
+// userKubeconfigSecretPurpose is the secret name suffix storing the user kubeconfig of AAD integrated clusters.
+const userKubeconfigSecretPurpose = secret.Purpose("user-kubeconfig")
+
 // ManagedControlPlaneScopeParams defines the input parameters used to create a new managed
 // control plane.
 type ManagedControlPlaneScopeParams struct {
@@ -116,10 +123,13 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 
 // ManagedControlPlaneScope defines the basic context for an actuator to operate upon.
 type ManagedControlPlaneScope struct {
-	Client         client.Client
-	patchHelper    *patch.Helper
-	kubeConfigData []byte
-	cache          *ManagedControlPlaneCache
+	Client             client.Client
+	patchHelper        *patch.Helper
+	kubeConfigData     []byte
+	userKubeConfigData []byte
+	// kubeConfigTokenExpiry is the expiry of the AAD token the kubeconfig authenticates with, if any.
+	kubeConfigTokenExpiry time.Time
+	cache                 *ManagedControlPlaneCache
 
 	AzureClients
 	Cluster             *clusterv1.Cluster
@@ -216,7 +226,7 @@ func (s *ManagedControlPlaneScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.ManagedControlPlaneScope.PatchObject")
 	defer done()
 
-	conditions.SetSummary(s.ControlPlane)
+	conditions.SetSummary(s.ControlPlane, conditions.WithConditions(readyConditions(s.ControlPlane)...))
 
 	return s.patchHelper.Patch(
 		ctx,
@@ -292,7 +302,7 @@ func (s *ManagedControlPlaneScope) NodeNatGateway() infrav1.NatGateway {
 
 // SubnetSpecs returns the subnets specs.
 func (s *ManagedControlPlaneScope) SubnetSpecs() []azure.ResourceSpecGetter {
-	return []azure.ResourceSpecGetter{
+	subnetSpecs := []azure.ResourceSpecGetter{
 		&subnets.SubnetSpec{
 			Name:              s.NodeSubnet().Name,
 			ResourceGroup:     s.ResourceGroup(),
@@ -305,6 +315,18 @@ func (s *ManagedControlPlaneScope) SubnetSpecs() []azure.ResourceSpecGetter {
 			ServiceEndpoints:  s.NodeSubnet().ServiceEndpoints,
 		},
 	}
+	for _, podSubnet := range s.ControlPlane.Spec.VirtualNetwork.PodSubnets {
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              podSubnet.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             []string{podSubnet.CIDRBlock},
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+		})
+	}
+	return subnetSpecs
 }
 
 // Subnets returns the subnets specs.
@@ -485,6 +507,12 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec() azure.ResourceSpecGetter
 		Identity:                    s.ControlPlane.Spec.Identity,
 		KubeletUserAssignedIdentity: s.ControlPlane.Spec.KubeletUserAssignedIdentity,
 		NetworkPluginMode:           s.ControlPlane.Spec.NetworkPluginMode,
+		NetworkDataplane:            s.ControlPlane.Spec.NetworkDataplane,
+		IPFamilies:                  s.ControlPlane.Spec.IPFamilies,
+		SecurityProfile:             s.ControlPlane.Spec.SecurityProfile,
+		DisableLocalAccounts:        s.ControlPlane.Spec.DisableLocalAccounts,
+		Monitoring:                  s.ControlPlane.Spec.Monitoring,
+		ReportDriftOnly:             isReportingDriftOnly(s.ControlPlane),
 	}
 
 	if s.ControlPlane.Spec.SSHPublicKey != nil {
@@ -500,7 +528,8 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec() azure.ResourceSpecGetter
 		managedClusterSpec.LoadBalancerSKU = *s.ControlPlane.Spec.LoadBalancerSKU
 	}
 
-	if clusterNetwork := s.Cluster.Spec.ClusterNetwork; clusterNetwork != nil {
+	clusterNetwork := s.Cluster.Spec.ClusterNetwork
+	if clusterNetwork != nil {
 		if clusterNetwork.Services != nil && len(clusterNetwork.Services.CIDRBlocks) == 1 {
 			managedClusterSpec.ServiceCIDR = clusterNetwork.Services.CIDRBlocks[0]
 		}
@@ -508,6 +537,23 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec() azure.ResourceSpecGetter
 			managedClusterSpec.PodCIDR = clusterNetwork.Pods.CIDRBlocks[0]
 		}
 	}
+	if s.ControlPlane.Spec.ServiceCIDR != nil {
+		managedClusterSpec.ServiceCIDR = *s.ControlPlane.Spec.ServiceCIDR
+	}
+	if s.ControlPlane.Spec.PodCIDR != nil {
+		managedClusterSpec.PodCIDR = *s.ControlPlane.Spec.PodCIDR
+	}
+	// Dual-stack clusters have one CIDR block per IP family.
+	if len(s.ControlPlane.Spec.IPFamilies) == 2 && clusterNetwork != nil {
+		if clusterNetwork.Services != nil && len(clusterNetwork.Services.CIDRBlocks) > 0 {
+			managedClusterSpec.ServiceCIDRs = cidrsPerIPFamily(clusterNetwork.Services.CIDRBlocks, managedClusterSpec.ServiceCIDR)
+			managedClusterSpec.ServiceCIDR = managedClusterSpec.ServiceCIDRs[0]
+		}
+		if clusterNetwork.Pods != nil && len(clusterNetwork.Pods.CIDRBlocks) > 0 {
+			managedClusterSpec.PodCIDRs = cidrsPerIPFamily(clusterNetwork.Pods.CIDRBlocks, managedClusterSpec.PodCIDR)
+			managedClusterSpec.PodCIDR = managedClusterSpec.PodCIDRs[0]
+		}
+	}
 
 	if s.ControlPlane.Spec.AADProfile != nil {
 		managedClusterSpec.AADProfile = &managedclusters.AADProfile{
@@ -589,6 +635,13 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec() azure.ResourceSpecGetter
 		}
 	}
 
+	if s.ControlPlane.Spec.AutoUpgradeProfile != nil {
+		managedClusterSpec.AutoUpgradeProfile = &managedclusters.AutoUpgradeProfile{
+			UpgradeChannel:       s.ControlPlane.Spec.AutoUpgradeProfile.UpgradeChannel,
+			NodeOSUpgradeChannel: s.ControlPlane.Spec.AutoUpgradeProfile.NodeOSUpgradeChannel,
+		}
+	}
+
 	return &managedClusterSpec
 }
 
@@ -602,7 +655,7 @@ func (s *ManagedControlPlaneScope) GetAllAgentPoolSpecs() ([]azure.ResourceSpecG
 		// TODO: this should be in a webhook: https://github.com/kubernetes-sigs/cluster-api/issues/6040
 		if pool.MachinePool != nil && pool.MachinePool.Spec.Template.Spec.Version != nil {
 			version := *pool.MachinePool.Spec.Template.Spec.Version
-			if semver.Compare(version, s.ControlPlane.Spec.Version) > 0 {
+			if semver.Compare(version, s.ControlPlaneVersion()) > 0 {
 				return nil, errors.New("MachinePool version cannot be greater than the AzureManagedControlPlane version")
 			}
 		}
@@ -652,6 +705,44 @@ func (s *ManagedControlPlaneScope) SetKubeConfigData(kubeConfigData []byte) {
 	s.kubeConfigData = kubeConfigData
 }
 
+// MakeEmptyUserKubeConfigSecret creates an empty secret object that is used for storing the user kubeconfig secret data.
+func (s *ManagedControlPlaneScope) MakeEmptyUserKubeConfigSecret() corev1.Secret {
+	kubeConfigSecret := s.MakeEmptyKubeConfigSecret()
+	kubeConfigSecret.Name = secret.Name(s.Cluster.Name, userKubeconfigSecretPurpose)
+	return kubeConfigSecret
+}
+
+// GetUserKubeConfigData returns a []byte that contains the user kubeconfig.
+func (s *ManagedControlPlaneScope) GetUserKubeConfigData() []byte {
+	return s.userKubeConfigData
+}
+
+// SetUserKubeConfigData sets the user kubeconfig data.
+func (s *ManagedControlPlaneScope) SetUserKubeConfigData(kubeConfigData []byte) {
+	s.userKubeConfigData = kubeConfigData
+}
+
+// KubeConfigTokenExpiry returns the expiry of the AAD token the kubeconfig authenticates with.
+// It is zero when the kubeconfig doesn't authenticate with an AAD token.
+func (s *ManagedControlPlaneScope) KubeConfigTokenExpiry() time.Time {
+	return s.kubeConfigTokenExpiry
+}
+
+// SetKubeConfigTokenExpiry sets the expiry of the AAD token the kubeconfig authenticates with.
+func (s *ManagedControlPlaneScope) SetKubeConfigTokenExpiry(expiry time.Time) {
+	s.kubeConfigTokenExpiry = expiry
+}
+
+// IsAADEnabled returns true if the managed cluster is integrated with AAD.
+func (s *ManagedControlPlaneScope) IsAADEnabled() bool {
+	return s.ControlPlane.Spec.AADProfile != nil && s.ControlPlane.Spec.AADProfile.Managed
+}
+
+// AreLocalAccountsDisabled returns true if the local accounts of the managed cluster are disabled.
+func (s *ManagedControlPlaneScope) AreLocalAccountsDisabled() bool {
+	return ptr.Deref(s.ControlPlane.Spec.DisableLocalAccounts, false)
+}
+
 // SetKubeletIdentity sets the ID of the user-assigned identity for kubelet if not already set.
 func (s *ManagedControlPlaneScope) SetKubeletIdentity(id string) {
 	s.ControlPlane.Spec.KubeletUserAssignedIdentity = id
@@ -697,6 +788,11 @@ func (s *ManagedControlPlaneScope) UpdatePutStatus(condition clusterv1.Condition
 	}
 }
 
+// DeleteCondition removes a condition from the AzureManagedControlPlane status.
+func (s *ManagedControlPlaneScope) DeleteCondition(condition clusterv1.ConditionType) {
+	conditions.Delete(s.ControlPlane, condition)
+}
+
 // UpdatePatchStatus updates a condition on the AzureManagedControlPlane status after a PATCH operation.
 func (s *ManagedControlPlaneScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
@@ -711,16 +807,7 @@ func (s *ManagedControlPlaneScope) UpdatePatchStatus(condition clusterv1.Conditi
 
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (s *ManagedControlPlaneScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
-	out := map[string]interface{}{}
-	jsonAnnotation := s.ControlPlane.GetAnnotations()[annotation]
-	if jsonAnnotation == "" {
-		return out, nil
-	}
-	err := json.Unmarshal([]byte(jsonAnnotation), &out)
-	if err != nil {
-		return out, err
-	}
-	return out, nil
+	return annotationJSON(s.ControlPlane, annotation)
 }
 
 // UpdateAnnotationJSON updates the `annotation` with
@@ -728,20 +815,12 @@ func (s *ManagedControlPlaneScope) AnnotationJSON(annotation string) (map[string
 // suitable for turning into JSON. This `content` map will be marshalled into a
 // JSON string before being set as the given `annotation`.
 func (s *ManagedControlPlaneScope) UpdateAnnotationJSON(annotation string, content map[string]interface{}) error {
-	b, err := json.Marshal(content)
-	if err != nil {
-		return err
-	}
-	s.SetAnnotation(annotation, string(b))
-	return nil
+	return updateAnnotationJSON(s.ControlPlane, annotation, content)
 }
 
 // SetAnnotation sets a key value annotation on the ControlPlane.
 func (s *ManagedControlPlaneScope) SetAnnotation(key, value string) {
-	if s.ControlPlane.Annotations == nil {
-		s.ControlPlane.Annotations = map[string]string{}
-	}
-	s.ControlPlane.Annotations[key] = value
+	setAnnotation(s.ControlPlane, key, value)
 }
 
 // TagsSpecs returns the tag specs for the ManagedControlPlane.
@@ -815,7 +894,255 @@ func (s *ManagedControlPlaneScope) PrivateEndpointSpecs() []azure.ResourceSpecGe
 	return privateEndpointSpecs
 }
 
+// MaintenanceConfigurationSpecs returns a maintenance configuration spec for each AKS maintenance configuration.
+// The configuration of a spec is nil when the maintenance configuration is not set on the AzureManagedControlPlane.
+func (s *ManagedControlPlaneScope) MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter {
+	names := []infrav1.MaintenanceConfigurationName{
+		infrav1.MaintenanceConfigurationNameDefault,
+		infrav1.MaintenanceConfigurationNameAutoUpgrade,
+		infrav1.MaintenanceConfigurationNameNodeOSUpgrade,
+	}
+	specs := make([]azure.ResourceSpecGetter, 0, len(names))
+	for _, name := range names {
+		spec := &maintenanceconfigurations.MaintenanceConfigurationSpec{
+			Name:          string(name),
+			ResourceGroup: s.ResourceGroup(),
+			Cluster:       s.ControlPlane.Name,
+		}
+		for i, config := range s.ControlPlane.Spec.MaintenanceConfigurations {
+			if config.Name == name {
+				spec.Configuration = &s.ControlPlane.Spec.MaintenanceConfigurations[i]
+				break
+			}
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// AKSExtensionSpecs returns an extension spec for each extension set on the AzureManagedControlPlane.
+func (s *ManagedControlPlaneScope) AKSExtensionSpecs() []azure.ResourceSpecGetter {
+	specs := make([]azure.ResourceSpecGetter, 0, len(s.ControlPlane.Spec.Extensions))
+	for i, extension := range s.ControlPlane.Spec.Extensions {
+		specs = append(specs, &aksextensions.AKSExtensionSpec{
+			Name:          extension.Name,
+			ResourceGroup: s.ResourceGroup(),
+			ClusterName:   s.ControlPlane.Name,
+			Extension:     &s.ControlPlane.Spec.Extensions[i],
+		})
+	}
+	return specs
+}
+
+// SetAKSExtensionsStatus sets the status of the extensions of the managed cluster.
+func (s *ManagedControlPlaneScope) SetAKSExtensionsStatus(statuses []infrav1.AKSExtensionStatus) {
+	s.ControlPlane.Status.Extensions = statuses
+}
+
 // SetOIDCIssuerProfileStatus sets the status for the OIDC issuer profile config.
 func (s *ManagedControlPlaneScope) SetOIDCIssuerProfileStatus(oidc *infrav1.OIDCIssuerProfileStatus) {
 	s.ControlPlane.Status.OIDCIssuerProfile = oidc
 }
+
+// SetAutoUpgradeVersionStatus records the version of a managed cluster which AKS upgraded past the version in the spec.
+func (s *ManagedControlPlaneScope) SetAutoUpgradeVersionStatus(version string) {
+	s.ControlPlane.Status.AutoUpgradeVersion = ""
+	if version == "" {
+		return
+	}
+	version = "v" + strings.TrimPrefix(version, "v")
+	if semver.Compare(version, s.ControlPlane.Spec.Version) > 0 {
+		s.ControlPlane.Status.AutoUpgradeVersion = version
+	}
+}
+
+// SetMonitoringStatus sets the Azure Monitor integration of the managed cluster as reported by AKS.
+func (s *ManagedControlPlaneScope) SetMonitoringStatus(status *infrav1.ManagedClusterMonitoringStatus) {
+	s.ControlPlane.Status.Monitoring = status
+}
+
+// DesiredPowerState returns the desired power state of the managed cluster.
+func (s *ManagedControlPlaneScope) DesiredPowerState() *infrav1.PowerState {
+	return s.ControlPlane.Spec.PowerState
+}
+
+// SetPowerStateStatus sets the power state of the managed cluster.
+func (s *ManagedControlPlaneScope) SetPowerStateStatus(powerState infrav1.PowerState) {
+	s.ControlPlane.Status.PowerState = powerState
+}
+
+// IsManagedClusterStopped returns true if the managed cluster is stopped or is to be stopped.
+func (s *ManagedControlPlaneScope) IsManagedClusterStopped() bool {
+	return s.ControlPlane.Status.PowerState == infrav1.PowerStateStopped ||
+		ptr.Deref(s.ControlPlane.Spec.PowerState, "") == infrav1.PowerStateStopped
+}
+
+// IsAdoptingManagedCluster returns true if the existing managed cluster is to be adopted and was not adopted yet.
+func (s *ManagedControlPlaneScope) IsAdoptingManagedCluster() bool {
+	return ptr.Deref(s.ControlPlane.Spec.AdoptionMode, infrav1.AdoptionModeNone) == infrav1.AdoptionModeAdopt &&
+		!conditions.IsTrue(s.ControlPlane, infrav1.AdoptedCondition)
+}
+
+// AdoptAgentPools creates a MachinePool and an AzureManagedMachinePool for each adopted agent pool which is not
+// managed by an AzureManagedMachinePool yet.
+func (s *ManagedControlPlaneScope) AdoptAgentPools(ctx context.Context, agentPools []azure.AdoptedAgentPool) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scope.ManagedControlPlaneScope.AdoptAgentPools")
+	defer done()
+
+	managed := make(map[string]bool, len(s.ManagedMachinePools))
+	for _, pool := range s.ManagedMachinePools {
+		managed[ptr.Deref(pool.InfraMachinePool.Spec.Name, pool.InfraMachinePool.Name)] = true
+	}
+
+	for _, agentPool := range agentPools {
+		agentPoolName := ptr.Deref(agentPool.Spec.Name, "")
+		if managed[agentPoolName] {
+			continue
+		}
+		name := fmt.Sprintf("%s-%s", s.ClusterName(), agentPoolName)
+		labels := map[string]string{clusterv1.ClusterNameLabel: s.ClusterName()}
+
+		machinePool := &expv1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: s.ControlPlane.Namespace,
+				Labels:    labels,
+			},
+			Spec: expv1.MachinePoolSpec{
+				ClusterName: s.ClusterName(),
+				Replicas:    ptr.To(agentPool.Replicas),
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						ClusterName: s.ClusterName(),
+						Bootstrap: clusterv1.Bootstrap{
+							DataSecretName: ptr.To(""),
+						},
+						InfrastructureRef: corev1.ObjectReference{
+							APIVersion: infrav1.GroupVersion.String(),
+							Kind:       "AzureManagedMachinePool",
+							Name:       name,
+						},
+						Version: agentPool.Version,
+					},
+				},
+			},
+		}
+		if err := s.Client.Create(ctx, machinePool); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return errors.Wrapf(err, "failed to create MachinePool %s for agent pool %s", name, agentPoolName)
+			}
+			if err := s.Client.Get(ctx, client.ObjectKeyFromObject(machinePool), machinePool); err != nil {
+				return errors.Wrapf(err, "failed to get MachinePool %s", name)
+			}
+		}
+
+		// The AzureManagedMachinePool is owned by its MachinePool right away, so that it is part of the managed
+		// cluster before the MachinePool controller picks it up.
+		infraMachinePool := &infrav1.AzureManagedMachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: s.ControlPlane.Namespace,
+				Labels:    labels,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: expv1.GroupVersion.String(),
+						Kind:       "MachinePool",
+						Name:       machinePool.Name,
+						UID:        machinePool.UID,
+					},
+				},
+			},
+			Spec: agentPool.Spec,
+		}
+		if err := s.Client.Create(ctx, infraMachinePool); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create AzureManagedMachinePool %s for agent pool %s", name, agentPoolName)
+		}
+		log.V(2).Info("adopted agent pool", "agentPool", agentPoolName, "machinePool", name)
+	}
+	return nil
+}
+
+// SetConditionTrue sets the specified AzureManagedControlPlane condition to true.
+func (s *ManagedControlPlaneScope) SetConditionTrue(conditionType clusterv1.ConditionType) {
+	conditions.MarkTrue(s.ControlPlane, conditionType)
+}
+
+// SetConditionFalse sets the specified AzureManagedControlPlane condition to false.
+func (s *ManagedControlPlaneScope) SetConditionFalse(conditionType clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.MarkFalse(s.ControlPlane, conditionType, reason, severity, message)
+}
+
+// SetDriftDetected sets the DriftDetected condition of the AzureManagedControlPlane from the fields of the managed
+// cluster which differ from the spec.
+func (s *ManagedControlPlaneScope) SetDriftDetected(drift []string) {
+	conditions.Set(s.ControlPlane, driftDetectedCondition(drift, isReportingDriftOnly(s.ControlPlane)))
+}
+
+// ControlPlaneVersion returns the Kubernetes version of the control plane, taking AKS auto-upgrades into account.
+func (s *ManagedControlPlaneScope) ControlPlaneVersion() string {
+	if semver.Compare(s.ControlPlane.Status.AutoUpgradeVersion, s.ControlPlane.Spec.Version) > 0 {
+		return s.ControlPlane.Status.AutoUpgradeVersion
+	}
+	return s.ControlPlane.Spec.Version
+}
+
+// cidrsPerIPFamily returns the CIDR blocks of a dual-stack cluster, with the first one replaced by the given CIDR block
+// when it is set.
+func cidrsPerIPFamily(cidrBlocks []string, first string) []string {
+	cidrs := make([]string, len(cidrBlocks))
+	copy(cidrs, cidrBlocks)
+	if first != "" {
+		cidrs[0] = first
+	}
+	return cidrs
+}
+
+// isReportingDriftOnly returns true if the managed cluster and its agent pools are left as they are when they differ
+// from their spec.
+func isReportingDriftOnly(controlPlane *infrav1.AzureManagedControlPlane) bool {
+	return ptr.Deref(controlPlane.Spec.DriftMode, infrav1.DriftModeCorrect) == infrav1.DriftModeReport
+}
+
+// upgradesKubernetesVersion returns true if the auto-upgrade channel of the managed cluster upgrades its Kubernetes
+// version, and not only the node images.
+func upgradesKubernetesVersion(controlPlane *infrav1.AzureManagedControlPlane) bool {
+	if controlPlane.Spec.AutoUpgradeProfile == nil || controlPlane.Spec.AutoUpgradeProfile.UpgradeChannel == nil {
+		return false
+	}
+	switch *controlPlane.Spec.AutoUpgradeProfile.UpgradeChannel {
+	case infrav1.UpgradeChannelPatch, infrav1.UpgradeChannelRapid, infrav1.UpgradeChannelStable:
+		return true
+	default:
+		return false
+	}
+}
+
+// driftDetectedCondition returns the DriftDetected condition for the given fields which differ from the spec.
+func driftDetectedCondition(drift []string, reportOnly bool) *clusterv1.Condition {
+	if len(drift) == 0 {
+		return conditions.FalseCondition(infrav1.DriftDetectedCondition, infrav1.NoDriftReason, clusterv1.ConditionSeverityNone, "")
+	}
+	condition := &clusterv1.Condition{
+		Type:    infrav1.DriftDetectedCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  infrav1.UpdatingReason,
+		Message: fmt.Sprintf("%s differ from the spec and are being updated", strings.Join(drift, ", ")),
+	}
+	if reportOnly {
+		condition.Reason = infrav1.DriftReportedReason
+		condition.Message = fmt.Sprintf("%s differ from the spec and are not updated as drift is only reported", strings.Join(drift, ", "))
+	}
+	return condition
+}
+
+// readyConditions returns the conditions of the object which make up its Ready condition. DriftDetected is left out as
+// it is true when the resource differs from its spec. The list is never nil, as SetSummary would then use all conditions.
+func readyConditions(from conditions.Getter) []clusterv1.ConditionType {
+	types := make([]clusterv1.ConditionType, 0, len(from.GetConditions()))
+	for _, condition := range from.GetConditions() {
+		if condition.Type != clusterv1.ReadyCondition && condition.Type != infrav1.DriftDetectedCondition {
+			types = append(types, condition.Type)
+		}
+	}
+	return types
+}
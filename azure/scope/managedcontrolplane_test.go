@@ -22,6 +22,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
@@ -31,6 +32,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -188,6 +191,51 @@ func TestManagedControlPlaneScope_PoolVersion(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "With version auto-upgraded by AKS",
+			Input: ManagedControlPlaneScopeParams{
+				AzureClients: AzureClients{
+					Authorizer: autorest.NullAuthorizer{},
+				},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster1",
+						Namespace: "default",
+					},
+				},
+				ControlPlane: &infrav1.AzureManagedControlPlane{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster1",
+						Namespace: "default",
+					},
+					Spec: infrav1.AzureManagedControlPlaneSpec{
+						Version:        "v1.21.0",
+						SubscriptionID: "00000000-0000-0000-0000-000000000000",
+					},
+					Status: infrav1.AzureManagedControlPlaneStatus{
+						AutoUpgradeVersion: "v1.21.1",
+					},
+				},
+				ManagedMachinePools: []ManagedMachinePool{
+					{
+						MachinePool:      getMachinePoolWithVersion("pool0", "v1.21.1"),
+						InfraMachinePool: getAzureMachinePool("pool0", infrav1.NodePoolModeSystem),
+					},
+				},
+			},
+			Expected: []azure.ResourceSpecGetter{
+				&agentpools.AgentPoolSpec{
+					Name:         "pool0",
+					SKU:          "Standard_D2s_v3",
+					Mode:         "System",
+					Replicas:     1,
+					Version:      ptr.To("1.21.1"),
+					Cluster:      "cluster1",
+					VnetSubnetID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups//providers/Microsoft.Network/virtualNetworks//subnets/",
+					Headers:      map[string]string{},
+				},
+			},
+		},
 		{
 			Name: "With bad version",
 			Input: ManagedControlPlaneScopeParams{
@@ -239,6 +287,296 @@ func TestManagedControlPlaneScope_PoolVersion(t *testing.T) {
 	}
 }
 
+func TestManagedControlPlaneScope_SetAutoUpgradeVersionStatus(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Version  string
+		Expected string
+	}{
+		{
+			Name:     "version matches the spec",
+			Version:  "1.27.1",
+			Expected: "",
+		},
+		{
+			Name:     "version is newer than the spec",
+			Version:  "1.27.3",
+			Expected: "v1.27.3",
+		},
+		{
+			Name:     "version is not set",
+			Version:  "",
+			Expected: "",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &ManagedControlPlaneScope{
+				ControlPlane: &infrav1.AzureManagedControlPlane{
+					Spec: infrav1.AzureManagedControlPlaneSpec{
+						Version: "v1.27.1",
+					},
+					Status: infrav1.AzureManagedControlPlaneStatus{
+						AutoUpgradeVersion: "v1.27.2",
+					},
+				},
+			}
+			s.SetAutoUpgradeVersionStatus(c.Version)
+			g.Expect(s.ControlPlane.Status.AutoUpgradeVersion).To(Equal(c.Expected))
+		})
+	}
+}
+
+func TestManagedControlPlaneScope_AdoptAgentPools(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := &ManagedControlPlaneScope{
+		Client: fakeClient,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "default"},
+		},
+		ControlPlane: &infrav1.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "default"},
+			Spec: infrav1.AzureManagedControlPlaneSpec{
+				AdoptionMode: ptr.To(infrav1.AdoptionModeAdopt),
+			},
+		},
+		ManagedMachinePools: []ManagedMachinePool{
+			{
+				MachinePool:      getMachinePool("pool0"),
+				InfraMachinePool: getAzureMachinePool("pool0", infrav1.NodePoolModeSystem),
+			},
+		},
+	}
+	g.Expect(s.IsAdoptingManagedCluster()).To(BeTrue())
+
+	err := s.AdoptAgentPools(context.TODO(), []azure.AdoptedAgentPool{
+		{
+			Spec:     infrav1.AzureManagedMachinePoolSpec{Name: ptr.To("pool0"), Mode: "System", SKU: "Standard_D2s_v3"},
+			Replicas: 1,
+		},
+		{
+			Spec:     infrav1.AzureManagedMachinePoolSpec{Name: ptr.To("pool1"), Mode: "User", SKU: "Standard_D4s_v3"},
+			Replicas: 3,
+			Version:  ptr.To("v1.27.3"),
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The agent pool which already has an AzureManagedMachinePool is left alone.
+	machinePools := &expv1.MachinePoolList{}
+	g.Expect(fakeClient.List(context.TODO(), machinePools)).To(Succeed())
+	g.Expect(machinePools.Items).To(HaveLen(1))
+
+	machinePool := machinePools.Items[0]
+	g.Expect(machinePool.Name).To(Equal("cluster1-pool1"))
+	g.Expect(machinePool.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "cluster1"))
+	g.Expect(machinePool.Spec.Replicas).To(Equal(ptr.To[int32](3)))
+	g.Expect(machinePool.Spec.Template.Spec.Version).To(Equal(ptr.To("v1.27.3")))
+	g.Expect(machinePool.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("cluster1-pool1"))
+
+	infraMachinePool := &infrav1.AzureManagedMachinePool{}
+	g.Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "cluster1-pool1"}, infraMachinePool)).To(Succeed())
+	g.Expect(infraMachinePool.Spec.SKU).To(Equal("Standard_D4s_v3"))
+	g.Expect(infraMachinePool.OwnerReferences).To(HaveLen(1))
+	g.Expect(infraMachinePool.OwnerReferences[0].Kind).To(Equal("MachinePool"))
+	g.Expect(infraMachinePool.OwnerReferences[0].Name).To(Equal("cluster1-pool1"))
+
+	// Adopting the agent pools again is a no-op.
+	g.Expect(s.AdoptAgentPools(context.TODO(), []azure.AdoptedAgentPool{
+		{Spec: infrav1.AzureManagedMachinePoolSpec{Name: ptr.To("pool1"), Mode: "User", SKU: "Standard_D4s_v3"}},
+	})).To(Succeed())
+
+	s.SetConditionTrue(infrav1.AdoptedCondition)
+	g.Expect(s.IsAdoptingManagedCluster()).To(BeFalse())
+}
+
+func TestManagedControlPlaneScope_SetDriftDetected(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	cases := []struct {
+		Name            string
+		DriftMode       *infrav1.DriftMode
+		Drift           []string
+		ExpectedStatus  corev1.ConditionStatus
+		ExpectedReason  string
+		ExpectedMessage string
+	}{
+		{
+			Name:           "no drift",
+			Drift:          []string{},
+			ExpectedStatus: corev1.ConditionFalse,
+			ExpectedReason: infrav1.NoDriftReason,
+		},
+		{
+			Name:            "drift is corrected",
+			Drift:           []string{"Properties.KubernetesVersion", "Tags"},
+			ExpectedStatus:  corev1.ConditionTrue,
+			ExpectedReason:  infrav1.UpdatingReason,
+			ExpectedMessage: "Properties.KubernetesVersion, Tags differ from the spec and are being updated",
+		},
+		{
+			Name:            "drift is only reported",
+			DriftMode:       ptr.To(infrav1.DriftModeReport),
+			Drift:           []string{"Properties.KubernetesVersion"},
+			ExpectedStatus:  corev1.ConditionTrue,
+			ExpectedReason:  infrav1.DriftReportedReason,
+			ExpectedMessage: "Properties.KubernetesVersion differ from the spec and are not updated as drift is only reported",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			controlPlane := &infrav1.AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "default"},
+				Spec: infrav1.AzureManagedControlPlaneSpec{
+					SubscriptionID: "00000000-0000-0000-0000-000000000000",
+					DriftMode:      c.DriftMode,
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).WithStatusSubresource(controlPlane).Build()
+			s, err := NewManagedControlPlaneScope(context.TODO(), ManagedControlPlaneScopeParams{
+				Client: fakeClient,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "default"},
+				},
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(s.ManagedClusterSpec().(*managedclusters.ManagedClusterSpec).ReportDriftOnly).To(Equal(c.DriftMode != nil))
+
+			conditions.MarkUnknown(s.ControlPlane, infrav1.ManagedClusterRunningCondition, infrav1.UpdatingReason, "")
+			s.SetDriftDetected(c.Drift)
+			g.Expect(s.PatchObject(context.TODO())).To(Succeed())
+
+			condition := conditions.Get(s.ControlPlane, infrav1.DriftDetectedCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(c.ExpectedStatus))
+			g.Expect(condition.Reason).To(Equal(c.ExpectedReason))
+			g.Expect(condition.Message).To(Equal(c.ExpectedMessage))
+			// The Ready condition only reflects the other conditions.
+			g.Expect(conditions.IsUnknown(s.ControlPlane, clusterv1.ReadyCondition)).To(BeTrue())
+		})
+	}
+}
+
+func TestManagedControlPlaneScope_ManagedClusterSpecCIDRs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	cases := []struct {
+		Name                 string
+		IPFamilies           []infrav1.IPFamily
+		PodCIDR              *string
+		ServiceCIDR          *string
+		PodCIDRBlocks        []string
+		ServiceCIDRBlocks    []string
+		ExpectedPodCIDR      string
+		ExpectedServiceCIDR  string
+		ExpectedPodCIDRs     []string
+		ExpectedServiceCIDRs []string
+	}{
+		{
+			Name:                "single-stack cluster network",
+			PodCIDRBlocks:       []string{"192.168.0.0/16"},
+			ServiceCIDRBlocks:   []string{"10.0.0.0/16"},
+			ExpectedPodCIDR:     "192.168.0.0/16",
+			ExpectedServiceCIDR: "10.0.0.0/16",
+		},
+		{
+			Name:                "single-stack cluster network overridden by the control plane",
+			PodCIDR:             ptr.To("172.16.0.0/16"),
+			ServiceCIDR:         ptr.To("10.1.0.0/16"),
+			PodCIDRBlocks:       []string{"192.168.0.0/16"},
+			ServiceCIDRBlocks:   []string{"10.0.0.0/16"},
+			ExpectedPodCIDR:     "172.16.0.0/16",
+			ExpectedServiceCIDR: "10.1.0.0/16",
+		},
+		{
+			Name:              "several CIDR blocks are ignored for single-stack clusters",
+			IPFamilies:        []infrav1.IPFamily{infrav1.IPFamilyIPv4},
+			PodCIDRBlocks:     []string{"192.168.0.0/16", "fd12:3456:789a::/64"},
+			ServiceCIDRBlocks: []string{"10.0.0.0/16", "fd12:3456:789a:1::/108"},
+		},
+		{
+			Name:                 "dual-stack cluster network",
+			IPFamilies:           []infrav1.IPFamily{infrav1.IPFamilyIPv4, infrav1.IPFamilyIPv6},
+			PodCIDRBlocks:        []string{"192.168.0.0/16", "fd12:3456:789a::/64"},
+			ServiceCIDRBlocks:    []string{"10.0.0.0/16", "fd12:3456:789a:1::/108"},
+			ExpectedPodCIDR:      "192.168.0.0/16",
+			ExpectedServiceCIDR:  "10.0.0.0/16",
+			ExpectedPodCIDRs:     []string{"192.168.0.0/16", "fd12:3456:789a::/64"},
+			ExpectedServiceCIDRs: []string{"10.0.0.0/16", "fd12:3456:789a:1::/108"},
+		},
+		{
+			Name:                 "dual-stack cluster network overridden by the control plane",
+			IPFamilies:           []infrav1.IPFamily{infrav1.IPFamilyIPv4, infrav1.IPFamilyIPv6},
+			PodCIDR:              ptr.To("172.16.0.0/16"),
+			ServiceCIDR:          ptr.To("10.1.0.0/16"),
+			PodCIDRBlocks:        []string{"192.168.0.0/16", "fd12:3456:789a::/64"},
+			ServiceCIDRBlocks:    []string{"10.0.0.0/16", "fd12:3456:789a:1::/108"},
+			ExpectedPodCIDR:      "172.16.0.0/16",
+			ExpectedServiceCIDR:  "10.1.0.0/16",
+			ExpectedPodCIDRs:     []string{"172.16.0.0/16", "fd12:3456:789a::/64"},
+			ExpectedServiceCIDRs: []string{"10.1.0.0/16", "fd12:3456:789a:1::/108"},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "default"},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: &clusterv1.ClusterNetwork{
+						Pods:     &clusterv1.NetworkRanges{CIDRBlocks: c.PodCIDRBlocks},
+						Services: &clusterv1.NetworkRanges{CIDRBlocks: c.ServiceCIDRBlocks},
+					},
+				},
+			}
+			controlPlane := &infrav1.AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "default"},
+				Spec: infrav1.AzureManagedControlPlaneSpec{
+					SubscriptionID: "00000000-0000-0000-0000-000000000000",
+					IPFamilies:     c.IPFamilies,
+					PodCIDR:        c.PodCIDR,
+					ServiceCIDR:    c.ServiceCIDR,
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).Build()
+			s, err := NewManagedControlPlaneScope(context.TODO(), ManagedControlPlaneScopeParams{
+				Client:       fakeClient,
+				Cluster:      cluster,
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			spec := s.ManagedClusterSpec().(*managedclusters.ManagedClusterSpec)
+			g.Expect(spec.PodCIDR).To(Equal(c.ExpectedPodCIDR))
+			g.Expect(spec.ServiceCIDR).To(Equal(c.ExpectedServiceCIDR))
+			g.Expect(spec.PodCIDRs).To(Equal(c.ExpectedPodCIDRs))
+			g.Expect(spec.ServiceCIDRs).To(Equal(c.ExpectedServiceCIDRs))
+			// The CIDR blocks of the Cluster are left as they are.
+			g.Expect(cluster.Spec.ClusterNetwork.Pods.CIDRBlocks).To(Equal(c.PodCIDRBlocks))
+		})
+	}
+}
+
 func TestManagedControlPlaneScope_AddonProfiles(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = expv1.AddToScheme(scheme)
@@ -610,3 +948,54 @@ func TestManagedControlPlaneScope_IsVnetManagedCache(t *testing.T) {
 		})
 	}
 }
+
+func TestUpgradesKubernetesVersion(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Profile  *infrav1.ManagedClusterAutoUpgradeProfile
+		Expected bool
+	}{
+		{
+			Name:     "no auto-upgrade profile",
+			Expected: false,
+		},
+		{
+			Name:     "no upgrade channel",
+			Profile:  &infrav1.ManagedClusterAutoUpgradeProfile{},
+			Expected: false,
+		},
+		{
+			Name:     "node image upgrade channel",
+			Profile:  &infrav1.ManagedClusterAutoUpgradeProfile{UpgradeChannel: ptr.To(infrav1.UpgradeChannelNodeImage)},
+			Expected: false,
+		},
+		{
+			Name:     "none upgrade channel",
+			Profile:  &infrav1.ManagedClusterAutoUpgradeProfile{UpgradeChannel: ptr.To(infrav1.UpgradeChannelNone)},
+			Expected: false,
+		},
+		{
+			Name:     "patch upgrade channel",
+			Profile:  &infrav1.ManagedClusterAutoUpgradeProfile{UpgradeChannel: ptr.To(infrav1.UpgradeChannelPatch)},
+			Expected: true,
+		},
+		{
+			Name:     "stable upgrade channel",
+			Profile:  &infrav1.ManagedClusterAutoUpgradeProfile{UpgradeChannel: ptr.To(infrav1.UpgradeChannelStable)},
+			Expected: true,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			controlPlane := &infrav1.AzureManagedControlPlane{
+				Spec: infrav1.AzureManagedControlPlaneSpec{
+					AutoUpgradeProfile: c.Profile,
+				},
+			}
+			g.Expect(upgradesKubernetesVersion(controlPlane)).To(Equal(c.Expected))
+		})
+	}
+}
@@ -106,7 +106,7 @@ func (s *ManagedMachinePoolScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.ManagedMachinePoolScope.PatchObject")
 	defer done()
 
-	conditions.SetSummary(s.InfraMachinePool)
+	conditions.SetSummary(s.InfraMachinePool, conditions.WithConditions(readyConditions(s.InfraMachinePool)...))
 
 	return s.patchHelper.Patch(
 		ctx,
@@ -134,6 +134,18 @@ func (s *ManagedMachinePoolScope) Name() string {
 	return s.InfraMachinePool.Name
 }
 
+// IsManagedClusterStopped returns true if the managed cluster is stopped or is to be stopped.
+func (s *ManagedMachinePoolScope) IsManagedClusterStopped() bool {
+	return s.ControlPlane.Status.PowerState == infrav1.PowerStateStopped ||
+		ptr.Deref(s.ControlPlane.Spec.PowerState, "") == infrav1.PowerStateStopped
+}
+
+// IsAdoptingAgentPool returns true if the managed cluster is adopted and the agent pool was not adopted yet.
+func (s *ManagedMachinePoolScope) IsAdoptingAgentPool() bool {
+	return ptr.Deref(s.ControlPlane.Spec.AdoptionMode, infrav1.AdoptionModeNone) == infrav1.AdoptionModeAdopt &&
+		!conditions.IsTrue(s.InfraMachinePool, infrav1.AdoptedCondition)
+}
+
 // SetSubnetName updates AzureManagedMachinePool.SubnetName if AzureManagedMachinePool.SubnetName is empty with s.ControlPlane.Spec.VirtualNetwork.Subnet.Name.
 func (s *ManagedMachinePoolScope) SetSubnetName() {
 	s.InfraMachinePool.Spec.SubnetName = getAgentPoolSubnet(s.ControlPlane, s.InfraMachinePool)
@@ -174,33 +186,51 @@ func buildAgentPoolSpec(managedControlPlane *infrav1.AzureManagedControlPlane,
 		Replicas:      replicas,
 		Version:       normalizedVersion,
 		OSType:        managedMachinePool.Spec.OSType,
+		OSSKU:         managedMachinePool.Spec.OSSKU,
 		VnetSubnetID: azure.SubnetID(
 			managedControlPlane.Spec.SubscriptionID,
 			managedControlPlane.Spec.VirtualNetwork.ResourceGroup,
 			managedControlPlane.Spec.VirtualNetwork.Name,
 			ptr.Deref(getAgentPoolSubnet(managedControlPlane, managedMachinePool), ""),
 		),
-		Mode:                 managedMachinePool.Spec.Mode,
-		MaxPods:              managedMachinePool.Spec.MaxPods,
-		AvailabilityZones:    managedMachinePool.Spec.AvailabilityZones,
-		OsDiskType:           managedMachinePool.Spec.OsDiskType,
-		EnableUltraSSD:       managedMachinePool.Spec.EnableUltraSSD,
-		Headers:              maps.FilterByKeyPrefix(agentPoolAnnotations, infrav1.CustomHeaderPrefix),
-		EnableNodePublicIP:   managedMachinePool.Spec.EnableNodePublicIP,
-		NodePublicIPPrefixID: managedMachinePool.Spec.NodePublicIPPrefixID,
-		ScaleSetPriority:     managedMachinePool.Spec.ScaleSetPriority,
-		ScaleDownMode:        managedMachinePool.Spec.ScaleDownMode,
-		SpotMaxPrice:         managedMachinePool.Spec.SpotMaxPrice,
-		AdditionalTags:       managedMachinePool.Spec.AdditionalTags,
-		KubeletDiskType:      managedMachinePool.Spec.KubeletDiskType,
-		LinuxOSConfig:        managedMachinePool.Spec.LinuxOSConfig,
-		EnableFIPS:           managedMachinePool.Spec.EnableFIPS,
+		Mode:                       managedMachinePool.Spec.Mode,
+		MaxPods:                    managedMachinePool.Spec.MaxPods,
+		AvailabilityZones:          managedMachinePool.Spec.AvailabilityZones,
+		OsDiskType:                 managedMachinePool.Spec.OsDiskType,
+		EnableUltraSSD:             managedMachinePool.Spec.EnableUltraSSD,
+		Headers:                    maps.FilterByKeyPrefix(agentPoolAnnotations, infrav1.CustomHeaderPrefix),
+		EnableNodePublicIP:         managedMachinePool.Spec.EnableNodePublicIP,
+		NodePublicIPPrefixID:       managedMachinePool.Spec.NodePublicIPPrefixID,
+		ScaleSetPriority:           managedMachinePool.Spec.ScaleSetPriority,
+		ScaleDownMode:              managedMachinePool.Spec.ScaleDownMode,
+		SpotMaxPrice:               managedMachinePool.Spec.SpotMaxPrice,
+		AdditionalTags:             managedMachinePool.Spec.AdditionalTags,
+		KubeletDiskType:            managedMachinePool.Spec.KubeletDiskType,
+		LinuxOSConfig:              managedMachinePool.Spec.LinuxOSConfig,
+		EnableFIPS:                 managedMachinePool.Spec.EnableFIPS,
+		ProximityPlacementGroupID:  managedMachinePool.Spec.ProximityPlacementGroupID,
+		HostGroupID:                managedMachinePool.Spec.HostGroupID,
+		CapacityReservationGroupID: managedMachinePool.Spec.CapacityReservationGroupID,
+		GPUInstanceProfile:         managedMachinePool.Spec.GPUInstanceProfile,
+		SkipGPUDriverInstall:       managedMachinePool.Spec.SkipGPUDriverInstall,
+		ReportDriftOnly:            isReportingDriftOnly(managedControlPlane),
+		UpgradesKubernetesVersion:  upgradesKubernetesVersion(managedControlPlane),
+		UpgradeSettings:            managedMachinePool.Spec.UpgradeSettings,
 	}
 
 	if managedMachinePool.Spec.OSDiskSizeGB != nil {
 		agentPoolSpec.OSDiskSizeGB = *managedMachinePool.Spec.OSDiskSizeGB
 	}
 
+	if managedMachinePool.Spec.PodSubnetName != nil {
+		agentPoolSpec.PodSubnetID = ptr.To(azure.SubnetID(
+			managedControlPlane.Spec.SubscriptionID,
+			managedControlPlane.Spec.VirtualNetwork.ResourceGroup,
+			managedControlPlane.Spec.VirtualNetwork.Name,
+			*managedMachinePool.Spec.PodSubnetName,
+		))
+	}
+
 	if len(managedMachinePool.Spec.Taints) > 0 {
 		nodeTaints := make([]string, 0, len(managedMachinePool.Spec.Taints))
 		for _, t := range managedMachinePool.Spec.Taints {
@@ -258,6 +288,51 @@ func (s *ManagedMachinePoolScope) SetAgentPoolReady(ready bool) {
 	s.InfraMachinePool.Status.Ready = ready
 }
 
+// AnnotationJSON returns a map[string]interface from a JSON annotation.
+func (s *ManagedMachinePoolScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
+	return annotationJSON(s.InfraMachinePool, annotation)
+}
+
+// UpdateAnnotationJSON updates the `annotation` with
+// `content`. `content` in this case should be a `map[string]interface{}`
+// suitable for turning into JSON. This `content` map will be marshalled into a
+// JSON string before being set as the given `annotation`.
+func (s *ManagedMachinePoolScope) UpdateAnnotationJSON(annotation string, content map[string]interface{}) error {
+	return updateAnnotationJSON(s.InfraMachinePool, annotation, content)
+}
+
+// SetConditionTrue sets the specified AzureManagedMachinePool condition to true.
+func (s *ManagedMachinePoolScope) SetConditionTrue(conditionType clusterv1.ConditionType) {
+	conditions.MarkTrue(s.InfraMachinePool, conditionType)
+}
+
+// SetConditionFalse sets the specified AzureManagedMachinePool condition to false.
+func (s *ManagedMachinePoolScope) SetConditionFalse(conditionType clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.MarkFalse(s.InfraMachinePool, conditionType, reason, severity, message)
+}
+
+// DeleteCondition removes a condition from the AzureManagedMachinePool status.
+func (s *ManagedMachinePoolScope) DeleteCondition(condition clusterv1.ConditionType) {
+	conditions.Delete(s.InfraMachinePool, condition)
+}
+
+// SetDriftDetected sets the DriftDetected condition of the AzureManagedMachinePool from the fields of the agent pool
+// which differ from the spec.
+func (s *ManagedMachinePoolScope) SetDriftDetected(drift []string) {
+	conditions.Set(s.InfraMachinePool, driftDetectedCondition(drift, isReportingDriftOnly(s.ControlPlane)))
+}
+
+// DesiredNodeImageVersion returns the desired node image version of the agent pool.
+func (s *ManagedMachinePoolScope) DesiredNodeImageVersion() *string {
+	return s.InfraMachinePool.Spec.NodeImageVersion
+}
+
+// SetNodeImageVersionStatus sets the current and latest node image versions of the agent pool.
+func (s *ManagedMachinePoolScope) SetNodeImageVersionStatus(current, latest string) {
+	s.InfraMachinePool.Status.NodeImageVersion = current
+	s.InfraMachinePool.Status.LatestNodeImageVersion = latest
+}
+
 // SetLongRunningOperationState will set the future on the AzureManagedMachinePool status to allow the resource to continue
 // in the next reconciliation.
 func (s *ManagedMachinePoolScope) SetLongRunningOperationState(future *infrav1.Future) {
@@ -18,6 +18,9 @@ package agentpools
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	"github.com/pkg/errors"
@@ -25,12 +28,16 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 const serviceName = "agentpools"
 
+// adoptionRequeueInterval is how long to wait before checking again whether an agent pool to adopt matches its spec.
+const adoptionRequeueInterval = 1 * time.Minute
+
 // AgentPoolScope defines the scope interface for an agent pool.
 type AgentPoolScope interface {
 	azure.ClusterDescriber
@@ -47,16 +54,30 @@ type AgentPoolScope interface {
 	SetCAPIMachinePoolAnnotation(key, value string)
 	RemoveCAPIMachinePoolAnnotation(key string)
 	SetSubnetName()
+	IsManagedClusterStopped() bool
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
+	SetConditionTrue(clusterv1.ConditionType)
+	SetConditionFalse(clusterv1.ConditionType, string, clusterv1.ConditionSeverity, string)
+	DeleteCondition(clusterv1.ConditionType)
+	DesiredNodeImageVersion() *string
+	SetNodeImageVersionStatus(current, latest string)
+	IsAdoptingAgentPool() bool
+	SetDriftDetected([]string)
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	scope AgentPoolScope
 	async.Reconciler
+	async.Getter
+	UpgradeProfileGetter
+	NodeImageUpgrader async.Invoker[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]
+	resourceSKUCache  *resourceskus.Cache
 }
 
 // New creates a new service.
-func New(scope AgentPoolScope) (*Service, error) {
+func New(scope AgentPoolScope, skuCache *resourceskus.Cache) (*Service, error) {
 	client, err := newClient(scope)
 	if err != nil {
 		return nil, err
@@ -65,6 +86,10 @@ func New(scope AgentPoolScope) (*Service, error) {
 		scope: scope,
 		Reconciler: async.New[armcontainerservice.AgentPoolsClientCreateOrUpdateResponse,
 			armcontainerservice.AgentPoolsClientDeleteResponse](scope, client, client),
+		Getter:               client,
+		UpgradeProfileGetter: client,
+		NodeImageUpgrader:    async.InvokerFunc[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse](client.UpgradeNodeImageVersionAsync),
+		resourceSKUCache:     skuCache,
 	}, nil
 }
 
@@ -78,30 +103,197 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "agentpools.Service.Reconcile")
 	defer done()
 
-	var resultingErr error
-	if agentPoolSpec := s.scope.AgentPoolSpec(); agentPoolSpec != nil {
-		result, err := s.CreateOrUpdateResource(ctx, agentPoolSpec, serviceName)
+	spec := s.scope.AgentPoolSpec()
+	if spec == nil {
+		return nil
+	}
+	agentPoolSpec, ok := spec.(*AgentPoolSpec)
+	if !ok {
+		return errors.Errorf("%T is not an AgentPoolSpec", spec)
+	}
+
+	if err := s.validateSpec(ctx, agentPoolSpec); err != nil {
+		s.scope.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, err)
+		return err
+	}
+
+	// The last applied node labels and taints are left nil for agent pools reconciled before CAPZ recorded them.
+	var err error
+	annotations := s.scope.AgentPoolAnnotations()
+	if _, ok := annotations[azure.NodeLabelsLastAppliedAnnotation]; ok {
+		if agentPoolSpec.LastAppliedNodeLabels, err = s.scope.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation); err != nil {
+			return err
+		}
+	}
+	if _, ok := annotations[azure.NodeTaintsLastAppliedAnnotation]; ok {
+		if agentPoolSpec.LastAppliedNodeTaints, err = s.scope.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation); err != nil {
+			return err
+		}
+	}
+
+	if s.scope.IsAdoptingAgentPool() {
+		if err := s.adoptAgentPool(ctx, agentPoolSpec); err != nil {
+			return err
+		}
+	}
+
+	// The agent pool cannot be updated until an ongoing node image upgrade is done.
+	if s.scope.GetLongRunningOperationState(agentPoolSpec.ResourceName(), serviceName, infrav1.UpgradeNodeImageFuture) != nil {
+		err := async.InvokeResource(ctx, s.scope, s.NodeImageUpgrader, agentPoolSpec, serviceName, infrav1.UpgradeNodeImageFuture)
+		s.scope.UpdatePatchStatus(infrav1.NodeImageUpgradedCondition, serviceName, err)
 		if err != nil {
-			resultingErr = err
-		} else {
-			agentPool, ok := result.(armcontainerservice.AgentPool)
-			if !ok {
-				return errors.Errorf("%T is not an armcontainerservice.AgentPool", result)
-			}
-			// When autoscaling is set, add the annotation to the machine pool and update the replica count.
-			if ptr.Deref(agentPool.Properties.EnableAutoScaling, false) {
-				s.scope.SetCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation, "true")
-				s.scope.SetCAPIMachinePoolReplicas(agentPool.Properties.Count)
-			} else { // Otherwise, remove the annotation.
-				s.scope.RemoveCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation)
-			}
+			return err
+		}
+	}
+
+	var agentPool armcontainerservice.AgentPool
+	result, resultingErr := s.CreateOrUpdateResource(ctx, agentPoolSpec, serviceName)
+	// The drift is only known when the spec was compared to the existing agent pool.
+	if drift := agentPoolSpec.Drift(); drift != nil {
+		s.scope.SetDriftDetected(drift)
+	}
+	if resultingErr == nil {
+		var ok bool
+		agentPool, ok = result.(armcontainerservice.AgentPool)
+		if !ok {
+			return errors.Errorf("%T is not an armcontainerservice.AgentPool", result)
+		}
+		// When autoscaling is set, add the annotation to the machine pool and update the replica count.
+		if ptr.Deref(agentPool.Properties.EnableAutoScaling, false) {
+			s.scope.SetCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation, "true")
+			s.scope.SetCAPIMachinePoolReplicas(agentPool.Properties.Count)
+		} else { // Otherwise, remove the annotation.
+			s.scope.RemoveCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation)
+		}
+		if err := s.updateNodeLabelsAndTaints(agentPoolSpec); err != nil {
+			return err
+		}
+	}
+
+	s.scope.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, resultingErr)
+	if resultingErr != nil {
+		return resultingErr
+	}
+
+	return s.reconcileNodeImage(ctx, agentPoolSpec, agentPool)
+}
+
+// adoptAgentPool checks whether the agent pool of an adopted managed cluster matches its spec. The agent pool is not
+// updated until it does, while an agent pool which does not exist yet is created as usual.
+func (s *Service) adoptAgentPool(ctx context.Context, agentPoolSpec *AgentPoolSpec) error {
+	existing, err := s.Get(ctx, agentPoolSpec)
+	if err != nil {
+		if azure.ResourceNotFound(err) {
+			s.scope.SetConditionTrue(infrav1.AdoptedCondition)
+			return nil
+		}
+		return errors.Wrap(err, "failed to get agent pool to adopt")
+	}
+
+	// Parameters returns nil when drift is only reported, so the fields which differ are checked instead.
+	if _, err := agentPoolSpec.Parameters(ctx, existing); err != nil {
+		return err
+	}
+	drift := agentPoolSpec.Drift()
+	if drift == nil {
+		return errors.Errorf("failed to compare the spec to the existing agent pool %s", agentPoolSpec.ResourceName())
+	}
+	if len(drift) > 0 {
+		msg := fmt.Sprintf("the spec does not match the existing agent pool in %s, which is not updated until it does", strings.Join(drift, ", "))
+		s.scope.SetConditionFalse(infrav1.AdoptedCondition, infrav1.AdoptionPendingReason, clusterv1.ConditionSeverityWarning, msg)
+		return azure.WithTransientError(errors.Errorf("agent pool %s is pending adoption: %s", agentPoolSpec.ResourceName(), msg), adoptionRequeueInterval)
+	}
+
+	s.scope.SetConditionTrue(infrav1.AdoptedCondition)
+	return nil
+}
+
+// validateSpec checks that the GPU settings of the agent pool are only used with a GPU VM size.
+func (s *Service) validateSpec(ctx context.Context, agentPoolSpec *AgentPoolSpec) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "agentpools.Service.validateSpec")
+	defer done()
+
+	if agentPoolSpec.GPUInstanceProfile == nil && !ptr.Deref(agentPoolSpec.SkipGPUDriverInstall, false) {
+		return nil
+	}
+
+	sku, err := s.resourceSKUCache.Get(ctx, agentPoolSpec.SKU, resourceskus.VirtualMachines)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get SKU %s in compute api", agentPoolSpec.SKU)
+	}
+
+	hasGPUs, err := sku.HasCapabilityWithCapacity(resourceskus.GPUs, 1)
+	if err != nil {
+		return azure.WithTerminalError(errors.Wrap(err, "failed to validate the GPU capability"))
+	}
+	if !hasGPUs {
+		return azure.WithTerminalError(errors.Errorf("vm size %s has no GPUs. select a GPU vm size or unset gpuInstanceProfile and skipGPUDriverInstall", agentPoolSpec.SKU))
+	}
+
+	return nil
+}
+
+// updateNodeLabelsAndTaints records the node labels and taints applied to the agent pool, so that only those are removed
+// from it later on, and reports the ones which were changed outside of CAPZ.
+func (s *Service) updateNodeLabelsAndTaints(agentPoolSpec *AgentPoolSpec) error {
+	if err := s.scope.UpdateAnnotationJSON(azure.NodeLabelsLastAppliedAnnotation, nodeLabelsAnnotation(agentPoolSpec.NodeLabels)); err != nil {
+		return err
+	}
+	if err := s.scope.UpdateAnnotationJSON(azure.NodeTaintsLastAppliedAnnotation, nodeTaintsAnnotation(azure.PtrSlice(&agentPoolSpec.NodeTaints))); err != nil {
+		return err
+	}
+
+	if drift := agentPoolSpec.NodeLabelsAndTaintsDrift(); len(drift) > 0 {
+		msg := fmt.Sprintf("%s changed outside of CAPZ and restored", strings.Join(drift, ", "))
+		if agentPoolSpec.ReportDriftOnly {
+			msg = fmt.Sprintf("%s changed outside of CAPZ", strings.Join(drift, ", "))
 		}
+		s.scope.SetConditionFalse(infrav1.NodeLabelsAndTaintsSyncedCondition, infrav1.DriftedReason, clusterv1.ConditionSeverityWarning, msg)
 	} else {
+		s.scope.SetConditionTrue(infrav1.NodeLabelsAndTaintsSyncedCondition)
+	}
+	return nil
+}
+
+// reconcileNodeImage reports the node image versions of the agent pool and upgrades its node image when the desired
+// version is the latest one. The latest version is only looked up when a node image version is desired.
+func (s *Service) reconcileNodeImage(ctx context.Context, spec azure.ResourceSpecGetter, agentPool armcontainerservice.AgentPool) error {
+	current := ptr.Deref(agentPool.Properties.NodeImageVersion, "")
+	desired := s.scope.DesiredNodeImageVersion()
+	if desired == nil {
+		s.scope.SetNodeImageVersionStatus(current, "")
+		s.scope.DeleteCondition(infrav1.NodeImageUpgradedCondition)
 		return nil
 	}
 
-	s.scope.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, resultingErr)
-	return resultingErr
+	latest, err := s.GetLatestNodeImageVersion(ctx, spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the latest node image version")
+	}
+	s.scope.SetNodeImageVersionStatus(current, latest)
+
+	target := *desired
+	if target == infrav1.LatestNodeImageVersion {
+		target = latest
+	}
+	if target == current {
+		s.scope.UpdatePatchStatus(infrav1.NodeImageUpgradedCondition, serviceName, nil)
+		return nil
+	}
+	// AKS can only upgrade the node image to the latest version, so wait until the desired version is released.
+	if target == "" || target != latest {
+		msg := fmt.Sprintf("requested node image version %s is not the latest available version %s", target, latest)
+		s.scope.SetConditionFalse(infrav1.NodeImageUpgradedCondition, infrav1.NodeImageVersionNotLatestReason, clusterv1.ConditionSeverityInfo, msg)
+		return nil
+	}
+
+	err = async.InvokeResource(ctx, s.scope, s.NodeImageUpgrader, spec, serviceName, infrav1.UpgradeNodeImageFuture)
+	s.scope.UpdatePatchStatus(infrav1.NodeImageUpgradedCondition, serviceName, err)
+	if err != nil {
+		return err
+	}
+	s.scope.SetNodeImageVersionStatus(latest, latest)
+	return nil
 }
 
 // Delete deletes the virtual network with the provided name.
@@ -21,66 +21,325 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	"github.com/Azure/go-autorest/autorest"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools/mock_agentpools"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
-var internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+var (
+	fakeSKUs = []armcompute.ResourceSKU{
+		{
+			Name:         ptr.To("fake-sku"),
+			ResourceType: ptr.To(string(resourceskus.VirtualMachines)),
+			Capabilities: []*armcompute.ResourceSKUCapabilities{
+				{Name: ptr.To(resourceskus.GPUs), Value: ptr.To("1")},
+			},
+		},
+		{
+			Name:         ptr.To("fake-cpu-sku"),
+			ResourceType: ptr.To(string(resourceskus.VirtualMachines)),
+			Capabilities: []*armcompute.ResourceSKUCapabilities{
+				{Name: ptr.To(resourceskus.VCPUs), Value: ptr.To("2")},
+			},
+		},
+	}
+	lastAppliedAnnotations = map[string]string{
+		azure.NodeLabelsLastAppliedAnnotation: "{}",
+		azure.NodeTaintsLastAppliedAnnotation: "{}",
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
 
 func TestReconcileAgentPools(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+			p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse])
 	}{
 		{
 			name:          "agent pool successfully created with autoscaling enabled",
 			expectedError: "",
-			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
 				fakeAgentPoolSpec := fakeAgentPool()
 				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAgentPoolSpec, serviceName).Return(sdkFakeAgentPool(sdkWithAutoscaling(true), sdkWithCount(1)), nil)
 				s.SetCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation, "true")
 				s.SetCAPIMachinePoolReplicas(ptr.To[int32](1))
+				s.UpdateAnnotationJSON(azure.NodeLabelsLastAppliedAnnotation, map[string]interface{}{"fake-label": "fake-value"}).Return(nil)
+				s.UpdateAnnotationJSON(azure.NodeTaintsLastAppliedAnnotation, map[string]interface{}{"fake-taint": true}).Return(nil)
+				s.SetConditionTrue(infrav1.NodeLabelsAndTaintsSyncedCondition)
 				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, nil)
+				s.DesiredNodeImageVersion().Return(nil)
+				s.SetNodeImageVersionStatus("", "")
+				s.DeleteCondition(infrav1.NodeImageUpgradedCondition)
 			},
 		},
 		{
 			name:          "agent pool successfully created with autoscaling disabled",
 			expectedError: "",
-			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
 				fakeAgentPoolSpec := fakeAgentPool()
 				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAgentPoolSpec, serviceName).Return(sdkFakeAgentPool(sdkWithAutoscaling(false), sdkWithCount(1)), nil)
 				s.RemoveCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation)
 
+				s.UpdateAnnotationJSON(azure.NodeLabelsLastAppliedAnnotation, map[string]interface{}{"fake-label": "fake-value"}).Return(nil)
+				s.UpdateAnnotationJSON(azure.NodeTaintsLastAppliedAnnotation, map[string]interface{}{"fake-taint": true}).Return(nil)
+				s.SetConditionTrue(infrav1.NodeLabelsAndTaintsSyncedCondition)
+				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, nil)
+				s.DesiredNodeImageVersion().Return(nil)
+				s.SetNodeImageVersionStatus("", "")
+				s.DeleteCondition(infrav1.NodeImageUpgradedCondition)
+			},
+		},
+		{
+			name:          "GPU settings are not supported by the vm size",
+			expectedError: "vm size fake-cpu-sku has no GPUs. select a GPU vm size or unset gpuInstanceProfile and skipGPUDriverInstall",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
+				fakeAgentPoolSpec := fakeAgentPool(withSKU("fake-cpu-sku"))
+				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "GPU driver installation not skipped on a vm size without GPUs",
+			expectedError: "",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
+				fakeAgentPoolSpec := fakeAgentPool(withSKU("fake-cpu-sku"))
+				fakeAgentPoolSpec.GPUInstanceProfile = nil
+				fakeAgentPoolSpec.SkipGPUDriverInstall = ptr.To(false)
+				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAgentPoolSpec, serviceName).Return(sdkFakeAgentPool(sdkWithAutoscaling(false), sdkWithCount(1)), nil)
+				s.RemoveCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation)
+				s.UpdateAnnotationJSON(azure.NodeLabelsLastAppliedAnnotation, map[string]interface{}{"fake-label": "fake-value"}).Return(nil)
+				s.UpdateAnnotationJSON(azure.NodeTaintsLastAppliedAnnotation, map[string]interface{}{"fake-taint": true}).Return(nil)
+				s.SetConditionTrue(infrav1.NodeLabelsAndTaintsSyncedCondition)
 				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, nil)
+				s.DesiredNodeImageVersion().Return(nil)
+				s.SetNodeImageVersionStatus("", "")
+				s.DeleteCondition(infrav1.NodeImageUpgradedCondition)
 			},
 		},
 		{
 			name:          "no agent pool spec found",
 			expectedError: "",
-			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
 				s.AgentPoolSpec().Return(nil)
 			},
 		},
+		{
+			name:          "node labels and taints changed outside of CAPZ are restored",
+			expectedError: "",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
+				fakeAgentPoolSpec := fakeAgentPool()
+				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{"fake-label": "fake-value"}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{"fake-taint": true}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAgentPoolSpec, serviceName).DoAndReturn(
+					func(ctx context.Context, spec *AgentPoolSpec, serviceName string) (interface{}, error) {
+						// The agent pool lost the CAPZ taint and had its label changed outside of CAPZ.
+						_, err := spec.Parameters(ctx, sdkFakeAgentPool(sdkWithNodeLabels(map[string]*string{"fake-label": ptr.To("other-value")}), sdkWithNodeTaints(nil), sdkWithProvisioningState("Succeeded")))
+						return sdkFakeAgentPool(sdkWithAutoscaling(false)), err
+					})
+				s.SetDriftDetected([]string{"Properties.NodeLabels", "Properties.NodeTaints"})
+				s.RemoveCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation)
+				s.UpdateAnnotationJSON(azure.NodeLabelsLastAppliedAnnotation, map[string]interface{}{"fake-label": "fake-value"}).Return(nil)
+				s.UpdateAnnotationJSON(azure.NodeTaintsLastAppliedAnnotation, map[string]interface{}{"fake-taint": true}).Return(nil)
+				s.SetConditionFalse(infrav1.NodeLabelsAndTaintsSyncedCondition, infrav1.DriftedReason, clusterv1.ConditionSeverityWarning,
+					"label fake-label, taint fake-taint changed outside of CAPZ and restored")
+				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, nil)
+				s.DesiredNodeImageVersion().Return(nil)
+				s.SetNodeImageVersionStatus("", "")
+				s.DeleteCondition(infrav1.NodeImageUpgradedCondition)
+			},
+		},
+		{
+			name:          "drift is only reported",
+			expectedError: "",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
+				fakeAgentPoolSpec := fakeAgentPool()
+				fakeAgentPoolSpec.ReportDriftOnly = true
+				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{"fake-label": "fake-value"}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{"fake-taint": true}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAgentPoolSpec, serviceName).DoAndReturn(
+					func(ctx context.Context, spec *AgentPoolSpec, serviceName string) (interface{}, error) {
+						existing := sdkFakeAgentPool(sdkWithNodeLabels(map[string]*string{"fake-label": ptr.To("other-value")}), sdkWithNodeTaints(nil), sdkWithProvisioningState("Succeeded"))
+						// The existing agent pool is left as it is.
+						if params, err := spec.Parameters(ctx, existing); params != nil || err != nil {
+							return params, err
+						}
+						return existing, nil
+					})
+				s.SetDriftDetected([]string{"Properties.NodeLabels", "Properties.NodeTaints"})
+				s.SetCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation, "true")
+				s.SetCAPIMachinePoolReplicas(gomock.Any())
+				s.UpdateAnnotationJSON(azure.NodeLabelsLastAppliedAnnotation, map[string]interface{}{"fake-label": "fake-value"}).Return(nil)
+				s.UpdateAnnotationJSON(azure.NodeTaintsLastAppliedAnnotation, map[string]interface{}{"fake-taint": true}).Return(nil)
+				s.SetConditionFalse(infrav1.NodeLabelsAndTaintsSyncedCondition, infrav1.DriftedReason, clusterv1.ConditionSeverityWarning,
+					"label fake-label, taint fake-taint changed outside of CAPZ")
+				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, nil)
+				s.DesiredNodeImageVersion().Return(nil)
+				s.SetNodeImageVersionStatus("", "")
+				s.DeleteCondition(infrav1.NodeImageUpgradedCondition)
+			},
+		},
 		{
 			name:          "fail to create a agent pool",
 			expectedError: internalError.Error(),
-			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
 				fakeAgentPoolSpec := fakeAgentPool()
 				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAgentPoolSpec, serviceName).Return(nil, internalError)
 				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, internalError)
 			},
 		},
+		{
+			name:          "node image is upgraded to the latest version",
+			expectedError: "",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
+				fakeAgentPoolSpec := fakeAgentPool()
+				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAgentPoolSpec, serviceName).Return(sdkFakeAgentPool(sdkWithAutoscaling(false), sdkWithNodeImageVersion("AKSUbuntu-2204gen2containerd-202310.04.0")), nil)
+				s.RemoveCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation)
+				s.UpdateAnnotationJSON(azure.NodeLabelsLastAppliedAnnotation, map[string]interface{}{"fake-label": "fake-value"}).Return(nil)
+				s.UpdateAnnotationJSON(azure.NodeTaintsLastAppliedAnnotation, map[string]interface{}{"fake-taint": true}).Return(nil)
+				s.SetConditionTrue(infrav1.NodeLabelsAndTaintsSyncedCondition)
+				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, nil)
+				s.DesiredNodeImageVersion().Return(ptr.To(infrav1.LatestNodeImageVersion))
+				p.GetLatestNodeImageVersion(gomockinternal.AContext(), &fakeAgentPoolSpec).Return("AKSUbuntu-2204gen2containerd-202310.09.0", nil)
+				s.SetNodeImageVersionStatus("AKSUbuntu-2204gen2containerd-202310.04.0", "AKSUbuntu-2204gen2containerd-202310.09.0")
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
+				u.InvokeAsync(gomockinternal.AContext(), &fakeAgentPoolSpec, "").Return(nil, nil)
+				s.DeleteLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture)
+				s.UpdatePatchStatus(infrav1.NodeImageUpgradedCondition, serviceName, nil)
+				s.SetNodeImageVersionStatus("AKSUbuntu-2204gen2containerd-202310.09.0", "AKSUbuntu-2204gen2containerd-202310.09.0")
+			},
+		},
+		{
+			name:          "node image is not upgraded until the desired version is the latest",
+			expectedError: "",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
+				fakeAgentPoolSpec := fakeAgentPool()
+				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAgentPoolSpec, serviceName).Return(sdkFakeAgentPool(sdkWithAutoscaling(false), sdkWithNodeImageVersion("AKSUbuntu-2204gen2containerd-202310.04.0")), nil)
+				s.RemoveCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation)
+				s.UpdateAnnotationJSON(azure.NodeLabelsLastAppliedAnnotation, map[string]interface{}{"fake-label": "fake-value"}).Return(nil)
+				s.UpdateAnnotationJSON(azure.NodeTaintsLastAppliedAnnotation, map[string]interface{}{"fake-taint": true}).Return(nil)
+				s.SetConditionTrue(infrav1.NodeLabelsAndTaintsSyncedCondition)
+				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, nil)
+				s.DesiredNodeImageVersion().Return(ptr.To("AKSUbuntu-2204gen2containerd-202310.16.0"))
+				p.GetLatestNodeImageVersion(gomockinternal.AContext(), &fakeAgentPoolSpec).Return("AKSUbuntu-2204gen2containerd-202310.09.0", nil)
+				s.SetNodeImageVersionStatus("AKSUbuntu-2204gen2containerd-202310.04.0", "AKSUbuntu-2204gen2containerd-202310.09.0")
+				s.SetConditionFalse(infrav1.NodeImageUpgradedCondition, infrav1.NodeImageVersionNotLatestReason, clusterv1.ConditionSeverityInfo,
+					"requested node image version AKSUbuntu-2204gen2containerd-202310.16.0 is not the latest available version AKSUbuntu-2204gen2containerd-202310.09.0")
+			},
+		},
+		{
+			name:          "node image is already at the desired version",
+			expectedError: "",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
+				fakeAgentPoolSpec := fakeAgentPool()
+				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAgentPoolSpec, serviceName).Return(sdkFakeAgentPool(sdkWithAutoscaling(false), sdkWithNodeImageVersion("AKSUbuntu-2204gen2containerd-202310.09.0")), nil)
+				s.RemoveCAPIMachinePoolAnnotation(clusterv1.ReplicasManagedByAnnotation)
+				s.UpdateAnnotationJSON(azure.NodeLabelsLastAppliedAnnotation, map[string]interface{}{"fake-label": "fake-value"}).Return(nil)
+				s.UpdateAnnotationJSON(azure.NodeTaintsLastAppliedAnnotation, map[string]interface{}{"fake-taint": true}).Return(nil)
+				s.SetConditionTrue(infrav1.NodeLabelsAndTaintsSyncedCondition)
+				s.UpdatePutStatus(infrav1.AgentPoolsReadyCondition, serviceName, nil)
+				s.DesiredNodeImageVersion().Return(ptr.To("AKSUbuntu-2204gen2containerd-202310.09.0"))
+				p.GetLatestNodeImageVersion(gomockinternal.AContext(), &fakeAgentPoolSpec).Return("AKSUbuntu-2204gen2containerd-202310.09.0", nil)
+				s.SetNodeImageVersionStatus("AKSUbuntu-2204gen2containerd-202310.09.0", "AKSUbuntu-2204gen2containerd-202310.09.0")
+				s.UpdatePatchStatus(infrav1.NodeImageUpgradedCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to resume an ongoing node image upgrade",
+			expectedError: "failed to invoke action UPGRADENODEIMAGE on resource fake-rg/fake-agent-pool-name (service: agentpools)",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				p *mock_agentpools.MockUpgradeProfileGetterMockRecorder, u *mock_async.MockInvokerMockRecorder[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse]) {
+				fakeAgentPoolSpec := fakeAgentPool()
+				future := &infrav1.Future{
+					Type:          infrav1.UpgradeNodeImageFuture,
+					ServiceName:   serviceName,
+					Name:          fakeAgentPoolSpec.Name,
+					ResourceGroup: fakeAgentPoolSpec.ResourceGroup,
+					Data:          "eyJtZXRob2QiOiJQT1NUIn0=",
+				}
+				s.AgentPoolSpec().Return(&fakeAgentPoolSpec)
+				s.AgentPoolAnnotations().Return(lastAppliedAnnotations)
+				s.AnnotationJSON(azure.NodeLabelsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AnnotationJSON(azure.NodeTaintsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.IsAdoptingAgentPool().Return(false)
+				s.GetLongRunningOperationState(fakeAgentPoolSpec.Name, serviceName, infrav1.UpgradeNodeImageFuture).Return(future).Times(2)
+				u.InvokeAsync(gomockinternal.AContext(), &fakeAgentPoolSpec, `{"method":"POST"}`).Return(nil, internalError)
+				s.UpdatePatchStatus(infrav1.NodeImageUpgradedCondition, serviceName, gomock.Any())
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -92,18 +351,110 @@ func TestReconcileAgentPools(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_agentpools.NewMockAgentPoolScope(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			upgradeProfileMock := mock_agentpools.NewMockUpgradeProfileGetter(mockCtrl)
+			upgraderMock := mock_async.NewMockInvoker[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse](mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), upgradeProfileMock.EXPECT(), upgraderMock.EXPECT())
 
 			s := &Service{
-				scope:      scopeMock,
-				Reconciler: asyncMock,
+				scope:                scopeMock,
+				Reconciler:           asyncMock,
+				UpgradeProfileGetter: upgradeProfileMock,
+				NodeImageUpgrader:    upgraderMock,
+				resourceSKUCache:     resourceskus.NewStaticCache(fakeSKUs, "fake-location"),
 			}
 
 			err := s.Reconcile(context.TODO())
 			if tc.expectedError != "" {
 				g.Expect(err).To(HaveOccurred())
-				g.Expect(err).To(MatchError(tc.expectedError))
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAdoptAgentPool(t *testing.T) {
+	testcases := []struct {
+		name            string
+		reportDriftOnly bool
+		expectedError   string
+		expect          func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, g *mock_async.MockGetterMockRecorder)
+	}{
+		{
+			name:          "agent pool is adopted when the spec matches",
+			expectedError: "",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(sdkFakeAgentPool(sdkWithProvisioningState("Succeeded")), nil)
+				s.SetConditionTrue(infrav1.AdoptedCondition)
+			},
+		},
+		{
+			name:          "agent pool is not updated while the spec differs",
+			expectedError: "agent pool fake-agent-pool-name is pending adoption: the spec does not match the existing agent pool in Properties.ScaleDownMode, which is not updated until it does",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(sdkFakeAgentPool(
+					sdkWithScaleDownMode(armcontainerservice.ScaleDownModeDeallocate),
+					sdkWithProvisioningState("Succeeded"),
+				), nil)
+				s.SetConditionFalse(infrav1.AdoptedCondition, infrav1.AdoptionPendingReason, clusterv1.ConditionSeverityWarning,
+					"the spec does not match the existing agent pool in Properties.ScaleDownMode, which is not updated until it does")
+			},
+		},
+		{
+			name:            "agent pool is pending adoption while the spec differs when drift is only reported",
+			reportDriftOnly: true,
+			expectedError:   "agent pool fake-agent-pool-name is pending adoption: the spec does not match the existing agent pool in Properties.ScaleDownMode, which is not updated until it does",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(sdkFakeAgentPool(
+					sdkWithScaleDownMode(armcontainerservice.ScaleDownModeDeallocate),
+					sdkWithProvisioningState("Succeeded"),
+				), nil)
+				s.SetConditionFalse(infrav1.AdoptedCondition, infrav1.AdoptionPendingReason, clusterv1.ConditionSeverityWarning,
+					"the spec does not match the existing agent pool in Properties.ScaleDownMode, which is not updated until it does")
+			},
+		},
+		{
+			name:          "agent pool which does not exist yet is created as usual",
+			expectedError: "",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound})
+				s.SetConditionTrue(infrav1.AdoptedCondition)
+			},
+		},
+		{
+			name:          "fail to get agent pool to adopt",
+			expectedError: "failed to get agent pool to adopt",
+			expect: func(s *mock_agentpools.MockAgentPoolScopeMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(nil, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_agentpools.NewMockAgentPoolScope(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), getterMock.EXPECT())
+
+			s := &Service{
+				scope:  scopeMock,
+				Getter: getterMock,
+			}
+
+			fakeAgentPoolSpec := fakeAgentPool()
+			fakeAgentPoolSpec.ReportDriftOnly = tc.reportDriftOnly
+			err := s.adoptAgentPool(context.TODO(), &fakeAgentPoolSpec)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
 			} else {
 				g.Expect(err).NotTo(HaveOccurred())
 			}
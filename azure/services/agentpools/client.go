@@ -22,12 +22,18 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// UpgradeProfileGetter is a helper interface for getting the upgrade profile of an agent pool.
+type UpgradeProfileGetter interface {
+	GetLatestNodeImageVersion(ctx context.Context, spec azure.ResourceSpecGetter) (string, error)
+}
+
 // azureClient contains the Azure go-sdk Client.
 type azureClient struct {
 	agentpools *armcontainerservice.AgentPoolsClient
@@ -129,3 +135,48 @@ func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	// if the operation completed, return a nil poller.
 	return nil, err
 }
+
+// GetLatestNodeImageVersion returns the latest node image version available for an agent pool.
+func (ac *azureClient) GetLatestNodeImageVersion(ctx context.Context, spec azure.ResourceSpecGetter) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "agentpools.azureClient.GetLatestNodeImageVersion")
+	defer done()
+
+	resp, err := ac.agentpools.GetUpgradeProfile(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), nil)
+	if err != nil {
+		return "", err
+	}
+	if resp.Properties == nil {
+		return "", nil
+	}
+	return ptr.Deref(resp.Properties.LatestNodeImageVersion, ""), nil
+}
+
+// UpgradeNodeImageVersionAsync upgrades the node image of an agent pool to the latest version asynchronously.
+// UpgradeNodeImageVersionAsync sends a POST request to Azure and if accepted without error, the func will return a Poller
+// which can be used to track the ongoing progress of the operation.
+func (ac *azureClient) UpgradeNodeImageVersionAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (
+	poller *runtime.Poller[armcontainerservice.AgentPoolsClientUpgradeNodeImageVersionResponse], err error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "agentpools.azureClient.UpgradeNodeImageVersionAsync")
+	defer done()
+
+	opts := &armcontainerservice.AgentPoolsClientBeginUpgradeNodeImageVersionOptions{ResumeToken: resumeToken}
+	log.V(4).Info("sending request", "resumeToken", resumeToken)
+	poller, err = ac.agentpools.BeginUpgradeNodeImageVersion(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	_, err = poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// If an error occurs, return the poller.
+		// This means the long-running operation didn't finish in the specified timeout.
+		return poller, err
+	}
+
+	// if the operation completed, return a nil poller.
+	return nil, err
+}
@@ -100,6 +100,21 @@ func (mr *MockAgentPoolScopeMockRecorder) AgentPoolSpec() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AgentPoolSpec", reflect.TypeOf((*MockAgentPoolScope)(nil).AgentPoolSpec))
 }
 
+// AnnotationJSON mocks base method.
+func (m *MockAgentPoolScope) AnnotationJSON(arg0 string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnnotationJSON", arg0)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnnotationJSON indicates an expected call of AnnotationJSON.
+func (mr *MockAgentPoolScopeMockRecorder) AnnotationJSON(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnotationJSON", reflect.TypeOf((*MockAgentPoolScope)(nil).AnnotationJSON), arg0)
+}
+
 // Authorizer mocks base method.
 func (m *MockAgentPoolScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -212,6 +227,18 @@ func (mr *MockAgentPoolScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockAgentPoolScope)(nil).ClusterName))
 }
 
+// DeleteCondition mocks base method.
+func (m *MockAgentPoolScope) DeleteCondition(arg0 v1beta10.ConditionType) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteCondition", arg0)
+}
+
+// DeleteCondition indicates an expected call of DeleteCondition.
+func (mr *MockAgentPoolScopeMockRecorder) DeleteCondition(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCondition", reflect.TypeOf((*MockAgentPoolScope)(nil).DeleteCondition), arg0)
+}
+
 // DeleteLongRunningOperationState mocks base method.
 func (m *MockAgentPoolScope) DeleteLongRunningOperationState(arg0, arg1, arg2 string) {
 	m.ctrl.T.Helper()
@@ -224,6 +251,20 @@ func (mr *MockAgentPoolScopeMockRecorder) DeleteLongRunningOperationState(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockAgentPoolScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DesiredNodeImageVersion mocks base method.
+func (m *MockAgentPoolScope) DesiredNodeImageVersion() *string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DesiredNodeImageVersion")
+	ret0, _ := ret[0].(*string)
+	return ret0
+}
+
+// DesiredNodeImageVersion indicates an expected call of DesiredNodeImageVersion.
+func (mr *MockAgentPoolScopeMockRecorder) DesiredNodeImageVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DesiredNodeImageVersion", reflect.TypeOf((*MockAgentPoolScope)(nil).DesiredNodeImageVersion))
+}
+
 // ExtendedLocation mocks base method.
 func (m *MockAgentPoolScope) ExtendedLocation() *v1beta1.ExtendedLocationSpec {
 	m.ctrl.T.Helper()
@@ -308,6 +349,34 @@ func (mr *MockAgentPoolScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockAgentPoolScope)(nil).HashKey))
 }
 
+// IsAdoptingAgentPool mocks base method.
+func (m *MockAgentPoolScope) IsAdoptingAgentPool() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdoptingAgentPool")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAdoptingAgentPool indicates an expected call of IsAdoptingAgentPool.
+func (mr *MockAgentPoolScopeMockRecorder) IsAdoptingAgentPool() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdoptingAgentPool", reflect.TypeOf((*MockAgentPoolScope)(nil).IsAdoptingAgentPool))
+}
+
+// IsManagedClusterStopped mocks base method.
+func (m *MockAgentPoolScope) IsManagedClusterStopped() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsManagedClusterStopped")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsManagedClusterStopped indicates an expected call of IsManagedClusterStopped.
+func (mr *MockAgentPoolScopeMockRecorder) IsManagedClusterStopped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsManagedClusterStopped", reflect.TypeOf((*MockAgentPoolScope)(nil).IsManagedClusterStopped))
+}
+
 // Location mocks base method.
 func (m *MockAgentPoolScope) Location() string {
 	m.ctrl.T.Helper()
@@ -436,6 +505,42 @@ func (mr *MockAgentPoolScopeMockRecorder) SetCAPIMachinePoolReplicas(replicas an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCAPIMachinePoolReplicas", reflect.TypeOf((*MockAgentPoolScope)(nil).SetCAPIMachinePoolReplicas), replicas)
 }
 
+// SetConditionFalse mocks base method.
+func (m *MockAgentPoolScope) SetConditionFalse(arg0 v1beta10.ConditionType, arg1 string, arg2 v1beta10.ConditionSeverity, arg3 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetConditionFalse", arg0, arg1, arg2, arg3)
+}
+
+// SetConditionFalse indicates an expected call of SetConditionFalse.
+func (mr *MockAgentPoolScopeMockRecorder) SetConditionFalse(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConditionFalse", reflect.TypeOf((*MockAgentPoolScope)(nil).SetConditionFalse), arg0, arg1, arg2, arg3)
+}
+
+// SetConditionTrue mocks base method.
+func (m *MockAgentPoolScope) SetConditionTrue(arg0 v1beta10.ConditionType) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetConditionTrue", arg0)
+}
+
+// SetConditionTrue indicates an expected call of SetConditionTrue.
+func (mr *MockAgentPoolScopeMockRecorder) SetConditionTrue(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConditionTrue", reflect.TypeOf((*MockAgentPoolScope)(nil).SetConditionTrue), arg0)
+}
+
+// SetDriftDetected mocks base method.
+func (m *MockAgentPoolScope) SetDriftDetected(arg0 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDriftDetected", arg0)
+}
+
+// SetDriftDetected indicates an expected call of SetDriftDetected.
+func (mr *MockAgentPoolScopeMockRecorder) SetDriftDetected(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDriftDetected", reflect.TypeOf((*MockAgentPoolScope)(nil).SetDriftDetected), arg0)
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockAgentPoolScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -448,6 +553,18 @@ func (mr *MockAgentPoolScopeMockRecorder) SetLongRunningOperationState(arg0 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockAgentPoolScope)(nil).SetLongRunningOperationState), arg0)
 }
 
+// SetNodeImageVersionStatus mocks base method.
+func (m *MockAgentPoolScope) SetNodeImageVersionStatus(current, latest string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetNodeImageVersionStatus", current, latest)
+}
+
+// SetNodeImageVersionStatus indicates an expected call of SetNodeImageVersionStatus.
+func (mr *MockAgentPoolScopeMockRecorder) SetNodeImageVersionStatus(current, latest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNodeImageVersionStatus", reflect.TypeOf((*MockAgentPoolScope)(nil).SetNodeImageVersionStatus), current, latest)
+}
+
 // SetSubnetName mocks base method.
 func (m *MockAgentPoolScope) SetSubnetName() {
 	m.ctrl.T.Helper()
@@ -502,6 +619,20 @@ func (mr *MockAgentPoolScopeMockRecorder) Token() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockAgentPoolScope)(nil).Token))
 }
 
+// UpdateAnnotationJSON mocks base method.
+func (m *MockAgentPoolScope) UpdateAnnotationJSON(arg0 string, arg1 map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnnotationJSON", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnnotationJSON indicates an expected call of UpdateAnnotationJSON.
+func (mr *MockAgentPoolScopeMockRecorder) UpdateAnnotationJSON(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnnotationJSON", reflect.TypeOf((*MockAgentPoolScope)(nil).UpdateAnnotationJSON), arg0, arg1)
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockAgentPoolScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
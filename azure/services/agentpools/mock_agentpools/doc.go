@@ -15,7 +15,9 @@ limitations under the License.
 */
 
 // Run go generate to regenerate this mock.
+//go:generate ../../../../hack/tools/bin/mockgen -destination client_mock.go -package mock_agentpools -source ../client.go UpgradeProfileGetter
 //go:generate ../../../../hack/tools/bin/mockgen -destination agentpools_mock.go -package mock_agentpools -source ../agentpools.go AgentPoolScope
+//go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt client_mock.go > _client_mock.go && mv _client_mock.go client_mock.go"
 //go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt agentpools_mock.go > _agentpools_mock.go && mv _agentpools_mock.go agentpools_mock.go"
 
 package mock_agentpools
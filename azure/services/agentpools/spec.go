@@ -19,20 +19,26 @@ package agentpools
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/diff"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// skipGPUDriverInstallHeader is the AKS custom header which skips the installation of the GPU drivers on an agent pool.
+const skipGPUDriverInstallHeader = "SkipGPUDriverInstall"
+
 // KubeletConfig defines the set of kubelet configurations for nodes in pools.
 type KubeletConfig struct {
 	// CPUManagerPolicy - CPU Manager policy to use.
@@ -100,6 +106,31 @@ type AgentPoolSpec struct {
 	// NodeTaints specifies the taints for nodes present in this agent pool.
 	NodeTaints []string `json:"nodeTaints,omitempty"`
 
+	// LastAppliedNodeLabels are the node labels CAPZ previously set on the agent pool.
+	// Labels of the agent pool which are not in this set were not added by CAPZ and are preserved.
+	// When nil, the agent pool was reconciled before CAPZ recorded the labels it set, back when all of the labels of the
+	// agent pool came from the spec, so the existing labels are used instead.
+	LastAppliedNodeLabels map[string]interface{}
+
+	// LastAppliedNodeTaints are the node taints CAPZ previously set on the agent pool.
+	// Taints of the agent pool which are not in this set were not added by CAPZ and are preserved.
+	// When nil, the existing taints are used instead, as for LastAppliedNodeLabels.
+	LastAppliedNodeTaints map[string]interface{}
+
+	// nodeLabelsAndTaintsDrift lists the node labels and taints previously set by CAPZ which were changed outside of
+	// CAPZ, as found by Parameters.
+	nodeLabelsAndTaintsDrift []string
+
+	// ReportDriftOnly leaves an existing agent pool which differs from the spec as it is.
+	ReportDriftOnly bool
+
+	// UpgradesKubernetesVersion is true if the cluster's auto-upgrade channel upgrades the Kubernetes version, in which
+	// case an existing agent pool running a newer version than the spec is left on that version.
+	UpgradesKubernetesVersion bool
+
+	// drift is the fields of the existing agent pool which differ from the spec, as found by Parameters.
+	drift []string
+
 	// EnableAutoScaling - Whether to enable auto-scaler
 	EnableAutoScaling bool `json:"enableAutoScaling,omitempty"`
 
@@ -118,6 +149,9 @@ type AgentPoolSpec struct {
 	// OSType specifies the operating system for the node pool. Allowed values are 'Linux' and 'Windows'
 	OSType *string `json:"osType,omitempty"`
 
+	// OSSKU specifies the OS SKU used by the node pool. Allowed values are 'Ubuntu', 'AzureLinux', 'CBLMariner', 'Windows2019' and 'Windows2022'
+	OSSKU *string `json:"osSKU,omitempty"`
+
 	// Headers is the list of headers to add to the HTTP requests to update this resource.
 	Headers map[string]string
 
@@ -150,6 +184,27 @@ type AgentPoolSpec struct {
 
 	// EnableFIPS indicates whether FIPS is enabled on the node pool
 	EnableFIPS *bool
+
+	// PodSubnetID is the ID of the subnet from which pod IPs are dynamically allocated
+	PodSubnetID *string
+
+	// ProximityPlacementGroupID is the ID of the proximity placement group of the nodes
+	ProximityPlacementGroupID *string
+
+	// HostGroupID is the ID of the dedicated host group of the nodes
+	HostGroupID *string
+
+	// CapacityReservationGroupID is the ID of the capacity reservation group of the nodes
+	CapacityReservationGroupID *string
+
+	// GPUInstanceProfile is the Multi-Instance GPU profile of the nodes
+	GPUInstanceProfile *infrav1.GPUInstanceProfile
+
+	// SkipGPUDriverInstall skips the installation of the GPU drivers by AKS
+	SkipGPUDriverInstall *bool
+
+	// UpgradeSettings specifies how the nodes are surged and drained during upgrades
+	UpgradeSettings *infrav1.ManagedMachinePoolUpgradeSettings
 }
 
 // ResourceName returns the name of the agent pool.
@@ -169,7 +224,16 @@ func (s *AgentPoolSpec) OwnerResourceName() string {
 
 // CustomHeaders returns custom headers to be added to the Azure API calls.
 func (s *AgentPoolSpec) CustomHeaders() map[string]string {
-	return s.Headers
+	if !ptr.Deref(s.SkipGPUDriverInstall, false) {
+		return s.Headers
+	}
+	// Skipping the GPU driver installation is only exposed through a custom header by the AKS API.
+	headers := make(map[string]string, len(s.Headers)+1)
+	for k, v := range s.Headers {
+		headers[k] = v
+	}
+	headers[skipGPUDriverInstallHeader] = "true"
+	return headers
 }
 
 // Parameters returns the parameters for the agent pool.
@@ -178,6 +242,8 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 	defer done()
 
 	nodeLabels := s.NodeLabels
+	nodeTaints := azure.PtrSlice(&s.NodeTaints)
+	version := s.Version
 	if existing != nil {
 		existingPool, ok := existing.(armcontainerservice.AgentPool)
 		if !ok {
@@ -191,6 +257,14 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 			return nil, azure.WithTransientError(errors.New(msg), 20*time.Second)
 		}
 
+		// AKS auto-upgrades may have moved the agent pool past the version in the spec. Agent pools
+		// cannot be downgraded, so keep the current version instead of fighting the upgrade. Without
+		// auto-upgrades the newer version is left in the diff and reported as drift.
+		if s.UpgradesKubernetesVersion && version != nil && existingPool.Properties.OrchestratorVersion != nil &&
+			semver.Compare("v"+*existingPool.Properties.OrchestratorVersion, "v"+*version) > 0 {
+			version = existingPool.Properties.OrchestratorVersion
+		}
+
 		// Normalize individual agent pools to diff in case we need to update
 		existingProfile := armcontainerservice.AgentPool{
 			Properties: &armcontainerservice.ManagedClusterAgentPoolProfileProperties{
@@ -201,7 +275,7 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 				MinCount:            existingPool.Properties.MinCount,
 				MaxCount:            existingPool.Properties.MaxCount,
 				NodeLabels:          existingPool.Properties.NodeLabels,
-				NodeTaints:          existingPool.Properties.NodeTaints,
+				NodeTaints:          nilIfEmpty(existingPool.Properties.NodeTaints),
 				Tags:                existingPool.Properties.Tags,
 				ScaleDownMode:       existingPool.Properties.ScaleDownMode,
 				SpotMaxPrice:        existingPool.Properties.SpotMaxPrice,
@@ -212,25 +286,38 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 		normalizedProfile := armcontainerservice.AgentPool{
 			Properties: &armcontainerservice.ManagedClusterAgentPoolProfileProperties{
 				Count:               &s.Replicas,
-				OrchestratorVersion: s.Version,
+				OrchestratorVersion: version,
 				Mode:                azure.AliasOrNil[armcontainerservice.AgentPoolMode](&s.Mode),
 				EnableAutoScaling:   ptr.To(s.EnableAutoScaling),
 				MinCount:            s.MinCount,
 				MaxCount:            s.MaxCount,
 				NodeLabels:          s.NodeLabels,
-				NodeTaints:          azure.PtrSlice(&s.NodeTaints),
+				NodeTaints:          nodeTaints,
 				ScaleDownMode:       azure.AliasOrNil[armcontainerservice.ScaleDownMode](s.ScaleDownMode),
 				Tags:                converters.TagsToMap(s.AdditionalTags),
 			},
 		}
-		if len(normalizedProfile.Properties.NodeTaints) == 0 {
-			normalizedProfile.Properties.NodeTaints = nil
-		}
 
 		if s.SpotMaxPrice != nil {
 			normalizedProfile.Properties.SpotMaxPrice = ptr.To[float32](float32(s.SpotMaxPrice.AsApproximateFloat64()))
 		}
 
+		// Only compare the upgrade settings set in the spec, as AKS fills in defaults for the others.
+		if desired := upgradeSettings(s.UpgradeSettings); desired != nil {
+			existing := ptr.Deref(existingPool.Properties.UpgradeSettings, armcontainerservice.AgentPoolUpgradeSettings{})
+			normalizedProfile.Properties.UpgradeSettings = desired
+			existingProfile.Properties.UpgradeSettings = &armcontainerservice.AgentPoolUpgradeSettings{}
+			if desired.MaxSurge != nil {
+				existingProfile.Properties.UpgradeSettings.MaxSurge = existing.MaxSurge
+			}
+			if desired.DrainTimeoutInMinutes != nil {
+				existingProfile.Properties.UpgradeSettings.DrainTimeoutInMinutes = existing.DrainTimeoutInMinutes
+			}
+			if desired.NodeSoakDurationInMinutes != nil {
+				existingProfile.Properties.UpgradeSettings.NodeSoakDurationInMinutes = existing.NodeSoakDurationInMinutes
+			}
+		}
+
 		if s.KubeletConfig != nil {
 			normalizedProfile.Properties.KubeletConfig = &armcontainerservice.KubeletConfig{
 				CPUManagerPolicy:      s.KubeletConfig.CPUManagerPolicy,
@@ -254,24 +341,39 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 			normalizedProfile.Properties.Count = existingProfile.Properties.Count
 		}
 
-		// We do a just-in-time merge of existent kubernetes.azure.com-prefixed labels
-		// So that we don't unintentionally delete them
-		// See https://github.com/Azure/AKS/issues/3152
-		nodeLabels = mergeSystemNodeLabels(normalizedProfile.Properties.NodeLabels, existingPool.Properties.NodeLabels)
+		// Only remove the labels and taints CAPZ set before, so that the ones added by AKS or directly on the agent pool
+		// are neither clobbered nor cause endless diffs.
+		// System labels prefixed with kubernetes.azure.com are always preserved, see https://github.com/Azure/AKS/issues/3152
+		lastAppliedNodeLabels, lastAppliedNodeTaints := s.LastAppliedNodeLabels, s.LastAppliedNodeTaints
+		if lastAppliedNodeLabels == nil {
+			lastAppliedNodeLabels = nodeLabelsAnnotation(existingPool.Properties.NodeLabels)
+		}
+		if lastAppliedNodeTaints == nil {
+			lastAppliedNodeTaints = nodeTaintsAnnotation(existingPool.Properties.NodeTaints)
+		}
+		s.nodeLabelsAndTaintsDrift = nodeLabelsAndTaintsDrift(s.NodeLabels, s.NodeTaints, existingPool.Properties.NodeLabels,
+			existingPool.Properties.NodeTaints, lastAppliedNodeLabels, lastAppliedNodeTaints)
+		nodeLabels = mergeNodeLabels(s.NodeLabels, existingPool.Properties.NodeLabels, lastAppliedNodeLabels)
 		normalizedProfile.Properties.NodeLabels = nodeLabels
+		nodeTaints = mergeNodeTaints(s.NodeTaints, existingPool.Properties.NodeTaints, lastAppliedNodeTaints)
+		normalizedProfile.Properties.NodeTaints = nilIfEmpty(nodeTaints)
 
 		// Compute a diff to check if we require an update
-		diff := cmp.Diff(normalizedProfile, existingProfile)
-		if diff == "" {
+		s.drift = diff.Fields(normalizedProfile, existingProfile)
+		difference := cmp.Diff(normalizedProfile, existingProfile)
+		if difference == "" {
 			// agent pool is up to date, nothing to do
 			log.V(4).Info("no changes found between user-updated spec and existing spec")
 			return nil, nil
 		}
-		log.V(4).Info("found a diff between the desired spec and the existing agentpool", "difference", diff)
+		log.V(4).Info("found a diff between the desired spec and the existing agentpool", "difference", difference)
+		if s.ReportDriftOnly {
+			log.V(4).Info("drift is only reported, skipping update", "fields", s.drift)
+			return nil, nil
+		}
 	}
 
 	availabilityZones := azure.PtrSlice(&s.AvailabilityZones)
-	nodeTaints := azure.PtrSlice(&s.NodeTaints)
 	var sku *string
 	if s.SKU != "" {
 		sku = &s.SKU
@@ -350,55 +452,176 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 
 	agentPool := armcontainerservice.AgentPool{
 		Properties: &armcontainerservice.ManagedClusterAgentPoolProfileProperties{
-			AvailabilityZones:    availabilityZones,
-			Count:                &s.Replicas,
-			EnableAutoScaling:    ptr.To(s.EnableAutoScaling),
-			EnableUltraSSD:       s.EnableUltraSSD,
-			KubeletConfig:        kubeletConfig,
-			KubeletDiskType:      azure.AliasOrNil[armcontainerservice.KubeletDiskType]((*string)(s.KubeletDiskType)),
-			MaxCount:             s.MaxCount,
-			MaxPods:              s.MaxPods,
-			MinCount:             s.MinCount,
-			Mode:                 ptr.To(armcontainerservice.AgentPoolMode(s.Mode)),
-			NodeLabels:           nodeLabels,
-			NodeTaints:           nodeTaints,
-			OrchestratorVersion:  s.Version,
-			OSDiskSizeGB:         &s.OSDiskSizeGB,
-			OSDiskType:           azure.AliasOrNil[armcontainerservice.OSDiskType](s.OsDiskType),
-			OSType:               azure.AliasOrNil[armcontainerservice.OSType](s.OSType),
-			ScaleSetPriority:     azure.AliasOrNil[armcontainerservice.ScaleSetPriority](s.ScaleSetPriority),
-			ScaleDownMode:        azure.AliasOrNil[armcontainerservice.ScaleDownMode](s.ScaleDownMode),
-			SpotMaxPrice:         spotMaxPrice,
-			Type:                 ptr.To(armcontainerservice.AgentPoolTypeVirtualMachineScaleSets),
-			VMSize:               sku,
-			VnetSubnetID:         vnetSubnetID,
-			EnableNodePublicIP:   s.EnableNodePublicIP,
-			NodePublicIPPrefixID: s.NodePublicIPPrefixID,
-			Tags:                 tags,
-			EnableFIPS:           s.EnableFIPS,
-			LinuxOSConfig:        linuxOSConfig,
+			AvailabilityZones:          availabilityZones,
+			Count:                      &s.Replicas,
+			EnableAutoScaling:          ptr.To(s.EnableAutoScaling),
+			EnableUltraSSD:             s.EnableUltraSSD,
+			KubeletConfig:              kubeletConfig,
+			KubeletDiskType:            azure.AliasOrNil[armcontainerservice.KubeletDiskType]((*string)(s.KubeletDiskType)),
+			MaxCount:                   s.MaxCount,
+			MaxPods:                    s.MaxPods,
+			MinCount:                   s.MinCount,
+			Mode:                       ptr.To(armcontainerservice.AgentPoolMode(s.Mode)),
+			NodeLabels:                 nodeLabels,
+			NodeTaints:                 nodeTaints,
+			OrchestratorVersion:        version,
+			OSDiskSizeGB:               &s.OSDiskSizeGB,
+			OSDiskType:                 azure.AliasOrNil[armcontainerservice.OSDiskType](s.OsDiskType),
+			OSType:                     azure.AliasOrNil[armcontainerservice.OSType](s.OSType),
+			OSSKU:                      azure.AliasOrNil[armcontainerservice.OSSKU](s.OSSKU),
+			ScaleSetPriority:           azure.AliasOrNil[armcontainerservice.ScaleSetPriority](s.ScaleSetPriority),
+			ScaleDownMode:              azure.AliasOrNil[armcontainerservice.ScaleDownMode](s.ScaleDownMode),
+			SpotMaxPrice:               spotMaxPrice,
+			Type:                       ptr.To(armcontainerservice.AgentPoolTypeVirtualMachineScaleSets),
+			VMSize:                     sku,
+			VnetSubnetID:               vnetSubnetID,
+			EnableNodePublicIP:         s.EnableNodePublicIP,
+			NodePublicIPPrefixID:       s.NodePublicIPPrefixID,
+			Tags:                       tags,
+			EnableFIPS:                 s.EnableFIPS,
+			PodSubnetID:                s.PodSubnetID,
+			ProximityPlacementGroupID:  s.ProximityPlacementGroupID,
+			HostGroupID:                s.HostGroupID,
+			CapacityReservationGroupID: s.CapacityReservationGroupID,
+			GpuInstanceProfile:         azure.AliasOrNil[armcontainerservice.GPUInstanceProfile]((*string)(s.GPUInstanceProfile)),
+			LinuxOSConfig:              linuxOSConfig,
+			UpgradeSettings:            upgradeSettings(s.UpgradeSettings),
 		},
 	}
 
 	return agentPool, nil
 }
 
-// mergeSystemNodeLabels appends any kubernetes.azure.com-prefixed labels from the AKS label set
-// into the local capz label set.
-func mergeSystemNodeLabels(capz, aks map[string]*string) map[string]*string {
-	ret := capz
-	if ret == nil {
-		ret = make(map[string]*string)
+// upgradeSettings converts the upgrade settings of an AzureManagedMachinePool to the ones of an agent pool.
+func upgradeSettings(settings *infrav1.ManagedMachinePoolUpgradeSettings) *armcontainerservice.AgentPoolUpgradeSettings {
+	if settings == nil {
+		return nil
+	}
+	ret := &armcontainerservice.AgentPoolUpgradeSettings{
+		DrainTimeoutInMinutes:     settings.DrainTimeoutInMinutes,
+		NodeSoakDurationInMinutes: settings.NodeSoakDurationInMinutes,
+	}
+	if settings.MaxSurge != nil {
+		ret.MaxSurge = ptr.To(settings.MaxSurge.String())
+	}
+	return ret
+}
+
+// NodeLabelsAndTaintsDrift returns the node labels and taints previously set by CAPZ which were found changed outside of
+// CAPZ when computing the parameters of the agent pool.
+func (s *AgentPoolSpec) NodeLabelsAndTaintsDrift() []string {
+	return s.nodeLabelsAndTaintsDrift
+}
+
+// Drift returns the fields of the existing agent pool which differ from the spec. It is nil until Parameters compares
+// the spec to an existing agent pool.
+func (s *AgentPoolSpec) Drift() []string {
+	return s.drift
+}
+
+// mergeNodeLabels returns the labels set by CAPZ along with the labels of the existing agent pool which CAPZ did not
+// set before. The kubernetes.azure.com-prefixed labels managed by AKS are always kept.
+func mergeNodeLabels(capz, aks map[string]*string, lastApplied map[string]interface{}) map[string]*string {
+	ret := make(map[string]*string, len(capz))
+	for k, v := range capz {
+		ret[k] = v
 	}
-	// Look for labels returned from the AKS node pool API that begin with kubernetes.azure.com
-	for aksNodeLabelKey := range aks {
-		if azureutil.IsAzureSystemNodeLabelKey(aksNodeLabelKey) {
-			ret[aksNodeLabelKey] = aks[aksNodeLabelKey]
+	for k, v := range aks {
+		if _, ok := capz[k]; ok {
+			continue
+		}
+		if _, ok := lastApplied[k]; !ok || azureutil.IsAzureSystemNodeLabelKey(k) {
+			ret[k] = v
 		}
 	}
-	// Preserve nil-ness of capz
-	if capz == nil && len(ret) == 0 {
+	// Preserve nil-ness of capz unless labels need to be removed from the agent pool.
+	if capz == nil && len(ret) == 0 && len(aks) == 0 {
 		ret = nil
 	}
 	return ret
 }
+
+// mergeNodeTaints returns the taints set by CAPZ along with the taints of the existing agent pool which CAPZ did not
+// set before. The order of the existing taints is kept.
+func mergeNodeTaints(capz []string, aks []*string, lastApplied map[string]interface{}) []*string {
+	desired := make(map[string]bool, len(capz))
+	for _, taint := range capz {
+		desired[taint] = true
+	}
+	ret := make([]*string, 0, len(capz)+len(aks))
+	existing := make(map[string]bool, len(aks))
+	for _, taint := range aks {
+		if taint == nil {
+			continue
+		}
+		existing[*taint] = true
+		if _, ok := lastApplied[*taint]; !ok || desired[*taint] {
+			ret = append(ret, taint)
+		}
+	}
+	for _, taint := range capz {
+		if !existing[taint] {
+			existing[taint] = true
+			ret = append(ret, ptr.To(taint))
+		}
+	}
+	// An empty slice is only sent to AKS when taints need to be removed from the agent pool.
+	if len(ret) == 0 && len(aks) == 0 {
+		return nil
+	}
+	return ret
+}
+
+// nodeLabelsAndTaintsDrift returns the labels and taints which CAPZ set before and still wants on the agent pool, but
+// which were changed or removed outside of CAPZ.
+func nodeLabelsAndTaintsDrift(capzLabels map[string]*string, capzTaints []string, aksLabels map[string]*string, aksTaints []*string,
+	lastAppliedLabels, lastAppliedTaints map[string]interface{}) []string {
+	var drift []string
+	for k, v := range capzLabels {
+		if lastApplied, ok := lastAppliedLabels[k]; !ok || lastApplied != ptr.Deref(v, "") {
+			continue
+		}
+		if aksValue, ok := aksLabels[k]; !ok || ptr.Deref(aksValue, "") != ptr.Deref(v, "") {
+			drift = append(drift, fmt.Sprintf("label %s", k))
+		}
+	}
+	existing := make(map[string]bool, len(aksTaints))
+	for _, taint := range aksTaints {
+		existing[ptr.Deref(taint, "")] = true
+	}
+	for _, taint := range capzTaints {
+		if _, ok := lastAppliedTaints[taint]; ok && !existing[taint] {
+			drift = append(drift, fmt.Sprintf("taint %s", taint))
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
+
+// nodeLabelsAnnotation returns the node labels in the format of the last applied node labels annotation.
+func nodeLabelsAnnotation(labels map[string]*string) map[string]interface{} {
+	ret := make(map[string]interface{}, len(labels))
+	for k, v := range labels {
+		ret[k] = ptr.Deref(v, "")
+	}
+	return ret
+}
+
+// nodeTaintsAnnotation returns the node taints in the format of the last applied node taints annotation.
+func nodeTaintsAnnotation(taints []*string) map[string]interface{} {
+	ret := make(map[string]interface{}, len(taints))
+	for _, taint := range taints {
+		if taint != nil {
+			ret[*taint] = true
+		}
+	}
+	return ret
+}
+
+// nilIfEmpty returns nil for an empty slice, so that empty and unset slices compare as equal.
+func nilIfEmpty[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
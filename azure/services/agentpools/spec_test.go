@@ -27,6 +27,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -34,28 +35,34 @@ import (
 
 func fakeAgentPool(changes ...func(*AgentPoolSpec)) AgentPoolSpec {
 	pool := AgentPoolSpec{
-		Name:              "fake-agent-pool-name",
-		ResourceGroup:     "fake-rg",
-		Cluster:           "fake-cluster",
-		AvailabilityZones: []string{"fake-zone"},
-		EnableAutoScaling: true,
-		EnableUltraSSD:    ptr.To(true),
-		KubeletDiskType:   (*infrav1.KubeletDiskType)(ptr.To("fake-kubelet-disk-type")),
-		MaxCount:          ptr.To[int32](5),
-		MaxPods:           ptr.To[int32](10),
-		MinCount:          ptr.To[int32](1),
-		Mode:              "fake-mode",
-		NodeLabels:        map[string]*string{"fake-label": ptr.To("fake-value")},
-		NodeTaints:        []string{"fake-taint"},
-		OSDiskSizeGB:      2,
-		OsDiskType:        ptr.To("fake-os-disk-type"),
-		OSType:            ptr.To("fake-os-type"),
-		Replicas:          1,
-		SKU:               "fake-sku",
-		Version:           ptr.To("fake-version"),
-		VnetSubnetID:      "fake-vnet-subnet-id",
-		Headers:           map[string]string{"fake-header": "fake-value"},
-		AdditionalTags:    infrav1.Tags{"fake": "tag"},
+		Name:                       "fake-agent-pool-name",
+		ResourceGroup:              "fake-rg",
+		Cluster:                    "fake-cluster",
+		AvailabilityZones:          []string{"fake-zone"},
+		EnableAutoScaling:          true,
+		EnableUltraSSD:             ptr.To(true),
+		KubeletDiskType:            (*infrav1.KubeletDiskType)(ptr.To("fake-kubelet-disk-type")),
+		MaxCount:                   ptr.To[int32](5),
+		MaxPods:                    ptr.To[int32](10),
+		MinCount:                   ptr.To[int32](1),
+		Mode:                       "fake-mode",
+		NodeLabels:                 map[string]*string{"fake-label": ptr.To("fake-value")},
+		NodeTaints:                 []string{"fake-taint"},
+		OSDiskSizeGB:               2,
+		OsDiskType:                 ptr.To("fake-os-disk-type"),
+		OSType:                     ptr.To("fake-os-type"),
+		OSSKU:                      ptr.To("fake-os-sku"),
+		PodSubnetID:                ptr.To("fake-pod-subnet-id"),
+		ProximityPlacementGroupID:  ptr.To("fake-ppg-id"),
+		HostGroupID:                ptr.To("fake-host-group-id"),
+		CapacityReservationGroupID: ptr.To("fake-crg-id"),
+		GPUInstanceProfile:         (*infrav1.GPUInstanceProfile)(ptr.To("fake-gpu-instance-profile")),
+		Replicas:                   1,
+		SKU:                        "fake-sku",
+		Version:                    ptr.To("fake-version"),
+		VnetSubnetID:               "fake-vnet-subnet-id",
+		Headers:                    map[string]string{"fake-header": "fake-value"},
+		AdditionalTags:             infrav1.Tags{"fake": "tag"},
 	}
 
 	for _, change := range changes {
@@ -77,6 +84,12 @@ func withAutoscaling(enabled bool) func(*AgentPoolSpec) {
 	}
 }
 
+func withSKU(sku string) func(*AgentPoolSpec) {
+	return func(pool *AgentPoolSpec) {
+		pool.SKU = sku
+	}
+}
+
 func withSpotMaxPrice(spotMaxPrice string) func(*AgentPoolSpec) {
 	quantity := resource.MustParse(spotMaxPrice)
 	return func(pool *AgentPoolSpec) {
@@ -86,25 +99,31 @@ func withSpotMaxPrice(spotMaxPrice string) func(*AgentPoolSpec) {
 func sdkFakeAgentPool(changes ...func(*armcontainerservice.AgentPool)) armcontainerservice.AgentPool {
 	pool := armcontainerservice.AgentPool{
 		Properties: &armcontainerservice.ManagedClusterAgentPoolProfileProperties{
-			AvailabilityZones:   []*string{ptr.To("fake-zone")},
-			Count:               ptr.To[int32](1), // updates if changed
-			EnableAutoScaling:   ptr.To(true),     // updates if changed
-			EnableUltraSSD:      ptr.To(true),
-			KubeletDiskType:     ptr.To(armcontainerservice.KubeletDiskType("fake-kubelet-disk-type")),
-			MaxCount:            ptr.To[int32](5), // updates if changed
-			MaxPods:             ptr.To[int32](10),
-			MinCount:            ptr.To[int32](1),                                       // updates if changed
-			Mode:                ptr.To(armcontainerservice.AgentPoolMode("fake-mode")), // updates if changed
-			NodeLabels:          map[string]*string{"fake-label": ptr.To("fake-value")}, // updates if changed
-			NodeTaints:          []*string{ptr.To("fake-taint")},                        // updates if changed
-			OrchestratorVersion: ptr.To("fake-version"),                                 // updates if changed
-			OSDiskSizeGB:        ptr.To[int32](2),
-			OSDiskType:          ptr.To(armcontainerservice.OSDiskType("fake-os-disk-type")),
-			OSType:              ptr.To(armcontainerservice.OSType("fake-os-type")),
-			Tags:                map[string]*string{"fake": ptr.To("tag")},
-			Type:                ptr.To(armcontainerservice.AgentPoolTypeVirtualMachineScaleSets),
-			VMSize:              ptr.To("fake-sku"),
-			VnetSubnetID:        ptr.To("fake-vnet-subnet-id"),
+			AvailabilityZones:          []*string{ptr.To("fake-zone")},
+			Count:                      ptr.To[int32](1), // updates if changed
+			EnableAutoScaling:          ptr.To(true),     // updates if changed
+			EnableUltraSSD:             ptr.To(true),
+			KubeletDiskType:            ptr.To(armcontainerservice.KubeletDiskType("fake-kubelet-disk-type")),
+			MaxCount:                   ptr.To[int32](5), // updates if changed
+			MaxPods:                    ptr.To[int32](10),
+			MinCount:                   ptr.To[int32](1),                                       // updates if changed
+			Mode:                       ptr.To(armcontainerservice.AgentPoolMode("fake-mode")), // updates if changed
+			NodeLabels:                 map[string]*string{"fake-label": ptr.To("fake-value")}, // updates if changed
+			NodeTaints:                 []*string{ptr.To("fake-taint")},                        // updates if changed
+			OrchestratorVersion:        ptr.To("fake-version"),                                 // updates if changed
+			OSDiskSizeGB:               ptr.To[int32](2),
+			OSDiskType:                 ptr.To(armcontainerservice.OSDiskType("fake-os-disk-type")),
+			OSType:                     ptr.To(armcontainerservice.OSType("fake-os-type")),
+			OSSKU:                      ptr.To(armcontainerservice.OSSKU("fake-os-sku")),
+			PodSubnetID:                ptr.To("fake-pod-subnet-id"),
+			ProximityPlacementGroupID:  ptr.To("fake-ppg-id"),
+			HostGroupID:                ptr.To("fake-host-group-id"),
+			CapacityReservationGroupID: ptr.To("fake-crg-id"),
+			GpuInstanceProfile:         ptr.To(armcontainerservice.GPUInstanceProfile("fake-gpu-instance-profile")),
+			Tags:                       map[string]*string{"fake": ptr.To("tag")},
+			Type:                       ptr.To(armcontainerservice.AgentPoolTypeVirtualMachineScaleSets),
+			VMSize:                     ptr.To("fake-sku"),
+			VnetSubnetID:               ptr.To("fake-vnet-subnet-id"),
 		},
 	}
 
@@ -127,6 +146,36 @@ func sdkWithCount(count int32) func(*armcontainerservice.AgentPool) {
 	}
 }
 
+func sdkWithNodeLabels(labels map[string]*string) func(*armcontainerservice.AgentPool) {
+	return func(pool *armcontainerservice.AgentPool) {
+		pool.Properties.NodeLabels = labels
+	}
+}
+
+func sdkWithNodeTaints(taints []*string) func(*armcontainerservice.AgentPool) {
+	return func(pool *armcontainerservice.AgentPool) {
+		pool.Properties.NodeTaints = taints
+	}
+}
+
+func sdkWithNodeImageVersion(version string) func(*armcontainerservice.AgentPool) {
+	return func(pool *armcontainerservice.AgentPool) {
+		pool.Properties.NodeImageVersion = ptr.To(version)
+	}
+}
+
+func withVersion(version string) func(*AgentPoolSpec) {
+	return func(pool *AgentPoolSpec) {
+		pool.Version = ptr.To(version)
+	}
+}
+
+func sdkWithOrchestratorVersion(version string) func(*armcontainerservice.AgentPool) {
+	return func(pool *armcontainerservice.AgentPool) {
+		pool.Properties.OrchestratorVersion = ptr.To(version)
+	}
+}
+
 func sdkWithProvisioningState(state string) func(*armcontainerservice.AgentPool) {
 	return func(pool *armcontainerservice.AgentPool) {
 		pool.Properties.ProvisioningState = ptr.To(state)
@@ -149,6 +198,12 @@ func sdkWithSpotMaxPrice(spotMaxPrice float32) func(*armcontainerservice.AgentPo
 	}
 }
 
+func sdkWithUpgradeSettings(settings *armcontainerservice.AgentPoolUpgradeSettings) func(*armcontainerservice.AgentPool) {
+	return func(pool *armcontainerservice.AgentPool) {
+		pool.Properties.UpgradeSettings = settings
+	}
+}
+
 func TestParameters(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -238,6 +293,36 @@ func TestParameters(t *testing.T) {
 			expected:      sdkFakeAgentPool(),
 			expectedError: nil,
 		},
+		{
+			name: "parameters with an existing agent pool and update needed on version",
+			spec: fakeAgentPool(withVersion("1.27.3")),
+			existing: sdkFakeAgentPool(
+				sdkWithOrchestratorVersion("1.27.1"),
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected:      sdkFakeAgentPool(sdkWithOrchestratorVersion("1.27.3")),
+			expectedError: nil,
+		},
+		{
+			name: "existing agent pool auto-upgraded past the desired version is not downgraded",
+			spec: fakeAgentPool(withVersion("1.27.1"), func(pool *AgentPoolSpec) { pool.UpgradesKubernetesVersion = true }),
+			existing: sdkFakeAgentPool(
+				sdkWithOrchestratorVersion("1.27.3"),
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected:      nil,
+			expectedError: nil,
+		},
+		{
+			name: "existing agent pool on a newer version is updated without Kubernetes auto-upgrades",
+			spec: fakeAgentPool(withVersion("1.27.1")),
+			existing: sdkFakeAgentPool(
+				sdkWithOrchestratorVersion("1.27.3"),
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected:      sdkFakeAgentPool(sdkWithOrchestratorVersion("1.27.1")),
+			expectedError: nil,
+		},
 		{
 			name: "parameters with an existing agent pool and update needed on scale down mode",
 			spec: fakeAgentPool(),
@@ -305,6 +390,47 @@ func TestParameters(t *testing.T) {
 		},
 		{
 			name: "parameters with an existing agent pool and update needed on node labels",
+			spec: fakeAgentPool(
+				func(pool *AgentPoolSpec) {
+					pool.LastAppliedNodeLabels = map[string]interface{}{
+						"fake-label":     "fake-value",
+						"fake-old-label": "fake-old-value",
+					}
+				},
+			),
+			existing: sdkFakeAgentPool(
+				func(pool *armcontainerservice.AgentPool) {
+					pool.Properties.NodeLabels = map[string]*string{
+						"fake-label":     ptr.To("fake-value"),
+						"fake-old-label": ptr.To("fake-old-value"),
+					}
+				},
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected:      sdkFakeAgentPool(),
+			expectedError: nil,
+		},
+		{
+			name: "node labels not applied by CAPZ shouldn't trigger update",
+			spec: fakeAgentPool(
+				func(pool *AgentPoolSpec) {
+					pool.LastAppliedNodeLabels = map[string]interface{}{"fake-label": "fake-value"}
+				},
+			),
+			existing: sdkFakeAgentPool(
+				func(pool *armcontainerservice.AgentPool) {
+					pool.Properties.NodeLabels = map[string]*string{
+						"fake-label":       ptr.To("fake-value"),
+						"fake-other-label": ptr.To("fake-other-value"),
+					}
+				},
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected:      nil,
+			expectedError: nil,
+		},
+		{
+			name: "node labels of an agent pool reconciled before the last applied node labels were recorded are replaced",
 			spec: fakeAgentPool(),
 			existing: sdkFakeAgentPool(
 				func(pool *armcontainerservice.AgentPool) {
@@ -359,7 +485,11 @@ func TestParameters(t *testing.T) {
 		},
 		{
 			name: "parameters with an existing agent pool and update needed on node taints",
-			spec: fakeAgentPool(),
+			spec: fakeAgentPool(
+				func(pool *AgentPoolSpec) {
+					pool.LastAppliedNodeTaints = map[string]interface{}{"fake-taint": true, "fake-old-taint": true}
+				},
+			),
 			existing: sdkFakeAgentPool(
 				func(pool *armcontainerservice.AgentPool) {
 					pool.Properties.NodeTaints = []*string{ptr.To("fake-old-taint")}
@@ -369,6 +499,92 @@ func TestParameters(t *testing.T) {
 			expected:      sdkFakeAgentPool(),
 			expectedError: nil,
 		},
+		{
+			name: "node taints not applied by CAPZ shouldn't trigger update",
+			spec: fakeAgentPool(
+				func(pool *AgentPoolSpec) {
+					pool.LastAppliedNodeTaints = map[string]interface{}{"fake-taint": true}
+				},
+			),
+			existing: sdkFakeAgentPool(
+				func(pool *armcontainerservice.AgentPool) {
+					pool.Properties.NodeTaints = []*string{ptr.To("fake-taint"), ptr.To("fake-other-taint")}
+				},
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected:      nil,
+			expectedError: nil,
+		},
+		{
+			name: "node taints of an agent pool reconciled before the last applied node taints were recorded are replaced",
+			spec: fakeAgentPool(),
+			existing: sdkFakeAgentPool(
+				func(pool *armcontainerservice.AgentPool) {
+					pool.Properties.NodeTaints = []*string{ptr.To("fake-taint"), ptr.To("fake-old-taint")}
+				},
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected:      sdkFakeAgentPool(),
+			expectedError: nil,
+		},
+		{
+			name: "parameters with an existing agent pool and update needed on upgrade settings",
+			spec: fakeAgentPool(
+				func(pool *AgentPoolSpec) {
+					pool.UpgradeSettings = &infrav1.ManagedMachinePoolUpgradeSettings{
+						MaxSurge: ptr.To(intstr.FromString("33%")),
+					}
+				},
+			),
+			existing: sdkFakeAgentPool(
+				sdkWithUpgradeSettings(&armcontainerservice.AgentPoolUpgradeSettings{MaxSurge: ptr.To("1")}),
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected: sdkFakeAgentPool(
+				sdkWithUpgradeSettings(&armcontainerservice.AgentPoolUpgradeSettings{MaxSurge: ptr.To("33%")}),
+			),
+			expectedError: nil,
+		},
+		{
+			name: "parameters with an existing agent pool and update needed on node soak duration",
+			spec: fakeAgentPool(
+				func(pool *AgentPoolSpec) {
+					pool.UpgradeSettings = &infrav1.ManagedMachinePoolUpgradeSettings{
+						NodeSoakDurationInMinutes: ptr.To[int32](5),
+					}
+				},
+			),
+			existing: sdkFakeAgentPool(
+				sdkWithUpgradeSettings(&armcontainerservice.AgentPoolUpgradeSettings{
+					MaxSurge:                  ptr.To("1"),
+					NodeSoakDurationInMinutes: ptr.To[int32](0),
+				}),
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected: sdkFakeAgentPool(
+				sdkWithUpgradeSettings(&armcontainerservice.AgentPoolUpgradeSettings{NodeSoakDurationInMinutes: ptr.To[int32](5)}),
+			),
+			expectedError: nil,
+		},
+		{
+			name: "upgrade settings defaulted by AKS shouldn't trigger update",
+			spec: fakeAgentPool(
+				func(pool *AgentPoolSpec) {
+					pool.UpgradeSettings = &infrav1.ManagedMachinePoolUpgradeSettings{
+						MaxSurge: ptr.To(intstr.FromInt(5)),
+					}
+				},
+			),
+			existing: sdkFakeAgentPool(
+				sdkWithUpgradeSettings(&armcontainerservice.AgentPoolUpgradeSettings{
+					MaxSurge:              ptr.To("5"),
+					DrainTimeoutInMinutes: ptr.To[int32](30),
+				}),
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected:      nil,
+			expectedError: nil,
+		},
 		{
 			name: "scale to zero",
 			spec: fakeAgentPool(
@@ -419,12 +635,49 @@ func TestParameters(t *testing.T) {
 	}
 }
 
-func TestMergeSystemNodeLabels(t *testing.T) {
+func TestCustomHeaders(t *testing.T) {
 	testcases := []struct {
-		name       string
-		capzLabels map[string]*string
-		aksLabels  map[string]*string
-		expected   map[string]*string
+		name     string
+		spec     AgentPoolSpec
+		expected map[string]string
+	}{
+		{
+			name:     "headers from annotations",
+			spec:     fakeAgentPool(),
+			expected: map[string]string{"fake-header": "fake-value"},
+		},
+		{
+			name: "GPU driver installation is skipped",
+			spec: fakeAgentPool(func(pool *AgentPoolSpec) {
+				pool.SkipGPUDriverInstall = ptr.To(true)
+			}),
+			expected: map[string]string{"fake-header": "fake-value", "SkipGPUDriverInstall": "true"},
+		},
+		{
+			name: "GPU driver installation is not skipped",
+			spec: fakeAgentPool(func(pool *AgentPoolSpec) {
+				pool.SkipGPUDriverInstall = ptr.To(false)
+			}),
+			expected: map[string]string{"fake-header": "fake-value"},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			g.Expect(tc.spec.CustomHeaders()).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestMergeNodeLabels(t *testing.T) {
+	testcases := []struct {
+		name        string
+		capzLabels  map[string]*string
+		aksLabels   map[string]*string
+		lastApplied map[string]interface{}
+		expected    map[string]*string
 	}{
 		{
 			name: "update an existing label",
@@ -434,6 +687,7 @@ func TestMergeSystemNodeLabels(t *testing.T) {
 			aksLabels: map[string]*string{
 				"foo": ptr.To("baz"),
 			},
+			lastApplied: map[string]interface{}{"foo": "baz"},
 			expected: map[string]*string{
 				"foo": ptr.To("bar"),
 			},
@@ -445,7 +699,8 @@ func TestMergeSystemNodeLabels(t *testing.T) {
 				"foo":   ptr.To("bar"),
 				"hello": ptr.To("world"),
 			},
-			expected: map[string]*string{},
+			lastApplied: map[string]interface{}{"foo": "bar", "hello": "world"},
+			expected:    map[string]*string{},
 		},
 		{
 			name:       "delete labels from nil",
@@ -454,7 +709,15 @@ func TestMergeSystemNodeLabels(t *testing.T) {
 				"foo":   ptr.To("bar"),
 				"hello": ptr.To("world"),
 			},
-			expected: nil,
+			lastApplied: map[string]interface{}{"foo": "bar", "hello": "world"},
+			expected:    map[string]*string{},
+		},
+		{
+			name:        "no labels",
+			capzLabels:  nil,
+			aksLabels:   nil,
+			lastApplied: map[string]interface{}{"foo": "bar"},
+			expected:    nil,
 		},
 		{
 			name: "delete one label",
@@ -465,10 +728,27 @@ func TestMergeSystemNodeLabels(t *testing.T) {
 				"foo":   ptr.To("bar"),
 				"hello": ptr.To("world"),
 			},
+			lastApplied: map[string]interface{}{"foo": "bar", "hello": "world"},
 			expected: map[string]*string{
 				"foo": ptr.To("bar"),
 			},
 		},
+		{
+			name: "retain labels not set by CAPZ",
+			capzLabels: map[string]*string{
+				"foo": ptr.To("bar"),
+			},
+			aksLabels: map[string]*string{
+				"foo":   ptr.To("bar"),
+				"hello": ptr.To("world"),
+				"team":  ptr.To("ml"),
+			},
+			lastApplied: map[string]interface{}{"foo": "bar", "hello": "world"},
+			expected: map[string]*string{
+				"foo":  ptr.To("bar"),
+				"team": ptr.To("ml"),
+			},
+		},
 		{
 			name: "retain system label during update",
 			capzLabels: map[string]*string{
@@ -488,6 +768,7 @@ func TestMergeSystemNodeLabels(t *testing.T) {
 			aksLabels: map[string]*string{
 				"kubernetes.azure.com/scalesetpriority": ptr.To("spot"),
 			},
+			lastApplied: map[string]interface{}{"kubernetes.azure.com/scalesetpriority": "spot"},
 			expected: map[string]*string{
 				"kubernetes.azure.com/scalesetpriority": ptr.To("spot"),
 			},
@@ -501,8 +782,138 @@ func TestMergeSystemNodeLabels(t *testing.T) {
 			g := NewWithT(t)
 			t.Parallel()
 
-			ret := mergeSystemNodeLabels(tc.capzLabels, tc.aksLabels)
+			ret := mergeNodeLabels(tc.capzLabels, tc.aksLabels, tc.lastApplied)
 			g.Expect(ret).To(Equal(tc.expected))
 		})
 	}
 }
+
+func TestMergeNodeTaints(t *testing.T) {
+	testcases := []struct {
+		name        string
+		capzTaints  []string
+		aksTaints   []*string
+		lastApplied map[string]interface{}
+		expected    []*string
+	}{
+		{
+			name:       "add a taint",
+			capzTaints: []string{"foo=bar:NoSchedule"},
+			expected:   []*string{ptr.To("foo=bar:NoSchedule")},
+		},
+		{
+			name:        "remove a taint set by CAPZ",
+			capzTaints:  nil,
+			aksTaints:   []*string{ptr.To("foo=bar:NoSchedule")},
+			lastApplied: map[string]interface{}{"foo=bar:NoSchedule": true},
+			expected:    []*string{},
+		},
+		{
+			name:        "retain taints not set by CAPZ",
+			capzTaints:  []string{"foo=baz:NoSchedule"},
+			aksTaints:   []*string{ptr.To("team=ml:NoExecute"), ptr.To("foo=bar:NoSchedule")},
+			lastApplied: map[string]interface{}{"foo=bar:NoSchedule": true},
+			expected:    []*string{ptr.To("team=ml:NoExecute"), ptr.To("foo=baz:NoSchedule")},
+		},
+		{
+			name:        "keep the order of the existing taints",
+			capzTaints:  []string{"b=b:NoSchedule", "a=a:NoSchedule"},
+			aksTaints:   []*string{ptr.To("a=a:NoSchedule"), ptr.To("b=b:NoSchedule")},
+			lastApplied: map[string]interface{}{"a=a:NoSchedule": true, "b=b:NoSchedule": true},
+			expected:    []*string{ptr.To("a=a:NoSchedule"), ptr.To("b=b:NoSchedule")},
+		},
+		{
+			name:     "no taints",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			g.Expect(mergeNodeTaints(tc.capzTaints, tc.aksTaints, tc.lastApplied)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestNodeLabelsAndTaintsDrift(t *testing.T) {
+	g := NewWithT(t)
+
+	drift := nodeLabelsAndTaintsDrift(
+		map[string]*string{"foo": ptr.To("bar"), "new": ptr.To("label"), "changed": ptr.To("value")},
+		[]string{"a=a:NoSchedule", "b=b:NoSchedule"},
+		map[string]*string{"changed": ptr.To("other"), "team": ptr.To("ml")},
+		[]*string{ptr.To("a=a:NoSchedule")},
+		map[string]interface{}{"foo": "bar", "changed": "value"},
+		map[string]interface{}{"a=a:NoSchedule": true, "b=b:NoSchedule": true},
+	)
+	g.Expect(drift).To(Equal([]string{"label changed", "label foo", "taint b=b:NoSchedule"}))
+}
+
+func TestDrift(t *testing.T) {
+	testcases := []struct {
+		name           string
+		spec           AgentPoolSpec
+		existing       interface{}
+		expectedParams bool
+		expectedDrift  []string
+	}{
+		{
+			name:           "no existing agent pool",
+			spec:           fakeAgentPool(),
+			existing:       nil,
+			expectedParams: true,
+			expectedDrift:  nil,
+		},
+		{
+			name:           "existing agent pool up to date",
+			spec:           fakeAgentPool(),
+			existing:       sdkFakeAgentPool(sdkWithProvisioningState("Succeeded")),
+			expectedParams: false,
+			expectedDrift:  []string{},
+		},
+		{
+			name:           "existing agent pool is updated",
+			spec:           fakeAgentPool(withVersion("1.27.3")),
+			existing:       sdkFakeAgentPool(sdkWithOrchestratorVersion("1.27.1"), sdkWithAutoscaling(false), sdkWithProvisioningState("Succeeded")),
+			expectedParams: true,
+			expectedDrift:  []string{"Properties.EnableAutoScaling", "Properties.OrchestratorVersion"},
+		},
+		{
+			name:           "existing agent pool is not updated when drift is only reported",
+			spec:           fakeAgentPool(withVersion("1.27.3"), func(pool *AgentPoolSpec) { pool.ReportDriftOnly = true }),
+			existing:       sdkFakeAgentPool(sdkWithOrchestratorVersion("1.27.1"), sdkWithProvisioningState("Succeeded")),
+			expectedParams: false,
+			expectedDrift:  []string{"Properties.OrchestratorVersion"},
+		},
+		{
+			name:           "newer version of an existing agent pool is drift without Kubernetes auto-upgrades",
+			spec:           fakeAgentPool(withVersion("1.27.1")),
+			existing:       sdkFakeAgentPool(sdkWithOrchestratorVersion("1.27.3"), sdkWithProvisioningState("Succeeded")),
+			expectedParams: true,
+			expectedDrift:  []string{"Properties.OrchestratorVersion"},
+		},
+		{
+			name:           "newer version of an existing agent pool is kept with Kubernetes auto-upgrades",
+			spec:           fakeAgentPool(withVersion("1.27.1"), func(pool *AgentPoolSpec) { pool.UpgradesKubernetesVersion = true }),
+			existing:       sdkFakeAgentPool(sdkWithOrchestratorVersion("1.27.3"), sdkWithProvisioningState("Succeeded")),
+			expectedParams: false,
+			expectedDrift:  []string{},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result != nil).To(Equal(tc.expectedParams))
+			g.Expect(tc.spec.Drift()).To(Equal(tc.expectedDrift))
+		})
+	}
+}
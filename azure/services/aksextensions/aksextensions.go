@@ -0,0 +1,185 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aksextensions
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/kubernetesconfiguration/armkubernetesconfiguration/v2"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "aksextensions"
+
+// AKSExtensionScope defines the scope interface for an AKS extensions service.
+type AKSExtensionScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
+	ManagedClusterSpec() azure.ResourceSpecGetter
+	AKSExtensionSpecs() []azure.ResourceSpecGetter
+	IsManagedClusterStopped() bool
+	SetAKSExtensionsStatus([]infrav1.AKSExtensionStatus)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope AKSExtensionScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope AKSExtensionScope) (*Service, error) {
+	client, err := newClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope: scope,
+		Reconciler: async.New[armkubernetesconfiguration.ExtensionsClientCreateResponse,
+			armkubernetesconfiguration.ExtensionsClientDeleteResponse](scope, client, client),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates the extensions of a managed cluster.
+// Extensions which were previously applied but are no longer specified are deleted.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "aksextensions.Service.Reconcile")
+	defer done()
+
+	// The extensions of a stopped managed cluster can't be updated, they are reconciled once it is started again.
+	if s.Scope.IsManagedClusterStopped() {
+		log.V(2).Info("skipping extensions reconcile while the managed cluster is stopped")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	lastApplied, err := s.Scope.AnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation)
+	if err != nil {
+		return err
+	}
+
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
+	var resultingErr error
+	applied := map[string]interface{}{}
+	var statuses []infrav1.AKSExtensionStatus
+	for _, spec := range s.Scope.AKSExtensionSpecs() {
+		applied[spec.ResourceName()] = true
+		result, err := s.CreateOrUpdateResource(ctx, spec, serviceName)
+		status, statusErr := extensionStatus(spec.ResourceName(), result, err)
+		if statusErr != nil {
+			err = statusErr
+		}
+		statuses = append(statuses, status)
+		if err != nil && (!azure.IsOperationNotDoneError(err) || resultingErr == nil) {
+			resultingErr = err
+		}
+	}
+
+	removed := make([]string, 0, len(lastApplied))
+	for name := range lastApplied {
+		if _, ok := applied[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	if len(removed) > 0 {
+		managedClusterSpec := s.Scope.ManagedClusterSpec()
+		for _, name := range removed {
+			spec := &AKSExtensionSpec{
+				Name:          name,
+				ResourceGroup: managedClusterSpec.ResourceGroupName(),
+				ClusterName:   managedClusterSpec.ResourceName(),
+			}
+			if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+				// Keep track of the extension until it is deleted.
+				applied[name] = true
+				statuses = append(statuses, infrav1.AKSExtensionStatus{Name: name, ProvisioningState: infrav1.Deleting})
+				if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
+					resultingErr = err
+				}
+			}
+		}
+	}
+
+	s.Scope.SetAKSExtensionsStatus(statuses)
+
+	if len(applied) == 0 && len(lastApplied) == 0 {
+		// Extensions are not used by this cluster.
+		return nil
+	}
+
+	if err := s.Scope.UpdateAnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation, applied); err != nil {
+		return err
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.AKSExtensionsReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// Delete is a no-op, as the extensions are deleted along with the managed cluster.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "aksextensions.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// IsManaged always returns true as the extensions of a managed cluster are managed by CAPZ.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// extensionStatus returns the status of an extension from the result of its reconciliation.
+func extensionStatus(name string, result interface{}, err error) (infrav1.AKSExtensionStatus, error) {
+	status := infrav1.AKSExtensionStatus{Name: name}
+	switch {
+	case azure.IsOperationNotDoneError(err):
+		status.ProvisioningState = infrav1.Updating
+		return status, nil
+	case err != nil:
+		status.ProvisioningState = infrav1.Failed
+		return status, nil
+	}
+
+	extension, ok := result.(armkubernetesconfiguration.Extension)
+	if !ok {
+		return status, errors.Errorf("%T is not an armkubernetesconfiguration.Extension", result)
+	}
+	if extension.Properties == nil {
+		return status, errors.Errorf("extension %s has no properties", name)
+	}
+	status.ProvisioningState = infrav1.ProvisioningState(ptr.Deref(extension.Properties.ProvisioningState, ""))
+	status.Version = ptr.Deref(extension.Properties.CurrentVersion, ptr.Deref(extension.Properties.Version, ""))
+	return status, nil
+}
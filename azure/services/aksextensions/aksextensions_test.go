@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aksextensions
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/kubernetesconfiguration/armkubernetesconfiguration/v2"
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/aksextensions/mock_aksextensions"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeFluxSpec = AKSExtensionSpec{
+		Name:          "flux",
+		ResourceGroup: "my-rg",
+		ClusterName:   "my-cluster",
+		Extension: &infrav1.AKSExtension{
+			Name:          "flux",
+			ExtensionType: "microsoft.flux",
+		},
+	}
+	fakeDaprSpec = AKSExtensionSpec{
+		Name:          "dapr",
+		ResourceGroup: "my-rg",
+		ClusterName:   "my-cluster",
+	}
+	fakeManagedClusterSpec = &managedclusters.ManagedClusterSpec{
+		Name:          "my-cluster",
+		ResourceGroup: "my-rg",
+	}
+	fakeFluxExtension = armkubernetesconfiguration.Extension{
+		Properties: &armkubernetesconfiguration.ExtensionProperties{
+			ExtensionType:     ptr.To("microsoft.flux"),
+			CurrentVersion:    ptr.To("1.7.5"),
+			ProvisioningState: ptr.To(armkubernetesconfiguration.ProvisioningStateSucceeded),
+		},
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcileAKSExtensions(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "skip extensions while the managed cluster is stopped",
+			expectedError: "",
+			expect: func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(true)
+			},
+		},
+		{
+			name:          "noop if no extensions are set or were applied",
+			expectedError: "",
+			expect: func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AKSExtensionSpecs().Return([]azure.ResourceSpecGetter{})
+				s.SetAKSExtensionsStatus(nil)
+			},
+		},
+		{
+			name:          "create extension successfully",
+			expectedError: "",
+			expect: func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AKSExtensionSpecs().Return([]azure.ResourceSpecGetter{&fakeFluxSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFluxSpec, serviceName).Return(fakeFluxExtension, nil)
+				s.SetAKSExtensionsStatus([]infrav1.AKSExtensionStatus{
+					{Name: "flux", ProvisioningState: infrav1.Succeeded, Version: "1.7.5"},
+				})
+				s.UpdateAnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation, map[string]interface{}{"flux": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.AKSExtensionsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "extension creation in progress",
+			expectedError: "operation type PUT on Azure resource my-rg/flux is not done",
+			expect: func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				notDoneErr := azure.NewOperationNotDoneError(&infrav1.Future{Type: "PUT", ResourceGroup: "my-rg", Name: "flux"})
+				s.AnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AKSExtensionSpecs().Return([]azure.ResourceSpecGetter{&fakeFluxSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFluxSpec, serviceName).Return(nil, notDoneErr)
+				s.SetAKSExtensionsStatus([]infrav1.AKSExtensionStatus{
+					{Name: "flux", ProvisioningState: infrav1.Updating},
+				})
+				s.UpdateAnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation, map[string]interface{}{"flux": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.AKSExtensionsReadyCondition, serviceName, notDoneErr)
+			},
+		},
+		{
+			name:          "delete previously applied extension",
+			expectedError: "",
+			expect: func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation).Return(map[string]interface{}{"dapr": true}, nil)
+				s.AKSExtensionSpecs().Return([]azure.ResourceSpecGetter{})
+				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakeDaprSpec, serviceName).Return(nil)
+				s.SetAKSExtensionsStatus(nil)
+				s.UpdateAnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation, map[string]interface{}{}).Return(nil)
+				s.UpdatePutStatus(infrav1.AKSExtensionsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "keep tracking extension which fails to delete",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation).Return(map[string]interface{}{"flux": true, "dapr": true}, nil)
+				s.AKSExtensionSpecs().Return([]azure.ResourceSpecGetter{&fakeFluxSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFluxSpec, serviceName).Return(fakeFluxExtension, nil)
+				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakeDaprSpec, serviceName).Return(internalError)
+				s.SetAKSExtensionsStatus([]infrav1.AKSExtensionStatus{
+					{Name: "flux", ProvisioningState: infrav1.Succeeded, Version: "1.7.5"},
+					{Name: "dapr", ProvisioningState: infrav1.Deleting},
+				})
+				s.UpdateAnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation, map[string]interface{}{"flux": true, "dapr": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.AKSExtensionsReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "extension without properties",
+			expectedError: "extension flux has no properties",
+			expect: func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AKSExtensionSpecs().Return([]azure.ResourceSpecGetter{&fakeFluxSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFluxSpec, serviceName).Return(armkubernetesconfiguration.Extension{}, nil)
+				s.SetAKSExtensionsStatus([]infrav1.AKSExtensionStatus{
+					{Name: "flux"},
+				})
+				s.UpdateAnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation, map[string]interface{}{"flux": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.AKSExtensionsReadyCondition, serviceName, gomockinternal.ErrStrEq("extension flux has no properties"))
+			},
+		},
+		{
+			name:          "result is not an extension",
+			expectedError: "string is not an armkubernetesconfiguration.Extension",
+			expect: func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AKSExtensionSpecs().Return([]azure.ResourceSpecGetter{&fakeFluxSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFluxSpec, serviceName).Return("not an extension", nil)
+				s.SetAKSExtensionsStatus([]infrav1.AKSExtensionStatus{
+					{Name: "flux"},
+				})
+				s.UpdateAnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation, map[string]interface{}{"flux": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.AKSExtensionsReadyCondition, serviceName, gomockinternal.ErrStrEq("string is not an armkubernetesconfiguration.Extension"))
+			},
+		},
+		{
+			name:          "fail to create extension",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_aksextensions.MockAKSExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.AKSExtensionSpecs().Return([]azure.ResourceSpecGetter{&fakeFluxSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFluxSpec, serviceName).Return(nil, internalError)
+				s.SetAKSExtensionsStatus([]infrav1.AKSExtensionStatus{
+					{Name: "flux", ProvisioningState: infrav1.Failed},
+				})
+				s.UpdateAnnotationJSON(azure.AKSExtensionsLastAppliedAnnotation, map[string]interface{}{"flux": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.AKSExtensionsReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_aksextensions.NewMockAKSExtensionScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
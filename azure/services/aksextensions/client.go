@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aksextensions
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/kubernetesconfiguration/armkubernetesconfiguration/v2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// clusterRP is the resource provider of the managed clusters extensions are installed on.
+	clusterRP = "Microsoft.ContainerService"
+	// clusterResourceName is the resource type of the managed clusters extensions are installed on.
+	clusterResourceName = "managedClusters"
+)
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	extensions *armkubernetesconfiguration.ExtensionsClient
+}
+
+// newClient creates a new extensions client from an authorizer.
+func newClient(auth azure.Authorizer) (*azureClient, error) {
+	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create aksextensions client options")
+	}
+	factory, err := armkubernetesconfiguration.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armkubernetesconfiguration client factory")
+	}
+	return &azureClient{factory.NewExtensionsClient()}, nil
+}
+
+// Get gets the specified extension.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "aksextensions.azureClient.Get")
+	defer done()
+
+	resp, err := ac.extensions.Get(ctx, spec.ResourceGroupName(), clusterRP, clusterResourceName, spec.OwnerResourceName(), spec.ResourceName(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Extension, nil
+}
+
+// CreateOrUpdateAsync creates or updates an extension asynchronously.
+// It sends a PUT request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string, parameters interface{}) (result interface{}, poller *runtime.Poller[armkubernetesconfiguration.ExtensionsClientCreateResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "aksextensions.azureClient.CreateOrUpdateAsync")
+	defer done()
+
+	extension, ok := parameters.(armkubernetesconfiguration.Extension)
+	if !ok && parameters != nil {
+		return nil, nil, errors.Errorf("%T is not an armkubernetesconfiguration.Extension", parameters)
+	}
+
+	opts := &armkubernetesconfiguration.ExtensionsClientBeginCreateOptions{ResumeToken: resumeToken}
+	poller, err = ac.extensions.BeginCreate(ctx, spec.ResourceGroupName(), clusterRP, clusterResourceName, spec.OwnerResourceName(), spec.ResourceName(), extension, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	resp, err := poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// if an error occurs, return the poller.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, poller, err
+	}
+
+	// if the operation completed, return a nil poller
+	return resp.Extension, nil, err
+}
+
+// DeleteAsync deletes an extension asynchronously. DeleteAsync sends a DELETE
+// request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[armkubernetesconfiguration.ExtensionsClientDeleteResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "aksextensions.azureClient.DeleteAsync")
+	defer done()
+
+	opts := &armkubernetesconfiguration.ExtensionsClientBeginDeleteOptions{ResumeToken: resumeToken}
+	poller, err = ac.extensions.BeginDelete(ctx, spec.ResourceGroupName(), clusterRP, clusterResourceName, spec.OwnerResourceName(), spec.ResourceName(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	_, err = poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// if an error occurs, return the poller.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return poller, err
+	}
+
+	// if the operation completed, return a nil poller.
+	return nil, err
+}
@@ -0,0 +1,342 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../aksextensions.go
+//
+// Generated by this command:
+//
+//	mockgen -destination aksextensions_mock.go -package mock_aksextensions -source ../aksextensions.go AKSExtensionScope
+//
+// Package mock_aksextensions is a generated GoMock package.
+package mock_aksextensions
+
+import (
+	reflect "reflect"
+
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	autorest "github.com/Azure/go-autorest/autorest"
+	gomock "go.uber.org/mock/gomock"
+	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
+	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// MockAKSExtensionScope is a mock of AKSExtensionScope interface.
+type MockAKSExtensionScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockAKSExtensionScopeMockRecorder
+}
+
+// MockAKSExtensionScopeMockRecorder is the mock recorder for MockAKSExtensionScope.
+type MockAKSExtensionScopeMockRecorder struct {
+	mock *MockAKSExtensionScope
+}
+
+// NewMockAKSExtensionScope creates a new mock instance.
+func NewMockAKSExtensionScope(ctrl *gomock.Controller) *MockAKSExtensionScope {
+	mock := &MockAKSExtensionScope{ctrl: ctrl}
+	mock.recorder = &MockAKSExtensionScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAKSExtensionScope) EXPECT() *MockAKSExtensionScopeMockRecorder {
+	return m.recorder
+}
+
+// AKSExtensionSpecs mocks base method.
+func (m *MockAKSExtensionScope) AKSExtensionSpecs() []azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AKSExtensionSpecs")
+	ret0, _ := ret[0].([]azure.ResourceSpecGetter)
+	return ret0
+}
+
+// AKSExtensionSpecs indicates an expected call of AKSExtensionSpecs.
+func (mr *MockAKSExtensionScopeMockRecorder) AKSExtensionSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AKSExtensionSpecs", reflect.TypeOf((*MockAKSExtensionScope)(nil).AKSExtensionSpecs))
+}
+
+// AnnotationJSON mocks base method.
+func (m *MockAKSExtensionScope) AnnotationJSON(arg0 string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnnotationJSON", arg0)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnnotationJSON indicates an expected call of AnnotationJSON.
+func (mr *MockAKSExtensionScopeMockRecorder) AnnotationJSON(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnotationJSON", reflect.TypeOf((*MockAKSExtensionScope)(nil).AnnotationJSON), arg0)
+}
+
+// Authorizer mocks base method.
+func (m *MockAKSExtensionScope) Authorizer() autorest.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizer")
+	ret0, _ := ret[0].(autorest.Authorizer)
+	return ret0
+}
+
+// Authorizer indicates an expected call of Authorizer.
+func (mr *MockAKSExtensionScopeMockRecorder) Authorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockAKSExtensionScope)(nil).Authorizer))
+}
+
+// BaseURI mocks base method.
+func (m *MockAKSExtensionScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockAKSExtensionScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockAKSExtensionScope)(nil).BaseURI))
+}
+
+// ClientID mocks base method.
+func (m *MockAKSExtensionScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockAKSExtensionScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockAKSExtensionScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockAKSExtensionScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockAKSExtensionScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockAKSExtensionScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockAKSExtensionScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockAKSExtensionScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockAKSExtensionScope)(nil).CloudEnvironment))
+}
+
+// DeleteLongRunningOperationState mocks base method.
+func (m *MockAKSExtensionScope) DeleteLongRunningOperationState(arg0, arg1, arg2 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteLongRunningOperationState", arg0, arg1, arg2)
+}
+
+// DeleteLongRunningOperationState indicates an expected call of DeleteLongRunningOperationState.
+func (mr *MockAKSExtensionScopeMockRecorder) DeleteLongRunningOperationState(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockAKSExtensionScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
+}
+
+// GetLongRunningOperationState mocks base method.
+func (m *MockAKSExtensionScope) GetLongRunningOperationState(arg0, arg1, arg2 string) *v1beta1.Future {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLongRunningOperationState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1beta1.Future)
+	return ret0
+}
+
+// GetLongRunningOperationState indicates an expected call of GetLongRunningOperationState.
+func (mr *MockAKSExtensionScopeMockRecorder) GetLongRunningOperationState(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLongRunningOperationState", reflect.TypeOf((*MockAKSExtensionScope)(nil).GetLongRunningOperationState), arg0, arg1, arg2)
+}
+
+// HashKey mocks base method.
+func (m *MockAKSExtensionScope) HashKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashKey indicates an expected call of HashKey.
+func (mr *MockAKSExtensionScopeMockRecorder) HashKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockAKSExtensionScope)(nil).HashKey))
+}
+
+// IsManagedClusterStopped mocks base method.
+func (m *MockAKSExtensionScope) IsManagedClusterStopped() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsManagedClusterStopped")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsManagedClusterStopped indicates an expected call of IsManagedClusterStopped.
+func (mr *MockAKSExtensionScopeMockRecorder) IsManagedClusterStopped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsManagedClusterStopped", reflect.TypeOf((*MockAKSExtensionScope)(nil).IsManagedClusterStopped))
+}
+
+// ManagedClusterSpec mocks base method.
+func (m *MockAKSExtensionScope) ManagedClusterSpec() azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManagedClusterSpec")
+	ret0, _ := ret[0].(azure.ResourceSpecGetter)
+	return ret0
+}
+
+// ManagedClusterSpec indicates an expected call of ManagedClusterSpec.
+func (mr *MockAKSExtensionScopeMockRecorder) ManagedClusterSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManagedClusterSpec", reflect.TypeOf((*MockAKSExtensionScope)(nil).ManagedClusterSpec))
+}
+
+// SetAKSExtensionsStatus mocks base method.
+func (m *MockAKSExtensionScope) SetAKSExtensionsStatus(arg0 []v1beta1.AKSExtensionStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAKSExtensionsStatus", arg0)
+}
+
+// SetAKSExtensionsStatus indicates an expected call of SetAKSExtensionsStatus.
+func (mr *MockAKSExtensionScopeMockRecorder) SetAKSExtensionsStatus(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAKSExtensionsStatus", reflect.TypeOf((*MockAKSExtensionScope)(nil).SetAKSExtensionsStatus), arg0)
+}
+
+// SetLongRunningOperationState mocks base method.
+func (m *MockAKSExtensionScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLongRunningOperationState", arg0)
+}
+
+// SetLongRunningOperationState indicates an expected call of SetLongRunningOperationState.
+func (mr *MockAKSExtensionScopeMockRecorder) SetLongRunningOperationState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockAKSExtensionScope)(nil).SetLongRunningOperationState), arg0)
+}
+
+// SubscriptionID mocks base method.
+func (m *MockAKSExtensionScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockAKSExtensionScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockAKSExtensionScope)(nil).SubscriptionID))
+}
+
+// TenantID mocks base method.
+func (m *MockAKSExtensionScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockAKSExtensionScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockAKSExtensionScope)(nil).TenantID))
+}
+
+// Token mocks base method.
+func (m *MockAKSExtensionScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockAKSExtensionScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockAKSExtensionScope)(nil).Token))
+}
+
+// UpdateAnnotationJSON mocks base method.
+func (m *MockAKSExtensionScope) UpdateAnnotationJSON(arg0 string, arg1 map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnnotationJSON", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnnotationJSON indicates an expected call of UpdateAnnotationJSON.
+func (mr *MockAKSExtensionScopeMockRecorder) UpdateAnnotationJSON(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnnotationJSON", reflect.TypeOf((*MockAKSExtensionScope)(nil).UpdateAnnotationJSON), arg0, arg1)
+}
+
+// UpdateDeleteStatus mocks base method.
+func (m *MockAKSExtensionScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateDeleteStatus", arg0, arg1, arg2)
+}
+
+// UpdateDeleteStatus indicates an expected call of UpdateDeleteStatus.
+func (mr *MockAKSExtensionScopeMockRecorder) UpdateDeleteStatus(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeleteStatus", reflect.TypeOf((*MockAKSExtensionScope)(nil).UpdateDeleteStatus), arg0, arg1, arg2)
+}
+
+// UpdatePatchStatus mocks base method.
+func (m *MockAKSExtensionScope) UpdatePatchStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePatchStatus", arg0, arg1, arg2)
+}
+
+// UpdatePatchStatus indicates an expected call of UpdatePatchStatus.
+func (mr *MockAKSExtensionScopeMockRecorder) UpdatePatchStatus(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatchStatus", reflect.TypeOf((*MockAKSExtensionScope)(nil).UpdatePatchStatus), arg0, arg1, arg2)
+}
+
+// UpdatePutStatus mocks base method.
+func (m *MockAKSExtensionScope) UpdatePutStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePutStatus", arg0, arg1, arg2)
+}
+
+// UpdatePutStatus indicates an expected call of UpdatePutStatus.
+func (mr *MockAKSExtensionScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockAKSExtensionScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aksextensions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/kubernetesconfiguration/armkubernetesconfiguration/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// AKSExtensionSpec defines the specification for an AKS cluster extension.
+type AKSExtensionSpec struct {
+	Name          string
+	ResourceGroup string
+	ClusterName   string
+	// Extension is the desired extension. It is nil when the extension is no longer part of the
+	// AzureManagedControlPlane spec and only needs to be deleted.
+	Extension *infrav1.AKSExtension
+}
+
+// ResourceName returns the name of the extension.
+func (s *AKSExtensionSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the managed cluster.
+func (s *AKSExtensionSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the managed cluster the extension is installed on.
+func (s *AKSExtensionSpec) OwnerResourceName() string {
+	return s.ClusterName
+}
+
+// Parameters returns the parameters for the extension.
+func (s *AKSExtensionSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if s.Extension == nil {
+		return nil, errors.Errorf("extension %s is not set", s.Name)
+	}
+
+	properties := &armkubernetesconfiguration.ExtensionProperties{
+		ExtensionType:           ptr.To(s.Extension.ExtensionType),
+		AutoUpgradeMinorVersion: s.Extension.AutoUpgradeMinorVersion,
+		ReleaseTrain:            s.Extension.ReleaseTrain,
+		Version:                 s.Extension.Version,
+		Scope:                   scope(s.Extension.Scope),
+	}
+	// AKS upgrades the minor version of extensions by default, which conflicts with a pinned version.
+	if s.Extension.Version != nil && s.Extension.AutoUpgradeMinorVersion == nil {
+		properties.AutoUpgradeMinorVersion = ptr.To(false)
+	}
+	if len(s.Extension.ConfigurationSettings) > 0 {
+		properties.ConfigurationSettings = make(map[string]*string, len(s.Extension.ConfigurationSettings))
+		for k, v := range s.Extension.ConfigurationSettings {
+			properties.ConfigurationSettings[k] = ptr.To(v)
+		}
+	}
+
+	extension := armkubernetesconfiguration.Extension{
+		Properties: properties,
+	}
+	if s.Extension.Plan != nil {
+		extension.Plan = &armkubernetesconfiguration.Plan{
+			Name:      ptr.To(s.Extension.Plan.Name),
+			Product:   ptr.To(s.Extension.Plan.Product),
+			Publisher: ptr.To(s.Extension.Plan.Publisher),
+		}
+		if s.Extension.Plan.Version != "" {
+			extension.Plan.Version = ptr.To(s.Extension.Plan.Version)
+		}
+	}
+
+	if existing != nil {
+		existingExtension, ok := existing.(armkubernetesconfiguration.Extension)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armkubernetesconfiguration.Extension", existing)
+		}
+		existingProperties := existingExtension.Properties
+		if existingProperties == nil {
+			existingProperties = &armkubernetesconfiguration.ExtensionProperties{}
+		}
+
+		// The scope and plan of an extension cannot be changed, so keep the ones AKS picked when they are not set.
+		if properties.Scope == nil {
+			properties.Scope = existingProperties.Scope
+		}
+		if extension.Plan == nil {
+			extension.Plan = existingExtension.Plan
+		}
+
+		if !hasChanges(properties, existingProperties) {
+			return nil, nil
+		}
+	}
+
+	return extension, nil
+}
+
+// hasChanges returns true if the mutable properties set in the spec differ from the existing extension.
+func hasChanges(desired, existing *armkubernetesconfiguration.ExtensionProperties) bool {
+	if desired.AutoUpgradeMinorVersion != nil && ptr.Deref(existing.AutoUpgradeMinorVersion, true) != *desired.AutoUpgradeMinorVersion {
+		return true
+	}
+	if desired.ReleaseTrain != nil && !strings.EqualFold(*desired.ReleaseTrain, ptr.Deref(existing.ReleaseTrain, "")) {
+		return true
+	}
+	if desired.Version != nil && *desired.Version != ptr.Deref(existing.Version, ptr.Deref(existing.CurrentVersion, "")) {
+		return true
+	}
+	return !cmp.Equal(desired.ConfigurationSettings, existing.ConfigurationSettings, cmpopts.EquateEmpty())
+}
+
+func scope(s *infrav1.ExtensionScope) *armkubernetesconfiguration.Scope {
+	if s == nil {
+		return nil
+	}
+	switch s.ScopeType {
+	case infrav1.ExtensionScopeTypeCluster:
+		cluster := &armkubernetesconfiguration.ScopeCluster{}
+		if s.ReleaseNamespace != "" {
+			cluster.ReleaseNamespace = ptr.To(s.ReleaseNamespace)
+		}
+		return &armkubernetesconfiguration.Scope{Cluster: cluster}
+	case infrav1.ExtensionScopeTypeNamespace:
+		return &armkubernetesconfiguration.Scope{Namespace: &armkubernetesconfiguration.ScopeNamespace{TargetNamespace: ptr.To(s.TargetNamespace)}}
+	default:
+		return nil
+	}
+}
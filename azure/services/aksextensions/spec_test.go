@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aksextensions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/kubernetesconfiguration/armkubernetesconfiguration/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	fluxExtension := &infrav1.AKSExtension{
+		Name:          "flux",
+		ExtensionType: "microsoft.flux",
+		Version:       ptr.To("1.7.5"),
+		Scope: &infrav1.ExtensionScope{
+			ScopeType:        infrav1.ExtensionScopeTypeCluster,
+			ReleaseNamespace: "flux-system",
+		},
+		ConfigurationSettings: map[string]string{"helm-controller.enabled": "false"},
+	}
+	expectedFluxExtension := armkubernetesconfiguration.Extension{
+		Properties: &armkubernetesconfiguration.ExtensionProperties{
+			ExtensionType:           ptr.To("microsoft.flux"),
+			AutoUpgradeMinorVersion: ptr.To(false),
+			Version:                 ptr.To("1.7.5"),
+			Scope: &armkubernetesconfiguration.Scope{
+				Cluster: &armkubernetesconfiguration.ScopeCluster{ReleaseNamespace: ptr.To("flux-system")},
+			},
+			ConfigurationSettings: map[string]*string{"helm-controller.enabled": ptr.To("false")},
+		},
+	}
+	// existingFluxExtension is the flux extension as returned by the Azure API.
+	existingFluxExtension := func(version string, settings map[string]*string) armkubernetesconfiguration.Extension {
+		return armkubernetesconfiguration.Extension{
+			ID:   ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.ContainerService/managedClusters/my-cluster/providers/Microsoft.KubernetesConfiguration/extensions/flux"),
+			Name: ptr.To("flux"),
+			Properties: &armkubernetesconfiguration.ExtensionProperties{
+				ExtensionType:           ptr.To("microsoft.flux"),
+				AutoUpgradeMinorVersion: ptr.To(false),
+				ReleaseTrain:            ptr.To("Stable"),
+				Version:                 ptr.To(version),
+				CurrentVersion:          ptr.To(version),
+				Scope: &armkubernetesconfiguration.Scope{
+					Cluster: &armkubernetesconfiguration.ScopeCluster{ReleaseNamespace: ptr.To("flux-system")},
+				},
+				ConfigurationSettings: settings,
+				ProvisioningState:     ptr.To(armkubernetesconfiguration.ProvisioningStateSucceeded),
+			},
+		}
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *AKSExtensionSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new extension",
+			spec:     &AKSExtensionSpec{Name: "flux", Extension: fluxExtension},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(expectedFluxExtension))
+			},
+		},
+		{
+			name: "new namespaced marketplace extension",
+			spec: &AKSExtensionSpec{
+				Name: "my-extension",
+				Extension: &infrav1.AKSExtension{
+					Name:          "my-extension",
+					ExtensionType: "contoso.my-extension",
+					Scope: &infrav1.ExtensionScope{
+						ScopeType:       infrav1.ExtensionScopeTypeNamespace,
+						TargetNamespace: "contoso",
+					},
+					Plan: &infrav1.ExtensionPlan{
+						Name:      "my-plan",
+						Product:   "my-offer",
+						Publisher: "contoso",
+					},
+				},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armkubernetesconfiguration.Extension{
+					Plan: &armkubernetesconfiguration.Plan{
+						Name:      ptr.To("my-plan"),
+						Product:   ptr.To("my-offer"),
+						Publisher: ptr.To("contoso"),
+					},
+					Properties: &armkubernetesconfiguration.ExtensionProperties{
+						ExtensionType: ptr.To("contoso.my-extension"),
+						Scope: &armkubernetesconfiguration.Scope{
+							Namespace: &armkubernetesconfiguration.ScopeNamespace{TargetNamespace: ptr.To("contoso")},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name:     "existing extension is up to date",
+			spec:     &AKSExtensionSpec{Name: "flux", Extension: fluxExtension},
+			existing: existingFluxExtension("1.7.5", map[string]*string{"helm-controller.enabled": ptr.To("false")}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "existing extension has a different version",
+			spec:     &AKSExtensionSpec{Name: "flux", Extension: fluxExtension},
+			existing: existingFluxExtension("1.7.4", map[string]*string{"helm-controller.enabled": ptr.To("false")}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(expectedFluxExtension))
+			},
+		},
+		{
+			name:     "existing extension has different configuration settings",
+			spec:     &AKSExtensionSpec{Name: "flux", Extension: fluxExtension},
+			existing: existingFluxExtension("1.7.5", nil),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(expectedFluxExtension))
+			},
+		},
+		{
+			name: "existing scope is kept when not set",
+			spec: &AKSExtensionSpec{
+				Name: "flux",
+				Extension: &infrav1.AKSExtension{
+					Name:          "flux",
+					ExtensionType: "microsoft.flux",
+					Version:       ptr.To("1.7.6"),
+				},
+			},
+			existing: existingFluxExtension("1.7.5", nil),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armkubernetesconfiguration.Extension{}))
+				g.Expect(result.(armkubernetesconfiguration.Extension).Properties.Scope).To(Equal(&armkubernetesconfiguration.Scope{
+					Cluster: &armkubernetesconfiguration.ScopeCluster{ReleaseNamespace: ptr.To("flux-system")},
+				}))
+			},
+		},
+		{
+			name:          "existing is not an extension",
+			spec:          &AKSExtensionSpec{Name: "flux", Extension: fluxExtension},
+			existing:      "not an extension",
+			expectedError: "string is not an armkubernetesconfiguration.Extension",
+		},
+		{
+			name:          "extension is not set",
+			spec:          &AKSExtensionSpec{Name: "flux"},
+			existing:      nil,
+			expectedError: "extension flux is not set",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
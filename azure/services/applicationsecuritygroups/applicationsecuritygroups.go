@@ -0,0 +1,236 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "applicationsecuritygroups"
+
+// ASGScope defines the scope interface for an application security group service.
+type ASGScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	azure.ClusterDescriber
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
+	ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope ASGScope
+	async.Reconciler
+	async.TagsGetter
+}
+
+// New creates a new service.
+func New(scope ASGScope) (*Service, error) {
+	client, err := newClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	tagsClient, err := tags.NewClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope:      scope,
+		TagsGetter: tagsClient,
+		Reconciler: async.New[armnetwork.ApplicationSecurityGroupsClientCreateOrUpdateResponse,
+			armnetwork.ApplicationSecurityGroupsClientDeleteResponse](scope, client, client),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates a set of application security groups.
+// Managed application security groups which were previously created but are no longer specified are deleted.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	lastApplied, err := s.Scope.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation)
+	if err != nil {
+		return err
+	}
+
+	specs := s.Scope.ApplicationSecurityGroupSpecs()
+	if len(specs) == 0 && len(lastApplied) == 0 {
+		return nil
+	}
+
+	// We go through the list of application security groups to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var resErr error
+	applied := map[string]interface{}{}
+	for _, asgSpec := range specs {
+		applied[asgSpec.ResourceName()] = asgSpec.ResourceGroupName()
+		if _, err := s.CreateOrUpdateResource(ctx, asgSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	for _, asgSpec := range removedASGSpecs(lastApplied, specs) {
+		managed, err := s.isASGManaged(ctx, asgSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrap(err, "could not get application security group management state")
+		}
+
+		if !managed {
+			log.V(2).Info("Skipping deletion of unmanaged application security group", "application security group", asgSpec.ResourceName())
+			continue
+		}
+
+		if err := s.DeleteResource(ctx, asgSpec, serviceName); err != nil {
+			// Keep track of the application security group until it is deleted.
+			applied[asgSpec.ResourceName()] = asgSpec.ResourceGroupName()
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	if err := s.Scope.UpdateAnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation, applied); err != nil {
+		return err
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, resErr)
+	return resErr
+}
+
+// Delete deletes the application security groups managed by this cluster.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	lastApplied, err := s.Scope.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation)
+	if err != nil {
+		return err
+	}
+
+	specs := s.Scope.ApplicationSecurityGroupSpecs()
+	specs = append(specs, removedASGSpecs(lastApplied, specs)...)
+	if len(specs) == 0 {
+		return nil
+	}
+
+	hasManagedASGs := false
+
+	// We go through the list of application security groups to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	var resErr error
+	for _, asgSpec := range specs {
+		managed, err := s.isASGManaged(ctx, asgSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrap(err, "could not get application security group management state")
+		}
+
+		if !managed {
+			log.V(2).Info("Skipping deletion of unmanaged application security group", "application security group", asgSpec.ResourceName())
+			continue
+		}
+
+		hasManagedASGs = true
+		if err := s.DeleteResource(ctx, asgSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	if hasManagedASGs {
+		s.Scope.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, resErr)
+	}
+
+	return resErr
+}
+
+// isASGManaged returns true if the application security group has an owned tag with the cluster name as value,
+// meaning that its lifecycle is managed.
+func (s *Service) isASGManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
+	scope := azure.ApplicationSecurityGroupID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName())
+	result, err := s.TagsGetter.GetAtScope(ctx, scope)
+	if err != nil {
+		return false, err
+	}
+
+	tagsMap := make(map[string]*string)
+	if result.Properties != nil && result.Properties.Tags != nil {
+		tagsMap = result.Properties.Tags
+	}
+
+	tags := converters.MapToTags(tagsMap)
+	return tags.HasOwned(s.Scope.ClusterName()), nil
+}
+
+// removedASGSpecs returns a spec for each previously created application security group which is not in specs, sorted
+// by name. The previously created application security groups are keyed by name, with their resource group as value.
+func removedASGSpecs(lastApplied map[string]interface{}, specs []azure.ResourceSpecGetter) []azure.ResourceSpecGetter {
+	specified := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		specified[spec.ResourceName()] = true
+	}
+
+	names := make([]string, 0, len(lastApplied))
+	for name := range lastApplied {
+		if !specified[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	removed := make([]azure.ResourceSpecGetter, 0, len(names))
+	for _, name := range names {
+		resourceGroup, _ := lastApplied[name].(string)
+		removed = append(removed, &ASGSpec{
+			Name:          name,
+			ResourceGroup: resourceGroup,
+		})
+	}
+	return removed
+}
+
+// IsManaged returns always returns true as application security groups are managed on a one-by-one basis.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups/mock_applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeASG = ASGSpec{
+		Name:          "web",
+		ResourceGroup: "my-rg",
+		Location:      "westus2",
+		ClusterName:   "my-cluster",
+	}
+	fakeASG2 = ASGSpec{
+		Name:          "db",
+		ResourceGroup: "my-rg",
+		Location:      "westus2",
+		ClusterName:   "my-cluster",
+	}
+
+	managedTags = armresources.TagsResource{
+		Properties: &armresources.Tags{
+			Tags: map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+			},
+		},
+	}
+	unmanagedTags = armresources.TagsResource{
+		Properties: &armresources.Tags{
+			Tags: map[string]*string{
+				"foo": ptr.To("bar"),
+			},
+		},
+	}
+
+	errFake      = errors.New("this is an error")
+	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func TestReconcileApplicationSecurityGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no application security groups",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(nil, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create multiple application security groups succeeds",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(nil, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeASG, &fakeASG2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeASG, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeASG2, serviceName).Return(nil, nil)
+				s.UpdateAnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation, map[string]interface{}{"web": "my-rg", "db": "my-rg"})
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "first application security group create fails and second is not done",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(nil, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeASG, &fakeASG2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeASG, serviceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeASG2, serviceName).Return(nil, notDoneError)
+				s.UpdateAnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation, map[string]interface{}{"web": "my-rg", "db": "my-rg"})
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, errFake)
+			},
+		},
+		{
+			name:          "delete managed application security groups removed from the spec and skip unmanaged ones",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(map[string]interface{}{"web": "my-rg", "db": "my-rg", "cache": "my-rg"}, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeASG})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeASG, serviceName).Return(nil, nil)
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", "my-rg", "cache")).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", "my-rg", "db")).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &ASGSpec{Name: "db", ResourceGroup: "my-rg"}, serviceName).Return(nil)
+
+				s.UpdateAnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation, map[string]interface{}{"web": "my-rg"})
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "keep track of removed application security group until it is deleted",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(map[string]interface{}{"db": "my-rg"}, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", "my-rg", "db")).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &ASGSpec{Name: "db", ResourceGroup: "my-rg"}, serviceName).Return(notDoneError)
+
+				s.UpdateAnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation, map[string]interface{}{"db": "my-rg"})
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, notDoneError)
+			},
+		},
+		{
+			name:          "fail to get last applied application security groups",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(nil, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationsecuritygroups.NewMockASGScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				TagsGetter: tagsGetterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteApplicationSecurityGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no application security groups",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(nil, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete managed application security groups and skip unmanaged ones",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(nil, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeASG, &fakeASG2})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", fakeASG.ResourceGroupName(), fakeASG.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeASG, serviceName).Return(nil)
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", fakeASG2.ResourceGroupName(), fakeASG2.ResourceName())).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "noop if no managed application security groups",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(nil, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeASG})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", fakeASG.ResourceGroupName(), fakeASG.ResourceName())).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+			},
+		},
+		{
+			name:          "fail to delete managed application security group",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(nil, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeASG})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", fakeASG.ResourceGroupName(), fakeASG.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeASG, serviceName).Return(errFake)
+
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, errFake)
+			},
+		},
+		{
+			name:          "delete managed application security group removed from the spec",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(map[string]interface{}{"web": "my-rg", "db": "my-rg"}, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeASG})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", fakeASG.ResourceGroupName(), fakeASG.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeASG, serviceName).Return(nil)
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", "my-rg", "db")).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &ASGSpec{Name: "db", ResourceGroup: "my-rg"}, serviceName).Return(nil)
+
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to get application security group tags",
+			expectedError: "could not get application security group management state: " + errFake.Error(),
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.ApplicationSecurityGroupsLastAppliedAnnotation).Return(nil, nil)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeASG})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.ApplicationSecurityGroupID("123", fakeASG.ResourceGroupName(), fakeASG.ResourceName())).Return(armresources.TagsResource{}, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationsecuritygroups.NewMockASGScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				TagsGetter: tagsGetterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *ASGSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "application security group does not exist",
+			spec: &ASGSpec{
+				Name:           "web",
+				Location:       "westus2",
+				ClusterName:    "my-cluster",
+				AdditionalTags: infrav1.Tags{"foo": "bar"},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armnetwork.ApplicationSecurityGroup{
+					Location:   ptr.To("westus2"),
+					Properties: &armnetwork.ApplicationSecurityGroupPropertiesFormat{},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+						"Name": ptr.To("web"),
+						"foo":  ptr.To("bar"),
+					},
+				}))
+			},
+		},
+		{
+			name:     "application security group already exists",
+			spec:     &ASGSpec{Name: "web"},
+			existing: armnetwork.ApplicationSecurityGroup{Name: ptr.To("web")},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not an application security group",
+			spec:          &ASGSpec{Name: "web"},
+			existing:      struct{}{},
+			expectedError: "struct {} is not an armnetwork.ApplicationSecurityGroup",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
@@ -165,6 +165,47 @@ func (s *Service[C, D]) DeleteResource(ctx context.Context, spec azure.ResourceS
 	return nil
 }
 
+// InvokeResource invokes a long-running action, such as starting or stopping, on a resource asynchronously.
+// The futureType identifies the action, so an ongoing action is resumed by the next reconciliation.
+func InvokeResource[T any](ctx context.Context, scope FutureScope, invoker Invoker[T], spec azure.ResourceSpecGetter, serviceName, futureType string) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.InvokeResource")
+	defer done()
+
+	resourceName := spec.ResourceName()
+	rgName := spec.ResourceGroupName()
+
+	// Check for an ongoing long-running operation.
+	resumeToken := ""
+	if future := scope.GetLongRunningOperationState(resourceName, serviceName, futureType); future != nil {
+		t, err := converters.FutureToResumeToken(*future)
+		if err != nil {
+			scope.DeleteLongRunningOperationState(resourceName, serviceName, futureType)
+			return errors.Wrap(err, "could not decode future data, resetting long-running operation state")
+		}
+		resumeToken = t
+	}
+
+	// Invoke the action on the resource.
+	log.V(2).Info("invoking action on resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName, "action", futureType)
+	poller, err := invoker.InvokeAsync(ctx, spec, resumeToken)
+	if poller != nil {
+		future, err := converters.PollerToFuture(poller, futureType, serviceName, resourceName, rgName)
+		if err != nil {
+			return errors.Wrap(err, "failed to convert poller to future")
+		}
+		scope.SetLongRunningOperationState(future)
+		return azure.WithTransientError(azure.NewOperationNotDoneError(future), requeueTime())
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to invoke action %s on resource %s/%s (service: %s)", futureType, rgName, resourceName, serviceName)
+	}
+
+	// Once the operation is done, delete the long-running operation state.
+	scope.DeleteLongRunningOperationState(resourceName, serviceName, futureType)
+
+	log.V(2).Info("successfully invoked action on resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName, "action", futureType)
+	return nil
+}
+
 // requeueTime returns the time to wait before requeuing a reconciliation.
 // It would be ideal to use the "retry-after" header from the API response, but
 // that is not readily accessible in the SDK v2 Poller framework.
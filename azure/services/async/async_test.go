@@ -244,12 +244,96 @@ func TestServiceDeleteResource(t *testing.T) {
 	}
 }
 
+func TestInvokeResource(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(g *WithT, s *mock_async.MockFutureScopeMockRecorder, i *mock_async.MockInvokerMockRecorder[MockInvoker], r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:          "invalid future",
+			expectedError: "could not decode future data, resetting long-running operation state",
+			expect: func(g *WithT, s *mock_async.MockFutureScopeMockRecorder, i *mock_async.MockInvokerMockRecorder[MockInvoker], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					s.GetLongRunningOperationState(resourceName, serviceName, invokeFuture).Return(invalidInvokeFuture),
+					s.DeleteLongRunningOperationState(resourceName, serviceName, invokeFuture),
+				)
+			},
+		},
+		{
+			name:          "valid future",
+			expectedError: "",
+			expect: func(g *WithT, s *mock_async.MockFutureScopeMockRecorder, i *mock_async.MockInvokerMockRecorder[MockInvoker], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					s.GetLongRunningOperationState(resourceName, serviceName, invokeFuture).Return(validInvokeFuture),
+					i.InvokeAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), resumeToken).Return(nil, nil),
+					s.DeleteLongRunningOperationState(resourceName, serviceName, invokeFuture),
+				)
+			},
+		},
+		{
+			name:          "operation in progress",
+			expectedError: "operation type INVOKE on Azure resource mock-resourcegroup/mock-resource is not done. Object will be requeued after 15s",
+			expect: func(g *WithT, s *mock_async.MockFutureScopeMockRecorder, i *mock_async.MockInvokerMockRecorder[MockInvoker], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					s.GetLongRunningOperationState(resourceName, serviceName, invokeFuture).Return(nil),
+					i.InvokeAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), "").Return(fakePoller[MockInvoker](g, http.StatusAccepted), nil),
+					s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{})),
+				)
+			},
+		},
+		{
+			name:          "action fails",
+			expectedError: "failed to invoke action INVOKE on resource mock-resourcegroup/mock-resource (service: mock-service): foo",
+			expect: func(g *WithT, s *mock_async.MockFutureScopeMockRecorder, i *mock_async.MockInvokerMockRecorder[MockInvoker], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					s.GetLongRunningOperationState(resourceName, serviceName, invokeFuture).Return(nil),
+					i.InvokeAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), "").Return(nil, errors.New("foo")),
+				)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			invokerMock := mock_async.NewMockInvoker[MockInvoker](mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			tc.expect(g, scopeMock.EXPECT(), invokerMock.EXPECT(), specMock.EXPECT())
+
+			err := InvokeResource[MockInvoker](context.TODO(), scopeMock, invokerMock, specMock, serviceName, invokeFuture)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 const (
 	resourceGroupName  = "mock-resourcegroup"
 	resourceName       = "mock-resource"
 	serviceName        = "mock-service"
 	resumeToken        = "mock-resume-token"
 	invalidResumeToken = "!invalid-resume-token"
+	invokeFuture       = "INVOKE"
 )
 
 var (
@@ -281,6 +365,20 @@ var (
 		ResourceGroup: resourceGroupName,
 		Data:          invalidResumeToken,
 	}
+	validInvokeFuture = &infrav1.Future{
+		Type:          invokeFuture,
+		ServiceName:   serviceName,
+		Name:          resourceName,
+		ResourceGroup: resourceGroupName,
+		Data:          base64.URLEncoding.EncodeToString([]byte(resumeToken)),
+	}
+	invalidInvokeFuture = &infrav1.Future{
+		Type:          invokeFuture,
+		ServiceName:   serviceName,
+		Name:          resourceName,
+		ResourceGroup: resourceGroupName,
+		Data:          invalidResumeToken,
+	}
 	fakeResource            = armresources.GenericResource{}
 	fakeParameters          = armresources.GenericResource{}
 	azureResourceGetterType = reflect.TypeOf((*azure.ResourceSpecGetter)(nil)).Elem()
@@ -303,3 +401,4 @@ func fakePoller[T any](g *GomegaWithT, statusCode int) *runtime.Poller[T] {
 
 type MockCreator struct{}
 type MockDeleter struct{}
+type MockInvoker struct{}
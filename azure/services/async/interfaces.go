@@ -50,6 +50,19 @@ type Deleter[T any] interface {
 	DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[T], err error)
 }
 
+// Invoker invokes a long-running action, such as starting or stopping, on a resource asynchronously.
+type Invoker[T any] interface {
+	InvokeAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[T], err error)
+}
+
+// InvokerFunc is a function which implements the Invoker interface.
+type InvokerFunc[T any] func(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[T], err error)
+
+// InvokeAsync calls f(ctx, spec, resumeToken).
+func (f InvokerFunc[T]) InvokeAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[T], err error) {
+	return f(ctx, spec, resumeToken)
+}
+
 // Reconciler reconciles a resource.
 type Reconciler interface {
 	CreateOrUpdateResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (result interface{}, err error)
@@ -301,6 +301,44 @@ func (mr *MockDeleterMockRecorder[T]) DeleteAsync(ctx, spec, resumeToken any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAsync", reflect.TypeOf((*MockDeleter[T])(nil).DeleteAsync), ctx, spec, resumeToken)
 }
 
+// MockInvoker is a mock of Invoker interface.
+type MockInvoker[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvokerMockRecorder[T]
+}
+
+// MockInvokerMockRecorder is the mock recorder for MockInvoker.
+type MockInvokerMockRecorder[T any] struct {
+	mock *MockInvoker[T]
+}
+
+// NewMockInvoker creates a new mock instance.
+func NewMockInvoker[T any](ctrl *gomock.Controller) *MockInvoker[T] {
+	mock := &MockInvoker[T]{ctrl: ctrl}
+	mock.recorder = &MockInvokerMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoker[T]) EXPECT() *MockInvokerMockRecorder[T] {
+	return m.recorder
+}
+
+// InvokeAsync mocks base method.
+func (m *MockInvoker[T]) InvokeAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (*runtime.Poller[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeAsync", ctx, spec, resumeToken)
+	ret0, _ := ret[0].(*runtime.Poller[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeAsync indicates an expected call of InvokeAsync.
+func (mr *MockInvokerMockRecorder[T]) InvokeAsync(ctx, spec, resumeToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeAsync", reflect.TypeOf((*MockInvoker[T])(nil).InvokeAsync), ctx, spec, resumeToken)
+}
+
 // MockReconciler is a mock of Reconciler interface.
 type MockReconciler struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,257 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of the flow logs service.
+const ServiceName = "flowlogs"
+
+// FlowLogScope defines the scope interface for a flow logs service.
+type FlowLogScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	azure.ClusterDescriber
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
+	FlowLogSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope FlowLogScope
+	async.Reconciler
+	async.TagsGetter
+}
+
+// New creates a new service.
+func New(scope FlowLogScope) (*Service, error) {
+	client, err := newClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	tagsClient, err := tags.NewClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope:      scope,
+		TagsGetter: tagsClient,
+		Reconciler: async.New[armnetwork.FlowLogsClientCreateOrUpdateResponse,
+			armnetwork.FlowLogsClientDeleteResponse](scope, client, client),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the flow logs of the cluster network security groups.
+// Managed flow logs which were previously created but are no longer specified are deleted.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "flowlogs.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	lastApplied, err := s.Scope.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation)
+	if err != nil {
+		return err
+	}
+
+	specs := s.Scope.FlowLogSpecs()
+	if len(specs) == 0 && len(lastApplied) == 0 {
+		return nil
+	}
+
+	// We go through the list of flow logs to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var resErr error
+	applied := map[string]interface{}{}
+	for _, flowLogSpec := range specs {
+		applied[s.flowLogID(flowLogSpec)] = true
+		if _, err := s.CreateOrUpdateResource(ctx, flowLogSpec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	removedSpecs, err := s.removedFlowLogSpecs(lastApplied, specs)
+	if err != nil {
+		return err
+	}
+	for _, flowLogSpec := range removedSpecs {
+		managed, err := s.isFlowLogManaged(ctx, flowLogSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrap(err, "could not get flow log management state")
+		}
+
+		if !managed {
+			log.V(2).Info("Skipping deletion of unmanaged flow log", "flow log", flowLogSpec.ResourceName())
+			continue
+		}
+
+		if err := s.DeleteResource(ctx, flowLogSpec, ServiceName); err != nil {
+			// Keep track of the flow log until it is deleted.
+			applied[s.flowLogID(flowLogSpec)] = true
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	if err := s.Scope.UpdateAnnotationJSON(azure.FlowLogsLastAppliedAnnotation, applied); err != nil {
+		return err
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.FlowLogsReadyCondition, ServiceName, resErr)
+	return resErr
+}
+
+// Delete deletes the flow logs managed by this cluster.
+// Flow logs live in the resource group of their Network Watcher, so they are not removed along with the cluster resource group.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "flowlogs.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	lastApplied, err := s.Scope.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation)
+	if err != nil {
+		return err
+	}
+
+	specs := s.Scope.FlowLogSpecs()
+	removedSpecs, err := s.removedFlowLogSpecs(lastApplied, specs)
+	if err != nil {
+		return err
+	}
+	specs = append(specs, removedSpecs...)
+	if len(specs) == 0 {
+		return nil
+	}
+
+	hasManagedFlowLogs := false
+
+	// We go through the list of flow logs to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	var resErr error
+	for _, flowLogSpec := range specs {
+		managed, err := s.isFlowLogManaged(ctx, flowLogSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrap(err, "could not get flow log management state")
+		}
+
+		if !managed {
+			log.V(2).Info("Skipping deletion of unmanaged flow log", "flow log", flowLogSpec.ResourceName())
+			continue
+		}
+
+		hasManagedFlowLogs = true
+		if err := s.DeleteResource(ctx, flowLogSpec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	if hasManagedFlowLogs {
+		s.Scope.UpdateDeleteStatus(infrav1.FlowLogsReadyCondition, ServiceName, resErr)
+	}
+
+	return resErr
+}
+
+// flowLogID returns the resource ID of the flow log of spec.
+func (s *Service) flowLogID(spec azure.ResourceSpecGetter) string {
+	return azure.FlowLogID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+}
+
+// removedFlowLogSpecs returns a spec for each previously created flow log which is not in specs, sorted by resource ID.
+// The previously created flow logs are keyed by resource ID, so a flow log moved to another Network Watcher is removed
+// from the one it was created in.
+func (s *Service) removedFlowLogSpecs(lastApplied map[string]interface{}, specs []azure.ResourceSpecGetter) ([]azure.ResourceSpecGetter, error) {
+	specified := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		specified[strings.ToLower(s.flowLogID(spec))] = true
+	}
+
+	ids := make([]string, 0, len(lastApplied))
+	for id := range lastApplied {
+		if !specified[strings.ToLower(id)] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	removed := make([]azure.ResourceSpecGetter, 0, len(ids))
+	for _, id := range ids {
+		flowLogID, err := azureutil.ParseResourceID(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse flow log resource ID %s", id)
+		}
+		removed = append(removed, &FlowLogSpec{
+			Name:                        flowLogID.Name,
+			NetworkWatcherName:          flowLogID.Parent.Name,
+			NetworkWatcherResourceGroup: flowLogID.ResourceGroupName,
+		})
+	}
+	return removed, nil
+}
+
+// isFlowLogManaged returns true if the flow log has an owned tag with the cluster name as value,
+// meaning that its lifecycle is managed.
+func (s *Service) isFlowLogManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
+	result, err := s.TagsGetter.GetAtScope(ctx, s.flowLogID(spec))
+	if err != nil {
+		return false, err
+	}
+
+	tagsMap := make(map[string]*string)
+	if result.Properties != nil && result.Properties.Tags != nil {
+		tagsMap = result.Properties.Tags
+	}
+
+	tags := converters.MapToTags(tagsMap)
+	return tags.HasOwned(s.Scope.ClusterName()), nil
+}
+
+// IsManaged returns always returns true as flow logs are managed on a one-by-one basis.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
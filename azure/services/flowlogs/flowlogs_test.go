@@ -0,0 +1,318 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs/mock_flowlogs"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeFlowLog = FlowLogSpec{
+		Name:                        "node-nsg-flowlog",
+		NetworkWatcherName:          "NetworkWatcher_westus2",
+		NetworkWatcherResourceGroup: "NetworkWatcherRG",
+		Location:                    "westus2",
+		SecurityGroupID:             "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/node-nsg",
+		StorageAccountID:            "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+		ClusterName:                 "my-cluster",
+	}
+	fakeFlowLog2 = FlowLogSpec{
+		Name:                        "control-plane-nsg-flowlog",
+		NetworkWatcherName:          "NetworkWatcher_westus2",
+		NetworkWatcherResourceGroup: "NetworkWatcherRG",
+		Location:                    "westus2",
+		SecurityGroupID:             "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/control-plane-nsg",
+		StorageAccountID:            "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+		ClusterName:                 "my-cluster",
+	}
+
+	managedTags = armresources.TagsResource{
+		Properties: &armresources.Tags{
+			Tags: map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+			},
+		},
+	}
+	unmanagedTags = armresources.TagsResource{
+		Properties: &armresources.Tags{
+			Tags: map[string]*string{
+				"foo": ptr.To("bar"),
+			},
+		},
+	}
+
+	errFake      = errors.New("this is an error")
+	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
+
+	fakeFlowLogID  = azure.FlowLogID("123", "NetworkWatcherRG", "NetworkWatcher_westus2", "node-nsg-flowlog")
+	fakeFlowLog2ID = azure.FlowLogID("123", "NetworkWatcherRG", "NetworkWatcher_westus2", "control-plane-nsg-flowlog")
+	removedFlowLog = FlowLogSpec{
+		Name:                        "old-nsg-flowlog",
+		NetworkWatcherName:          "NetworkWatcher_westus2",
+		NetworkWatcherResourceGroup: "NetworkWatcherRG",
+	}
+	removedFlowLogID = azure.FlowLogID("123", "NetworkWatcherRG", "NetworkWatcher_westus2", "old-nsg-flowlog")
+)
+
+func TestReconcileFlowLogs(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no flow logs",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(nil, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create multiple flow logs succeeds",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(nil, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog, &fakeFlowLog2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFlowLog, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFlowLog2, ServiceName).Return(nil, nil)
+				s.UpdateAnnotationJSON(azure.FlowLogsLastAppliedAnnotation, map[string]interface{}{fakeFlowLogID: true, fakeFlowLog2ID: true})
+				s.UpdatePutStatus(infrav1.FlowLogsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "first flow log create fails and second is not done",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(nil, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog, &fakeFlowLog2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFlowLog, ServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFlowLog2, ServiceName).Return(nil, notDoneError)
+				s.UpdateAnnotationJSON(azure.FlowLogsLastAppliedAnnotation, map[string]interface{}{fakeFlowLogID: true, fakeFlowLog2ID: true})
+				s.UpdatePutStatus(infrav1.FlowLogsReadyCondition, ServiceName, errFake)
+			},
+		},
+		{
+			name:          "delete managed flow logs removed from the spec",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(map[string]interface{}{fakeFlowLogID: true, removedFlowLogID: true}, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFlowLog, ServiceName).Return(nil, nil)
+
+				m.GetAtScope(gomockinternal.AContext(), removedFlowLogID).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &removedFlowLog, ServiceName).Return(nil)
+
+				s.UpdateAnnotationJSON(azure.FlowLogsLastAppliedAnnotation, map[string]interface{}{fakeFlowLogID: true})
+				s.UpdatePutStatus(infrav1.FlowLogsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "skip deletion of unmanaged flow logs removed from the spec",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(map[string]interface{}{removedFlowLogID: true}, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{})
+
+				m.GetAtScope(gomockinternal.AContext(), removedFlowLogID).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				s.UpdateAnnotationJSON(azure.FlowLogsLastAppliedAnnotation, map[string]interface{}{})
+				s.UpdatePutStatus(infrav1.FlowLogsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "keep track of removed flow log until it is deleted",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(map[string]interface{}{removedFlowLogID: true}, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{})
+
+				m.GetAtScope(gomockinternal.AContext(), removedFlowLogID).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &removedFlowLog, ServiceName).Return(notDoneError)
+
+				s.UpdateAnnotationJSON(azure.FlowLogsLastAppliedAnnotation, map[string]interface{}{removedFlowLogID: true})
+				s.UpdatePutStatus(infrav1.FlowLogsReadyCondition, ServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "fail to parse last applied flow log",
+			expectedError: "failed to parse flow log resource ID not-an-id: invalid resource ID: resource id 'not-an-id' must start with '/'",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(map[string]interface{}{"not-an-id": true}, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_flowlogs.NewMockFlowLogScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			scopeMock.EXPECT().SubscriptionID().Return("123").AnyTimes()
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				TagsGetter: tagsGetterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteFlowLogs(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no flow logs",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(nil, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete managed flow logs and skip unmanaged ones",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(nil, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog, &fakeFlowLog2})
+
+				m.GetAtScope(gomockinternal.AContext(), azure.FlowLogID("123", fakeFlowLog.ResourceGroupName(), fakeFlowLog.OwnerResourceName(), fakeFlowLog.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeFlowLog, ServiceName).Return(nil)
+
+				m.GetAtScope(gomockinternal.AContext(), azure.FlowLogID("123", fakeFlowLog2.ResourceGroupName(), fakeFlowLog2.OwnerResourceName(), fakeFlowLog2.ResourceName())).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				s.UpdateDeleteStatus(infrav1.FlowLogsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "noop if no managed flow logs",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(nil, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog})
+
+				m.GetAtScope(gomockinternal.AContext(), azure.FlowLogID("123", fakeFlowLog.ResourceGroupName(), fakeFlowLog.OwnerResourceName(), fakeFlowLog.ResourceName())).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+			},
+		},
+		{
+			name:          "fail to delete managed flow log",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(nil, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog})
+
+				m.GetAtScope(gomockinternal.AContext(), azure.FlowLogID("123", fakeFlowLog.ResourceGroupName(), fakeFlowLog.OwnerResourceName(), fakeFlowLog.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeFlowLog, ServiceName).Return(errFake)
+
+				s.UpdateDeleteStatus(infrav1.FlowLogsReadyCondition, ServiceName, errFake)
+			},
+		},
+		{
+			name:          "delete managed flow log removed from the spec",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(map[string]interface{}{removedFlowLogID: true}, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{})
+
+				m.GetAtScope(gomockinternal.AContext(), removedFlowLogID).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &removedFlowLog, ServiceName).Return(nil)
+
+				s.UpdateDeleteStatus(infrav1.FlowLogsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to get flow log tags",
+			expectedError: "could not get flow log management state: " + errFake.Error(),
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.FlowLogsLastAppliedAnnotation).Return(nil, nil)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog})
+
+				m.GetAtScope(gomockinternal.AContext(), azure.FlowLogID("123", fakeFlowLog.ResourceGroupName(), fakeFlowLog.OwnerResourceName(), fakeFlowLog.ResourceName())).Return(armresources.TagsResource{}, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_flowlogs.NewMockFlowLogScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			scopeMock.EXPECT().SubscriptionID().Return("123").AnyTimes()
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				TagsGetter: tagsGetterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+const (
+	// flowLogFormatVersion is the version of the JSON flow log format, version 2 adds flow state and throughput information.
+	flowLogFormatVersion = 2
+	// defaultTrafficAnalyticsInterval is the default traffic analytics processing interval in minutes.
+	defaultTrafficAnalyticsInterval = 60
+)
+
+// FlowLogSpec defines the specification for a network security group flow log.
+type FlowLogSpec struct {
+	Name                        string
+	NetworkWatcherName          string
+	NetworkWatcherResourceGroup string
+	Location                    string
+	SecurityGroupID             string
+	StorageAccountID            string
+	RetentionDays               int32
+	TrafficAnalytics            *infrav1.TrafficAnalytics
+	ClusterName                 string
+	AdditionalTags              infrav1.Tags
+}
+
+// ResourceName returns the name of the flow log.
+func (s *FlowLogSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the Network Watcher.
+func (s *FlowLogSpec) ResourceGroupName() string {
+	return s.NetworkWatcherResourceGroup
+}
+
+// OwnerResourceName returns the name of the Network Watcher the flow log belongs to.
+func (s *FlowLogSpec) OwnerResourceName() string {
+	return s.NetworkWatcherName
+}
+
+// Parameters returns the parameters for the flow log.
+func (s *FlowLogSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	flowLog := armnetwork.FlowLog{
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.FlowLogPropertiesFormat{
+			Enabled:          ptr.To(true),
+			TargetResourceID: ptr.To(s.SecurityGroupID),
+			StorageID:        ptr.To(s.StorageAccountID),
+			RetentionPolicy: &armnetwork.RetentionPolicyParameters{
+				Enabled: ptr.To(s.RetentionDays > 0),
+				Days:    ptr.To(s.RetentionDays),
+			},
+			Format: &armnetwork.FlowLogFormatParameters{
+				Type:    ptr.To(armnetwork.FlowLogFormatTypeJSON),
+				Version: ptr.To[int32](flowLogFormatVersion),
+			},
+			FlowAnalyticsConfiguration: s.trafficAnalytics(),
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        ptr.To(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}
+
+	if existing != nil {
+		existingFlowLog, ok := existing.(armnetwork.FlowLog)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.FlowLog", existing)
+		}
+		// Flow logs that were not created by CAPZ are used as is.
+		if !converters.MapToTags(existingFlowLog.Tags).HasOwned(s.ClusterName) {
+			return nil, nil
+		}
+		if flowLogUpToDate(existingFlowLog, flowLog) {
+			return nil, nil
+		}
+	}
+
+	return flowLog, nil
+}
+
+// trafficAnalytics returns the traffic analytics configuration of the flow log.
+func (s *FlowLogSpec) trafficAnalytics() *armnetwork.TrafficAnalyticsProperties {
+	if s.TrafficAnalytics == nil {
+		return &armnetwork.TrafficAnalyticsProperties{
+			NetworkWatcherFlowAnalyticsConfiguration: &armnetwork.TrafficAnalyticsConfigurationProperties{
+				Enabled: ptr.To(false),
+			},
+		}
+	}
+	return &armnetwork.TrafficAnalyticsProperties{
+		NetworkWatcherFlowAnalyticsConfiguration: &armnetwork.TrafficAnalyticsConfigurationProperties{
+			Enabled:                  ptr.To(true),
+			WorkspaceResourceID:      ptr.To(s.TrafficAnalytics.WorkspaceResourceID),
+			WorkspaceID:              ptr.To(s.TrafficAnalytics.WorkspaceID),
+			WorkspaceRegion:          ptr.To(s.TrafficAnalytics.WorkspaceRegion),
+			TrafficAnalyticsInterval: ptr.To(ptr.Deref(s.TrafficAnalytics.IntervalInMinutes, defaultTrafficAnalyticsInterval)),
+		},
+	}
+}
+
+// flowLogUpToDate returns true if the existing flow log has the settings CAPZ manages.
+func flowLogUpToDate(existing, desired armnetwork.FlowLog) bool {
+	if existing.Properties == nil {
+		return false
+	}
+	e, d := existing.Properties, desired.Properties
+	if !ptr.Deref(e.Enabled, false) ||
+		!strings.EqualFold(ptr.Deref(e.TargetResourceID, ""), ptr.Deref(d.TargetResourceID, "")) ||
+		!strings.EqualFold(ptr.Deref(e.StorageID, ""), ptr.Deref(d.StorageID, "")) {
+		return false
+	}
+
+	if e.RetentionPolicy == nil ||
+		ptr.Deref(e.RetentionPolicy.Enabled, false) != ptr.Deref(d.RetentionPolicy.Enabled, false) ||
+		ptr.Deref(e.RetentionPolicy.Days, 0) != ptr.Deref(d.RetentionPolicy.Days, 0) {
+		return false
+	}
+
+	var existingTA armnetwork.TrafficAnalyticsConfigurationProperties
+	if e.FlowAnalyticsConfiguration != nil && e.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration != nil {
+		existingTA = *e.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration
+	}
+	desiredTA := *d.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration
+	if ptr.Deref(existingTA.Enabled, false) != ptr.Deref(desiredTA.Enabled, false) {
+		return false
+	}
+	if !ptr.Deref(desiredTA.Enabled, false) {
+		return true
+	}
+	return strings.EqualFold(ptr.Deref(existingTA.WorkspaceResourceID, ""), ptr.Deref(desiredTA.WorkspaceResourceID, "")) &&
+		strings.EqualFold(ptr.Deref(existingTA.WorkspaceID, ""), ptr.Deref(desiredTA.WorkspaceID, "")) &&
+		strings.EqualFold(ptr.Deref(existingTA.WorkspaceRegion, ""), ptr.Deref(desiredTA.WorkspaceRegion, "")) &&
+		ptr.Deref(existingTA.TrafficAnalyticsInterval, 0) == ptr.Deref(desiredTA.TrafficAnalyticsInterval, 0)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	trafficAnalyticsSpec := fakeFlowLog
+	trafficAnalyticsSpec.RetentionDays = 30
+	trafficAnalyticsSpec.TrafficAnalytics = &infrav1.TrafficAnalytics{
+		WorkspaceResourceID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+		WorkspaceID:         "00000000-0000-0000-0000-000000000000",
+		WorkspaceRegion:     "westus2",
+	}
+
+	fakeFlowLogParams := armnetwork.FlowLog{
+		Location: ptr.To("westus2"),
+		Properties: &armnetwork.FlowLogPropertiesFormat{
+			Enabled:          ptr.To(true),
+			TargetResourceID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/node-nsg"),
+			StorageID:        ptr.To("/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs"),
+			RetentionPolicy: &armnetwork.RetentionPolicyParameters{
+				Enabled: ptr.To(false),
+				Days:    ptr.To[int32](0),
+			},
+			Format: &armnetwork.FlowLogFormatParameters{
+				Type:    ptr.To(armnetwork.FlowLogFormatTypeJSON),
+				Version: ptr.To[int32](2),
+			},
+			FlowAnalyticsConfiguration: &armnetwork.TrafficAnalyticsProperties{
+				NetworkWatcherFlowAnalyticsConfiguration: &armnetwork.TrafficAnalyticsConfigurationProperties{
+					Enabled: ptr.To(false),
+				},
+			},
+		},
+		Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+			"Name": ptr.To("node-nsg-flowlog"),
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *FlowLogSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "flow log does not exist",
+			spec:     &fakeFlowLog,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(fakeFlowLogParams))
+			},
+		},
+		{
+			name:     "flow log with retention and traffic analytics does not exist",
+			spec:     &trafficAnalyticsSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.FlowLog{}))
+				props := result.(armnetwork.FlowLog).Properties
+				g.Expect(props.RetentionPolicy).To(Equal(&armnetwork.RetentionPolicyParameters{
+					Enabled: ptr.To(true),
+					Days:    ptr.To[int32](30),
+				}))
+				g.Expect(props.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration).To(Equal(&armnetwork.TrafficAnalyticsConfigurationProperties{
+					Enabled:                  ptr.To(true),
+					WorkspaceResourceID:      ptr.To("/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace"),
+					WorkspaceID:              ptr.To("00000000-0000-0000-0000-000000000000"),
+					WorkspaceRegion:          ptr.To("westus2"),
+					TrafficAnalyticsInterval: ptr.To[int32](60),
+				}))
+			},
+		},
+		{
+			name:     "managed flow log is up to date",
+			spec:     &fakeFlowLog,
+			existing: fakeFlowLogParams,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managed flow log writes to another storage account",
+			spec: &fakeFlowLog,
+			existing: func() armnetwork.FlowLog {
+				existing := fakeFlowLogParams
+				props := *existing.Properties
+				props.StorageID = ptr.To("/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/old")
+				existing.Properties = &props
+				return existing
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(fakeFlowLogParams))
+			},
+		},
+		{
+			name: "unmanaged flow log is left as is",
+			spec: &fakeFlowLog,
+			existing: armnetwork.FlowLog{
+				Properties: &armnetwork.FlowLogPropertiesFormat{
+					Enabled:   ptr.To(false),
+					StorageID: ptr.To("/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/other"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not a flow log",
+			spec:          &fakeFlowLog,
+			existing:      struct{}{},
+			expectedError: "struct {} is not an armnetwork.FlowLog",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
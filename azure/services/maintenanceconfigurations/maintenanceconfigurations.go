@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const serviceName = "maintenanceconfigurations"
+
+// MaintenanceConfigurationScope defines the scope interface for a maintenance configurations service.
+type MaintenanceConfigurationScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
+	DeleteCondition(clusterv1.ConditionType)
+	MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter
+	IsManagedClusterStopped() bool
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope MaintenanceConfigurationScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope MaintenanceConfigurationScope) (*Service, error) {
+	client, err := newClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope: scope,
+		Reconciler: async.New[armcontainerservice.MaintenanceConfigurationsClientCreateOrUpdateResponse,
+			armcontainerservice.MaintenanceConfigurationsClientDeleteResponse](scope, client, client),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates the maintenance configurations of a managed cluster.
+// Maintenance configurations which were previously applied but are no longer specified are deleted.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.Service.Reconcile")
+	defer done()
+
+	// The maintenance configurations of a stopped managed cluster can't be updated, they are reconciled once it is started again.
+	if s.Scope.IsManagedClusterStopped() {
+		log.V(2).Info("skipping maintenance configurations reconcile while the managed cluster is stopped")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	lastApplied, err := s.Scope.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation)
+	if err != nil {
+		return err
+	}
+
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
+	var resultingErr error
+	applied := map[string]interface{}{}
+	for _, spec := range s.Scope.MaintenanceConfigurationSpecs() {
+		configSpec, ok := spec.(*MaintenanceConfigurationSpec)
+		if !ok {
+			return errors.Errorf("%T is not a *MaintenanceConfigurationSpec", spec)
+		}
+
+		var err error
+		switch _, wasApplied := lastApplied[configSpec.ResourceName()]; {
+		case configSpec.Configuration != nil:
+			applied[configSpec.ResourceName()] = true
+			_, err = s.CreateOrUpdateResource(ctx, configSpec, serviceName)
+		case wasApplied:
+			if err = s.DeleteResource(ctx, configSpec, serviceName); err != nil {
+				// Keep track of the maintenance configuration until it is deleted.
+				applied[configSpec.ResourceName()] = true
+			}
+		default:
+			continue
+		}
+		if err != nil && (!azure.IsOperationNotDoneError(err) || resultingErr == nil) {
+			resultingErr = err
+		}
+	}
+
+	if len(applied) == 0 && len(lastApplied) == 0 {
+		// Maintenance configurations are not used by this cluster, remove any condition left from when they were.
+		s.Scope.DeleteCondition(infrav1.MaintenanceConfigurationsReadyCondition)
+		return nil
+	}
+
+	if err := s.Scope.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, applied); err != nil {
+		return err
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// Delete is a no-op, as the maintenance configurations are deleted along with the managed cluster.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// IsManaged always returns true as the maintenance configurations of a managed cluster are managed by CAPZ.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations/mock_maintenanceconfigurations"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	defaultConfigSpec = MaintenanceConfigurationSpec{
+		Name:          "default",
+		ResourceGroup: "my-rg",
+		Cluster:       "my-cluster",
+		Configuration: &infrav1.MaintenanceConfiguration{
+			Name: infrav1.MaintenanceConfigurationNameDefault,
+			TimeInWeek: []infrav1.TimeInWeek{
+				{Day: "Saturday", HourSlots: []int32{1, 2}},
+			},
+		},
+	}
+	unsetAutoUpgradeConfigSpec = MaintenanceConfigurationSpec{
+		Name:          "aksManagedAutoUpgradeSchedule",
+		ResourceGroup: "my-rg",
+		Cluster:       "my-cluster",
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcileMaintenanceConfigurations(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "skip maintenance configurations while the managed cluster is stopped",
+			expectedError: "",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(true)
+			},
+		},
+		{
+			name:          "remove condition if no maintenance configurations are set or were applied",
+			expectedError: "",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&unsetAutoUpgradeConfigSpec})
+				s.DeleteCondition(infrav1.MaintenanceConfigurationsReadyCondition)
+			},
+		},
+		{
+			name:          "create maintenance configuration successfully",
+			expectedError: "",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&defaultConfigSpec, &unsetAutoUpgradeConfigSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &defaultConfigSpec, serviceName).Return(nil, nil)
+				s.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, map[string]interface{}{"default": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "delete previously applied maintenance configuration",
+			expectedError: "",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(map[string]interface{}{"aksManagedAutoUpgradeSchedule": true}, nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&unsetAutoUpgradeConfigSpec})
+				r.DeleteResource(gomockinternal.AContext(), &unsetAutoUpgradeConfigSpec, serviceName).Return(nil)
+				s.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, map[string]interface{}{}).Return(nil)
+				s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "keep tracking maintenance configuration which fails to delete",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(map[string]interface{}{"aksManagedAutoUpgradeSchedule": true}, nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&unsetAutoUpgradeConfigSpec})
+				r.DeleteResource(gomockinternal.AContext(), &unsetAutoUpgradeConfigSpec, serviceName).Return(internalError)
+				s.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, map[string]interface{}{"aksManagedAutoUpgradeSchedule": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "fail to create maintenance configuration",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsManagedClusterStopped().Return(false)
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&defaultConfigSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &defaultConfigSpec, serviceName).Return(nil, internalError)
+				s.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, map[string]interface{}{"default": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_maintenanceconfigurations.NewMockMaintenanceConfigurationScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../maintenanceconfigurations.go
+//
+// Generated by this command:
+//
+//	mockgen -destination maintenanceconfigurations_mock.go -package mock_maintenanceconfigurations -source ../maintenanceconfigurations.go MaintenanceConfigurationScope
+//
+// Package mock_maintenanceconfigurations is a generated GoMock package.
+package mock_maintenanceconfigurations
+
+import (
+	reflect "reflect"
+
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	autorest "github.com/Azure/go-autorest/autorest"
+	gomock "go.uber.org/mock/gomock"
+	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
+	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// MockMaintenanceConfigurationScope is a mock of MaintenanceConfigurationScope interface.
+type MockMaintenanceConfigurationScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceConfigurationScopeMockRecorder
+}
+
+// MockMaintenanceConfigurationScopeMockRecorder is the mock recorder for MockMaintenanceConfigurationScope.
+type MockMaintenanceConfigurationScopeMockRecorder struct {
+	mock *MockMaintenanceConfigurationScope
+}
+
+// NewMockMaintenanceConfigurationScope creates a new mock instance.
+func NewMockMaintenanceConfigurationScope(ctrl *gomock.Controller) *MockMaintenanceConfigurationScope {
+	mock := &MockMaintenanceConfigurationScope{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceConfigurationScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceConfigurationScope) EXPECT() *MockMaintenanceConfigurationScopeMockRecorder {
+	return m.recorder
+}
+
+// AnnotationJSON mocks base method.
+func (m *MockMaintenanceConfigurationScope) AnnotationJSON(arg0 string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnnotationJSON", arg0)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnnotationJSON indicates an expected call of AnnotationJSON.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) AnnotationJSON(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnotationJSON", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).AnnotationJSON), arg0)
+}
+
+// Authorizer mocks base method.
+func (m *MockMaintenanceConfigurationScope) Authorizer() autorest.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizer")
+	ret0, _ := ret[0].(autorest.Authorizer)
+	return ret0
+}
+
+// Authorizer indicates an expected call of Authorizer.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) Authorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).Authorizer))
+}
+
+// BaseURI mocks base method.
+func (m *MockMaintenanceConfigurationScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).BaseURI))
+}
+
+// ClientID mocks base method.
+func (m *MockMaintenanceConfigurationScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockMaintenanceConfigurationScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockMaintenanceConfigurationScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).CloudEnvironment))
+}
+
+// DeleteCondition mocks base method.
+func (m *MockMaintenanceConfigurationScope) DeleteCondition(arg0 v1beta10.ConditionType) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteCondition", arg0)
+}
+
+// DeleteCondition indicates an expected call of DeleteCondition.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) DeleteCondition(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCondition", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).DeleteCondition), arg0)
+}
+
+// DeleteLongRunningOperationState mocks base method.
+func (m *MockMaintenanceConfigurationScope) DeleteLongRunningOperationState(arg0, arg1, arg2 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteLongRunningOperationState", arg0, arg1, arg2)
+}
+
+// DeleteLongRunningOperationState indicates an expected call of DeleteLongRunningOperationState.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) DeleteLongRunningOperationState(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
+}
+
+// GetLongRunningOperationState mocks base method.
+func (m *MockMaintenanceConfigurationScope) GetLongRunningOperationState(arg0, arg1, arg2 string) *v1beta1.Future {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLongRunningOperationState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1beta1.Future)
+	return ret0
+}
+
+// GetLongRunningOperationState indicates an expected call of GetLongRunningOperationState.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) GetLongRunningOperationState(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLongRunningOperationState", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).GetLongRunningOperationState), arg0, arg1, arg2)
+}
+
+// HashKey mocks base method.
+func (m *MockMaintenanceConfigurationScope) HashKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashKey indicates an expected call of HashKey.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) HashKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).HashKey))
+}
+
+// IsManagedClusterStopped mocks base method.
+func (m *MockMaintenanceConfigurationScope) IsManagedClusterStopped() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsManagedClusterStopped")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsManagedClusterStopped indicates an expected call of IsManagedClusterStopped.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) IsManagedClusterStopped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsManagedClusterStopped", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).IsManagedClusterStopped))
+}
+
+// MaintenanceConfigurationSpecs mocks base method.
+func (m *MockMaintenanceConfigurationScope) MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceConfigurationSpecs")
+	ret0, _ := ret[0].([]azure.ResourceSpecGetter)
+	return ret0
+}
+
+// MaintenanceConfigurationSpecs indicates an expected call of MaintenanceConfigurationSpecs.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) MaintenanceConfigurationSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceConfigurationSpecs", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).MaintenanceConfigurationSpecs))
+}
+
+// SetLongRunningOperationState mocks base method.
+func (m *MockMaintenanceConfigurationScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLongRunningOperationState", arg0)
+}
+
+// SetLongRunningOperationState indicates an expected call of SetLongRunningOperationState.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) SetLongRunningOperationState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).SetLongRunningOperationState), arg0)
+}
+
+// SubscriptionID mocks base method.
+func (m *MockMaintenanceConfigurationScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).SubscriptionID))
+}
+
+// TenantID mocks base method.
+func (m *MockMaintenanceConfigurationScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).TenantID))
+}
+
+// Token mocks base method.
+func (m *MockMaintenanceConfigurationScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).Token))
+}
+
+// UpdateAnnotationJSON mocks base method.
+func (m *MockMaintenanceConfigurationScope) UpdateAnnotationJSON(arg0 string, arg1 map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnnotationJSON", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnnotationJSON indicates an expected call of UpdateAnnotationJSON.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) UpdateAnnotationJSON(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnnotationJSON", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).UpdateAnnotationJSON), arg0, arg1)
+}
+
+// UpdateDeleteStatus mocks base method.
+func (m *MockMaintenanceConfigurationScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateDeleteStatus", arg0, arg1, arg2)
+}
+
+// UpdateDeleteStatus indicates an expected call of UpdateDeleteStatus.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) UpdateDeleteStatus(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeleteStatus", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).UpdateDeleteStatus), arg0, arg1, arg2)
+}
+
+// UpdatePatchStatus mocks base method.
+func (m *MockMaintenanceConfigurationScope) UpdatePatchStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePatchStatus", arg0, arg1, arg2)
+}
+
+// UpdatePatchStatus indicates an expected call of UpdatePatchStatus.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) UpdatePatchStatus(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatchStatus", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).UpdatePatchStatus), arg0, arg1, arg2)
+}
+
+// UpdatePutStatus mocks base method.
+func (m *MockMaintenanceConfigurationScope) UpdatePutStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePutStatus", arg0, arg1, arg2)
+}
+
+// UpdatePutStatus indicates an expected call of UpdatePutStatus.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// defaultUTCOffset is the UTC offset AKS applies to a maintenance window which doesn't specify one.
+const defaultUTCOffset = "+00:00"
+
+// MaintenanceConfigurationSpec defines the specification for an AKS maintenance configuration.
+type MaintenanceConfigurationSpec struct {
+	Name          string
+	ResourceGroup string
+	Cluster       string
+	// Configuration is the desired maintenance configuration. It is nil when the maintenance
+	// configuration is not part of the AzureManagedControlPlane spec.
+	Configuration *infrav1.MaintenanceConfiguration
+}
+
+// ResourceName returns the name of the maintenance configuration.
+func (s *MaintenanceConfigurationSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *MaintenanceConfigurationSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the managed cluster the maintenance configuration belongs to.
+func (s *MaintenanceConfigurationSpec) OwnerResourceName() string {
+	return s.Cluster
+}
+
+// Parameters returns the parameters for the maintenance configuration.
+func (s *MaintenanceConfigurationSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if s.Configuration == nil {
+		return nil, errors.Errorf("maintenance configuration %s is not set", s.Name)
+	}
+
+	properties, err := s.properties()
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		existingConfig, ok := existing.(armcontainerservice.MaintenanceConfiguration)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armcontainerservice.MaintenanceConfiguration", existing)
+		}
+
+		if existingConfig.Properties != nil && cmp.Equal(properties, existingConfig.Properties, cmpopts.EquateEmpty()) {
+			// Skip update for the maintenance configuration as it exists with expected values.
+			return nil, nil
+		}
+	}
+
+	return armcontainerservice.MaintenanceConfiguration{
+		Properties: properties,
+	}, nil
+}
+
+// properties converts the desired maintenance configuration to its SDK representation.
+func (s *MaintenanceConfigurationSpec) properties() (*armcontainerservice.MaintenanceConfigurationProperties, error) {
+	config := s.Configuration
+	properties := &armcontainerservice.MaintenanceConfigurationProperties{}
+
+	for _, timeInWeek := range config.TimeInWeek {
+		var hourSlots []*int32
+		for _, hour := range timeInWeek.HourSlots {
+			hourSlots = append(hourSlots, ptr.To(hour))
+		}
+		properties.TimeInWeek = append(properties.TimeInWeek, &armcontainerservice.TimeInWeek{
+			Day:       ptr.To(armcontainerservice.WeekDay(timeInWeek.Day)),
+			HourSlots: hourSlots,
+		})
+	}
+
+	for _, span := range config.NotAllowedTime {
+		properties.NotAllowedTime = append(properties.NotAllowedTime, &armcontainerservice.TimeSpan{
+			Start: ptr.To(span.Start.Time),
+			End:   ptr.To(span.End.Time),
+		})
+	}
+
+	if window := config.MaintenanceWindow; window != nil {
+		maintenanceWindow := &armcontainerservice.MaintenanceWindow{
+			DurationHours: ptr.To(window.DurationHours),
+			StartTime:     ptr.To(window.StartTime),
+			UTCOffset:     ptr.To(defaultUTCOffset),
+			Schedule:      schedule(window.Schedule),
+		}
+		if window.UTCOffset != "" {
+			maintenanceWindow.UTCOffset = ptr.To(window.UTCOffset)
+		}
+		if window.StartDate != "" {
+			startDate, err := time.Parse(time.DateOnly, window.StartDate)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse start date of maintenance configuration %s", s.Name)
+			}
+			maintenanceWindow.StartDate = ptr.To(startDate)
+		}
+		for _, span := range window.NotAllowedDates {
+			start, err := time.Parse(time.DateOnly, span.Start)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse not allowed dates of maintenance configuration %s", s.Name)
+			}
+			end, err := time.Parse(time.DateOnly, span.End)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse not allowed dates of maintenance configuration %s", s.Name)
+			}
+			maintenanceWindow.NotAllowedDates = append(maintenanceWindow.NotAllowedDates, &armcontainerservice.DateSpan{
+				Start: ptr.To(start),
+				End:   ptr.To(end),
+			})
+		}
+		properties.MaintenanceWindow = maintenanceWindow
+	}
+
+	return properties, nil
+}
+
+// schedule converts a maintenance window schedule to its SDK representation.
+func schedule(in infrav1.MaintenanceSchedule) *armcontainerservice.Schedule {
+	out := &armcontainerservice.Schedule{}
+	if in.Daily != nil {
+		out.Daily = &armcontainerservice.DailySchedule{
+			IntervalDays: ptr.To(in.Daily.IntervalDays),
+		}
+	}
+	if in.Weekly != nil {
+		out.Weekly = &armcontainerservice.WeeklySchedule{
+			DayOfWeek:     ptr.To(armcontainerservice.WeekDay(in.Weekly.DayOfWeek)),
+			IntervalWeeks: ptr.To(in.Weekly.IntervalWeeks),
+		}
+	}
+	if in.AbsoluteMonthly != nil {
+		out.AbsoluteMonthly = &armcontainerservice.AbsoluteMonthlySchedule{
+			DayOfMonth:     ptr.To(in.AbsoluteMonthly.DayOfMonth),
+			IntervalMonths: ptr.To(in.AbsoluteMonthly.IntervalMonths),
+		}
+	}
+	if in.RelativeMonthly != nil {
+		out.RelativeMonthly = &armcontainerservice.RelativeMonthlySchedule{
+			DayOfWeek:      ptr.To(armcontainerservice.WeekDay(in.RelativeMonthly.DayOfWeek)),
+			WeekIndex:      ptr.To(armcontainerservice.Type(in.RelativeMonthly.WeekIndex)),
+			IntervalMonths: ptr.To(in.RelativeMonthly.IntervalMonths),
+		}
+	}
+	return out
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	nodeOSUpgradeConfig := &infrav1.MaintenanceConfiguration{
+		Name: infrav1.MaintenanceConfigurationNameNodeOSUpgrade,
+		MaintenanceWindow: &infrav1.MaintenanceWindow{
+			Schedule: infrav1.MaintenanceSchedule{
+				RelativeMonthly: &infrav1.RelativeMonthlySchedule{
+					DayOfWeek:      "Sunday",
+					WeekIndex:      "Last",
+					IntervalMonths: 1,
+				},
+			},
+			DurationHours: 4,
+			StartTime:     "01:00",
+			StartDate:     "2023-11-01",
+			NotAllowedDates: []infrav1.DateSpan{
+				{Start: "2023-12-23", End: "2024-01-03"},
+			},
+		},
+	}
+	expectedNodeOSUpgradeConfig := armcontainerservice.MaintenanceConfiguration{
+		Properties: &armcontainerservice.MaintenanceConfigurationProperties{
+			MaintenanceWindow: &armcontainerservice.MaintenanceWindow{
+				Schedule: &armcontainerservice.Schedule{
+					RelativeMonthly: &armcontainerservice.RelativeMonthlySchedule{
+						DayOfWeek:      ptr.To(armcontainerservice.WeekDaySunday),
+						WeekIndex:      ptr.To(armcontainerservice.TypeLast),
+						IntervalMonths: ptr.To[int32](1),
+					},
+				},
+				DurationHours: ptr.To[int32](4),
+				StartTime:     ptr.To("01:00"),
+				UTCOffset:     ptr.To("+00:00"),
+				StartDate:     ptr.To(time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC)),
+				NotAllowedDates: []*armcontainerservice.DateSpan{
+					{
+						Start: ptr.To(time.Date(2023, time.December, 23, 0, 0, 0, 0, time.UTC)),
+						End:   ptr.To(time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *MaintenanceConfigurationSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "default maintenance configuration",
+			spec: &MaintenanceConfigurationSpec{
+				Name: "default",
+				Configuration: &infrav1.MaintenanceConfiguration{
+					Name: infrav1.MaintenanceConfigurationNameDefault,
+					TimeInWeek: []infrav1.TimeInWeek{
+						{Day: "Saturday", HourSlots: []int32{1, 2}},
+					},
+				},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armcontainerservice.MaintenanceConfiguration{
+					Properties: &armcontainerservice.MaintenanceConfigurationProperties{
+						TimeInWeek: []*armcontainerservice.TimeInWeek{
+							{
+								Day:       ptr.To(armcontainerservice.WeekDaySaturday),
+								HourSlots: []*int32{ptr.To[int32](1), ptr.To[int32](2)},
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name:     "maintenance window",
+			spec:     &MaintenanceConfigurationSpec{Name: "aksManagedNodeOSUpgradeSchedule", Configuration: nodeOSUpgradeConfig},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(expectedNodeOSUpgradeConfig))
+			},
+		},
+		{
+			name: "existing maintenance configuration is up to date",
+			spec: &MaintenanceConfigurationSpec{Name: "aksManagedNodeOSUpgradeSchedule", Configuration: nodeOSUpgradeConfig},
+			existing: armcontainerservice.MaintenanceConfiguration{
+				ID:         ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.ContainerService/managedClusters/my-cluster/maintenanceConfigurations/aksManagedNodeOSUpgradeSchedule"),
+				Name:       ptr.To("aksManagedNodeOSUpgradeSchedule"),
+				Properties: expectedNodeOSUpgradeConfig.Properties,
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing maintenance configuration is out of date",
+			spec: &MaintenanceConfigurationSpec{Name: "aksManagedNodeOSUpgradeSchedule", Configuration: nodeOSUpgradeConfig},
+			existing: armcontainerservice.MaintenanceConfiguration{
+				Properties: &armcontainerservice.MaintenanceConfigurationProperties{
+					MaintenanceWindow: &armcontainerservice.MaintenanceWindow{
+						Schedule: &armcontainerservice.Schedule{
+							Daily: &armcontainerservice.DailySchedule{IntervalDays: ptr.To[int32](1)},
+						},
+						DurationHours: ptr.To[int32](4),
+						StartTime:     ptr.To("01:00"),
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(expectedNodeOSUpgradeConfig))
+			},
+		},
+		{
+			name:          "existing is not a maintenance configuration",
+			spec:          &MaintenanceConfigurationSpec{Name: "aksManagedNodeOSUpgradeSchedule", Configuration: nodeOSUpgradeConfig},
+			existing:      "not a maintenance configuration",
+			expectedError: "string is not an armcontainerservice.MaintenanceConfiguration",
+		},
+		{
+			name:          "configuration is not set",
+			spec:          &MaintenanceConfigurationSpec{Name: "default"},
+			existing:      nil,
+			expectedError: "maintenance configuration default is not set",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
@@ -31,6 +32,7 @@ import (
 // CredentialGetter is a helper interface for getting managed cluster credentials.
 type CredentialGetter interface {
 	GetCredentials(context.Context, string, string) ([]byte, error)
+	GetUserCredentials(context.Context, string, string) ([]byte, error)
 }
 
 // azureClient contains the Azure go-sdk Client.
@@ -84,6 +86,25 @@ func (ac *azureClient) GetCredentials(ctx context.Context, resourceGroupName, na
 	return credentialList.Kubeconfigs[0].Value, nil
 }
 
+// GetUserCredentials fetches the user kubeconfig for a managed cluster.
+// For AAD integrated clusters, the kubeconfig authenticates with the kubelogin exec plugin.
+func (ac *azureClient) GetUserCredentials(ctx context.Context, resourceGroupName, name string) ([]byte, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.GetUserCredentials")
+	defer done()
+
+	opts := &armcontainerservice.ManagedClustersClientListClusterUserCredentialsOptions{Format: ptr.To(armcontainerservice.FormatExec)}
+	credentialList, err := ac.managedclusters.ListClusterUserCredentials(ctx, resourceGroupName, name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(credentialList.Kubeconfigs) == 0 {
+		return nil, errors.New("no user kubeconfigs available for the managed cluster")
+	}
+
+	return credentialList.Kubeconfigs[0].Value, nil
+}
+
 // CreateOrUpdateAsync creates or updates a managed cluster.
 // It sends a PUT request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
 // progress of the operation.
@@ -151,3 +172,63 @@ func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	// if the operation completed, return a nil poller.
 	return nil, err
 }
+
+// StartAsync starts a stopped managed cluster asynchronously. StartAsync sends a POST
+// request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureClient) StartAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (
+	poller *runtime.Poller[armcontainerservice.ManagedClustersClientStartResponse], err error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.StartAsync")
+	defer done()
+
+	opts := &armcontainerservice.ManagedClustersClientBeginStartOptions{ResumeToken: resumeToken}
+	log.V(4).Info("sending request", "resumeToken", resumeToken)
+	poller, err = ac.managedclusters.BeginStart(ctx, spec.ResourceGroupName(), spec.ResourceName(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	_, err = poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// If an error occurs, return the poller.
+		// This means the long-running operation didn't finish in the specified timeout.
+		return poller, err
+	}
+
+	// if the operation completed, return a nil poller.
+	return nil, err
+}
+
+// StopAsync stops a running managed cluster asynchronously. StopAsync sends a POST
+// request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureClient) StopAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (
+	poller *runtime.Poller[armcontainerservice.ManagedClustersClientStopResponse], err error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.StopAsync")
+	defer done()
+
+	opts := &armcontainerservice.ManagedClustersClientBeginStopOptions{ResumeToken: resumeToken}
+	log.V(4).Info("sending request", "resumeToken", resumeToken)
+	poller, err = ac.managedclusters.BeginStop(ctx, spec.ResourceGroupName(), spec.ResourceName(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	_, err = poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// If an error occurs, return the poller.
+		// This means the long-running operation didn't finish in the specified timeout.
+		return poller, err
+	}
+
+	// if the operation completed, return a nil poller.
+	return nil, err
+}
@@ -18,13 +18,20 @@ package managedclusters
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -35,6 +42,12 @@ const serviceName = "managedcluster"
 
 const kubeletIdentityKey = "kubeletidentity"
 
+// adoptionRequeueInterval is how long to wait before checking again whether a managed cluster to adopt matches its spec.
+const adoptionRequeueInterval = 1 * time.Minute
+
+// aksAADServerAppID is the well-known application ID of the AKS AAD server, which AAD tokens for AKS clusters are issued for.
+const aksAADServerAppID = "6dae42f8-4368-4678-94ff-3960e28e3630"
+
 // ManagedClusterScope defines the scope interface for a managed cluster.
 type ManagedClusterScope interface {
 	azure.Authorizer
@@ -45,14 +58,39 @@ type ManagedClusterScope interface {
 	MakeEmptyKubeConfigSecret() corev1.Secret
 	GetKubeConfigData() []byte
 	SetKubeConfigData([]byte)
+	MakeEmptyUserKubeConfigSecret() corev1.Secret
+	GetUserKubeConfigData() []byte
+	SetUserKubeConfigData([]byte)
+	SetKubeConfigTokenExpiry(time.Time)
+	IsAADEnabled() bool
+	AreLocalAccountsDisabled() bool
 	SetOIDCIssuerProfileStatus(*infrav1.OIDCIssuerProfileStatus)
+	SetAutoUpgradeVersionStatus(string)
+	SetMonitoringStatus(*infrav1.ManagedClusterMonitoringStatus)
+	DesiredPowerState() *infrav1.PowerState
+	SetPowerStateStatus(infrav1.PowerState)
+	IsAdoptingManagedCluster() bool
+	AdoptAgentPools(context.Context, []azure.AdoptedAgentPool) error
+	SetConditionTrue(clusterv1.ConditionType)
+	SetConditionFalse(clusterv1.ConditionType, string, clusterv1.ConditionSeverity, string)
+	SetDriftDetected([]string)
+}
+
+// driftReporter is a resource spec which reports the fields of the existing resource which differ from it once its
+// parameters are computed.
+type driftReporter interface {
+	azure.ResourceSpecGetter
+	Drift() []string
 }
 
 // Service provides operations on azure resources.
 type Service struct {
 	Scope ManagedClusterScope
 	async.Reconciler
+	async.Getter
 	CredentialGetter
+	Starter async.Invoker[armcontainerservice.ManagedClustersClientStartResponse]
+	Stopper async.Invoker[armcontainerservice.ManagedClustersClientStopResponse]
 }
 
 // New creates a new service.
@@ -65,7 +103,10 @@ func New(scope ManagedClusterScope) (*Service, error) {
 		Scope: scope,
 		Reconciler: async.New[armcontainerservice.ManagedClustersClientCreateOrUpdateResponse,
 			armcontainerservice.ManagedClustersClientDeleteResponse](scope, client, client),
+		Getter:           client,
 		CredentialGetter: client,
+		Starter:          async.InvokerFunc[armcontainerservice.ManagedClustersClientStartResponse](client.StartAsync),
+		Stopper:          async.InvokerFunc[armcontainerservice.ManagedClustersClientStopResponse](client.StopAsync),
 	}, nil
 }
 
@@ -87,12 +128,34 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
+	if s.Scope.IsAdoptingManagedCluster() {
+		if err := s.adoptManagedCluster(ctx, managedClusterSpec); err != nil {
+			return err
+		}
+	}
+
+	// The managed cluster cannot be updated while it is being started or stopped, so finish that first.
+	if err := s.resumePowerStateOperations(ctx, managedClusterSpec); err != nil {
+		s.Scope.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, err)
+		return err
+	}
+
 	result, resultErr := s.CreateOrUpdateResource(ctx, managedClusterSpec, serviceName)
+	// The drift is only known when the spec was compared to the existing managed cluster.
+	if spec, ok := managedClusterSpec.(*ManagedClusterSpec); ok && spec.Drift() != nil {
+		s.Scope.SetDriftDetected(spec.Drift())
+	}
 	if resultErr == nil {
 		managedCluster, ok := result.(armcontainerservice.ManagedCluster)
 		if !ok {
 			return errors.Errorf("%T is not an armcontainerservice.ManagedCluster\n%v\n%v", result, result, managedCluster)
 		}
+		powerState := infrav1.PowerStateRunning
+		if managedCluster.Properties.PowerState != nil && ptr.Deref(managedCluster.Properties.PowerState.Code, "") == armcontainerservice.CodeStopped {
+			powerState = infrav1.PowerStateStopped
+		}
+		s.Scope.SetPowerStateStatus(powerState)
+
 		// Update control plane endpoint.
 		endpoint := clusterv1.APIEndpoint{
 			Host: ptr.Deref(managedCluster.Properties.Fqdn, ""),
@@ -101,12 +164,12 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		s.Scope.SetControlPlaneEndpoint(endpoint)
 
 		// Update kubeconfig data
-		// Always fetch credentials in case of rotation
-		kubeConfigData, err := s.GetCredentials(ctx, managedClusterSpec.ResourceGroupName(), managedClusterSpec.ResourceName())
-		if err != nil {
-			return errors.Wrap(err, "failed to get credentials for managed cluster")
+		// Always fetch credentials in case of rotation, unless the cluster is stopped.
+		if powerState == infrav1.PowerStateRunning {
+			if err := s.reconcileKubeconfigs(ctx, managedClusterSpec); err != nil {
+				return errors.Wrap(err, "failed to get credentials for managed cluster")
+			}
 		}
-		s.Scope.SetKubeConfigData(kubeConfigData)
 
 		// This field gets populated by AKS when not set by the user. Persist AKS's value so for future diffs,
 		// the "before" reflects the correct value.
@@ -120,11 +183,182 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				IssuerURL: managedCluster.Properties.OidcIssuerProfile.IssuerURL,
 			})
 		}
+
+		// AKS may have upgraded the cluster past the version in the spec through its auto-upgrade channel.
+		s.Scope.SetAutoUpgradeVersionStatus(ptr.Deref(managedCluster.Properties.KubernetesVersion, ""))
+
+		s.Scope.SetMonitoringStatus(monitoringStatus(managedCluster))
+
+		resultErr = s.reconcilePowerState(ctx, managedClusterSpec, powerState)
 	}
 	s.Scope.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, resultErr)
 	return resultErr
 }
 
+// adoptManagedCluster imports an existing managed cluster and its agent pools. The managed cluster is not updated
+// until the spec matches its live state, so that adopting it never changes it.
+func (s *Service) adoptManagedCluster(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	existing, err := s.Get(ctx, spec)
+	if err != nil {
+		if azure.ResourceNotFound(err) {
+			err = errors.Errorf("managed cluster %s/%s does not exist and cannot be adopted", spec.ResourceGroupName(), spec.ResourceName())
+			s.Scope.SetConditionFalse(infrav1.AdoptedCondition, infrav1.AdoptionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return azure.WithTerminalError(err)
+		}
+		return errors.Wrap(err, "failed to get managed cluster to adopt")
+	}
+
+	// Parameters returns nil for a stopped managed cluster or when drift is only reported, so the fields which differ
+	// are checked instead.
+	reporter, ok := spec.(driftReporter)
+	if !ok {
+		return errors.Errorf("%T does not report the fields which differ from the existing managed cluster", spec)
+	}
+	if _, err := reporter.Parameters(ctx, existing); err != nil {
+		return err
+	}
+	drift := reporter.Drift()
+	if drift == nil {
+		return errors.Errorf("failed to compare the spec to the existing managed cluster %s/%s", spec.ResourceGroupName(), spec.ResourceName())
+	}
+	if len(drift) > 0 {
+		msg := fmt.Sprintf("the spec does not match the existing managed cluster in %s, which is not updated until it does", strings.Join(drift, ", "))
+		s.Scope.SetConditionFalse(infrav1.AdoptedCondition, infrav1.AdoptionPendingReason, clusterv1.ConditionSeverityWarning, msg)
+		return azure.WithTransientError(errors.Errorf("managed cluster %s/%s is pending adoption: %s", spec.ResourceGroupName(), spec.ResourceName(), msg), adoptionRequeueInterval)
+	}
+
+	managedCluster, ok := existing.(armcontainerservice.ManagedCluster)
+	if !ok {
+		return errors.Errorf("%T is not an armcontainerservice.ManagedCluster", existing)
+	}
+	var agentPools []azure.AdoptedAgentPool
+	if managedCluster.Properties != nil {
+		for _, profile := range managedCluster.Properties.AgentPoolProfiles {
+			if profile != nil {
+				agentPools = append(agentPools, converters.ManagedClusterAgentPoolProfileToAdoptedAgentPool(*profile))
+			}
+		}
+	}
+	if err := s.Scope.AdoptAgentPools(ctx, agentPools); err != nil {
+		return errors.Wrap(err, "failed to adopt agent pools")
+	}
+
+	s.Scope.SetConditionTrue(infrav1.AdoptedCondition)
+	return nil
+}
+
+// monitoringStatus returns the Azure Monitor integration of the managed cluster, or nil if it is not monitored.
+func monitoringStatus(managedCluster armcontainerservice.ManagedCluster) *infrav1.ManagedClusterMonitoringStatus {
+	status := &infrav1.ManagedClusterMonitoringStatus{}
+	if oms := managedCluster.Properties.AddonProfiles[omsAgentAddonName]; oms != nil && ptr.Deref(oms.Enabled, false) {
+		for key, value := range oms.Config {
+			if strings.EqualFold(key, omsAgentWorkspaceConfigKey) {
+				status.LogAnalyticsWorkspaceResourceID = ptr.Deref(value, "")
+			}
+		}
+	}
+	if profile := managedCluster.Properties.AzureMonitorProfile; profile != nil && profile.Metrics != nil {
+		status.ManagedPrometheusEnabled = ptr.Deref(profile.Metrics.Enabled, false)
+	}
+	if *status == (infrav1.ManagedClusterMonitoringStatus{}) {
+		return nil
+	}
+	return status
+}
+
+// reconcileKubeconfigs fetches the kubeconfigs of the managed cluster.
+// The user kubeconfig is only fetched for AAD integrated clusters.
+func (s *Service) reconcileKubeconfigs(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	var userKubeConfigData []byte
+	if s.Scope.IsAADEnabled() {
+		var err error
+		userKubeConfigData, err = s.GetUserCredentials(ctx, spec.ResourceGroupName(), spec.ResourceName())
+		if err != nil {
+			return err
+		}
+		s.Scope.SetUserKubeConfigData(userKubeConfigData)
+	}
+
+	if !s.Scope.AreLocalAccountsDisabled() {
+		kubeConfigData, err := s.GetCredentials(ctx, spec.ResourceGroupName(), spec.ResourceName())
+		if err != nil {
+			return err
+		}
+		s.Scope.SetKubeConfigData(kubeConfigData)
+		return nil
+	}
+
+	// The admin credentials cannot be fetched once local accounts are disabled, so authenticate as the cluster
+	// identity instead. The kubeconfig is not usable once the token expires, so its expiry is tracked to refresh it in time.
+	kubeConfigData, expiresOn, err := s.kubeconfigWithToken(ctx, userKubeConfigData)
+	if err != nil {
+		return err
+	}
+	s.Scope.SetKubeConfigData(kubeConfigData)
+	s.Scope.SetKubeConfigTokenExpiry(expiresOn)
+	return nil
+}
+
+// kubeconfigWithToken returns the given kubeconfig with its credentials replaced by an AAD token of the cluster identity,
+// along with the expiry of the token.
+func (s *Service) kubeconfigWithToken(ctx context.Context, kubeConfigData []byte) ([]byte, time.Time, error) {
+	token, err := s.Scope.Token().GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{aksAADServerAppID + "/.default"}})
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "failed to get token for the managed cluster")
+	}
+	config, err := clientcmd.Load(kubeConfigData)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "failed to load user kubeconfig")
+	}
+	if len(config.AuthInfos) == 0 {
+		return nil, time.Time{}, errors.New("user kubeconfig has no users")
+	}
+	for name := range config.AuthInfos {
+		config.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: token.Token}
+	}
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return data, token.ExpiresOn, nil
+}
+
+// resumePowerStateOperations resumes an ongoing start or stop of the managed cluster.
+func (s *Service) resumePowerStateOperations(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	if s.Scope.GetLongRunningOperationState(spec.ResourceName(), serviceName, infrav1.StartFuture) != nil {
+		if err := async.InvokeResource(ctx, s.Scope, s.Starter, spec, serviceName, infrav1.StartFuture); err != nil {
+			return err
+		}
+	}
+	if s.Scope.GetLongRunningOperationState(spec.ResourceName(), serviceName, infrav1.StopFuture) != nil {
+		if err := async.InvokeResource(ctx, s.Scope, s.Stopper, spec, serviceName, infrav1.StopFuture); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcilePowerState starts or stops the managed cluster when its power state differs from the desired one.
+func (s *Service) reconcilePowerState(ctx context.Context, spec azure.ResourceSpecGetter, current infrav1.PowerState) error {
+	desired := s.Scope.DesiredPowerState()
+	if desired == nil || *desired == current {
+		return nil
+	}
+
+	var err error
+	switch *desired {
+	case infrav1.PowerStateRunning:
+		err = async.InvokeResource(ctx, s.Scope, s.Starter, spec, serviceName, infrav1.StartFuture)
+	case infrav1.PowerStateStopped:
+		err = async.InvokeResource(ctx, s.Scope, s.Stopper, spec, serviceName, infrav1.StopFuture)
+	}
+	if err != nil {
+		return err
+	}
+	s.Scope.SetPowerStateStatus(*desired)
+	return nil
+}
+
 // Delete deletes the managed cluster.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.Delete")
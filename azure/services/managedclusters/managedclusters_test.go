@@ -19,13 +19,20 @@ package managedclusters
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters/mock_managedclusters"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -52,6 +59,9 @@ func TestReconcile(t *testing.T) {
 			expectedError: "some unexpected error occurred",
 			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				s.IsAdoptingManagedCluster().Return(false)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture).Return(nil)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StopFuture).Return(nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(nil, errors.New("some unexpected error occurred"))
 				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, errors.New("some unexpected error occurred"))
 			},
@@ -61,10 +71,14 @@ func TestReconcile(t *testing.T) {
 			expectedError: "",
 			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				s.IsAdoptingManagedCluster().Return(false)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture).Return(nil)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StopFuture).Return(nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(armcontainerservice.ManagedCluster{
 					Properties: &armcontainerservice.ManagedClusterProperties{
 						Fqdn:              ptr.To("my-managedcluster-fqdn"),
 						ProvisioningState: ptr.To("Succeeded"),
+						KubernetesVersion: ptr.To("1.27.3"),
 						IdentityProfile: map[string]*armcontainerservice.UserAssignedIdentity{
 							kubeletIdentityKey: {
 								ResourceID: ptr.To("kubelet-id"),
@@ -76,10 +90,13 @@ func TestReconcile(t *testing.T) {
 						},
 					},
 				}, nil)
+				s.SetPowerStateStatus(infrav1.PowerStateRunning)
 				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
 					Host: "my-managedcluster-fqdn",
 					Port: 443,
 				})
+				s.IsAADEnabled().Return(false)
+				s.AreLocalAccountsDisabled().Return(false)
 				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
 				s.SetKubeConfigData([]byte("credentials"))
 				s.SetKubeletIdentity("kubelet-id")
@@ -87,24 +104,51 @@ func TestReconcile(t *testing.T) {
 				s.SetOIDCIssuerProfileStatus(&infrav1.OIDCIssuerProfileStatus{
 					IssuerURL: ptr.To("oidc issuer url"),
 				})
+				s.SetAutoUpgradeVersionStatus("1.27.3")
+				s.SetMonitoringStatus(nil)
+				s.DesiredPowerState().Return(nil)
 				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
 			},
 		},
+		{
+			name:          "drift found when updating managed cluster is reported",
+			expectedError: "some unexpected error occurred",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				spec := &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg"}
+				s.ManagedClusterSpec().Return(spec)
+				s.IsAdoptingManagedCluster().Return(false)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture).Return(nil)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StopFuture).Return(nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), spec, serviceName).DoAndReturn(
+					func(ctx context.Context, spec *ManagedClusterSpec, serviceName string) (interface{}, error) {
+						spec.drift = []string{"Properties.KubernetesVersion"}
+						return nil, errors.New("some unexpected error occurred")
+					})
+				s.SetDriftDetected([]string{"Properties.KubernetesVersion"})
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, errors.New("some unexpected error occurred"))
+			},
+		},
 		{
 			name:          "fail to get managed cluster credentials",
 			expectedError: "failed to get credentials for managed cluster: internal server error",
 			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				s.IsAdoptingManagedCluster().Return(false)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture).Return(nil)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StopFuture).Return(nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(armcontainerservice.ManagedCluster{
 					Properties: &armcontainerservice.ManagedClusterProperties{
 						Fqdn:              ptr.To("my-managedcluster-fqdn"),
 						ProvisioningState: ptr.To("Succeeded"),
 					},
 				}, nil)
+				s.SetPowerStateStatus(infrav1.PowerStateRunning)
 				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
 					Host: "my-managedcluster-fqdn",
 					Port: 443,
 				})
+				s.IsAADEnabled().Return(false)
+				s.AreLocalAccountsDisabled().Return(false)
 				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte(""), errors.New("internal server error"))
 			},
 		},
@@ -140,6 +184,461 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcilePowerState(t *testing.T) {
+	stoppedManagedCluster := armcontainerservice.ManagedCluster{
+		Properties: &armcontainerservice.ManagedClusterProperties{
+			Fqdn:              ptr.To("my-managedcluster-fqdn"),
+			ProvisioningState: ptr.To("Succeeded"),
+			KubernetesVersion: ptr.To("1.27.3"),
+			PowerState:        &armcontainerservice.PowerState{Code: ptr.To(armcontainerservice.CodeStopped)},
+		},
+	}
+	runningManagedCluster := armcontainerservice.ManagedCluster{
+		Properties: &armcontainerservice.ManagedClusterProperties{
+			Fqdn:              ptr.To("my-managedcluster-fqdn"),
+			ProvisioningState: ptr.To("Succeeded"),
+			KubernetesVersion: ptr.To("1.27.3"),
+			PowerState:        &armcontainerservice.PowerState{Code: ptr.To(armcontainerservice.CodeRunning)},
+		},
+	}
+	stopFuture := &infrav1.Future{
+		Type:          infrav1.StopFuture,
+		ServiceName:   serviceName,
+		Name:          "my-managedcluster",
+		ResourceGroup: "my-rg",
+		Data:          "eyJtZXRob2QiOiJQT1NUIiwidXJsIjoiaHR0cHM6Ly9tYW5hZ2VtZW50LmF6dXJlLmNvbSJ9",
+	}
+	// expectReconciled sets the expectations of a successful reconcile of the given managed cluster.
+	expectReconciled := func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, managedCluster armcontainerservice.ManagedCluster, powerState infrav1.PowerState) {
+		r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(managedCluster, nil)
+		s.SetPowerStateStatus(powerState)
+		s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+			Host: "my-managedcluster-fqdn",
+			Port: 443,
+		})
+		s.SetOIDCIssuerProfileStatus(nil)
+		s.SetAutoUpgradeVersionStatus("1.27.3")
+		s.SetMonitoringStatus(nil)
+	}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+			start *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStartResponse], stop *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStopResponse])
+	}{
+		{
+			name:          "stopped managed cluster is left stopped if no power state is desired",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				start *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStartResponse], stop *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStopResponse]) {
+				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				s.IsAdoptingManagedCluster().Return(false)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture).Return(nil)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StopFuture).Return(nil)
+				expectReconciled(s, r, stoppedManagedCluster, infrav1.PowerStateStopped)
+				s.DesiredPowerState().Return(nil)
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "stopped managed cluster is started",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				start *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStartResponse], stop *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStopResponse]) {
+				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				s.IsAdoptingManagedCluster().Return(false)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture).Return(nil)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StopFuture).Return(nil)
+				expectReconciled(s, r, stoppedManagedCluster, infrav1.PowerStateStopped)
+				s.DesiredPowerState().Return(ptr.To(infrav1.PowerStateRunning))
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture).Return(nil)
+				start.InvokeAsync(gomockinternal.AContext(), fakeManagedClusterSpec, "").Return(nil, nil)
+				s.DeleteLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture)
+				s.SetPowerStateStatus(infrav1.PowerStateRunning)
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "running managed cluster fails to stop",
+			expectedError: "failed to invoke action STOP on resource my-rg/my-managedcluster (service: managedcluster): internal server error",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				start *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStartResponse], stop *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStopResponse]) {
+				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				s.IsAdoptingManagedCluster().Return(false)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture).Return(nil)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StopFuture).Return(nil)
+				expectReconciled(s, r, runningManagedCluster, infrav1.PowerStateRunning)
+				s.IsAADEnabled().Return(false)
+				s.AreLocalAccountsDisabled().Return(false)
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
+				s.SetKubeConfigData([]byte("credentials"))
+				s.DesiredPowerState().Return(ptr.To(infrav1.PowerStateStopped))
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StopFuture).Return(nil)
+				stop.InvokeAsync(gomockinternal.AContext(), fakeManagedClusterSpec, "").Return(nil, errors.New("internal server error"))
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "ongoing stop is resumed before the managed cluster is updated",
+			expectedError: "failed to invoke action STOP on resource my-rg/my-managedcluster (service: managedcluster): internal server error",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+				start *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStartResponse], stop *mock_async.MockInvokerMockRecorder[armcontainerservice.ManagedClustersClientStopResponse]) {
+				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				s.IsAdoptingManagedCluster().Return(false)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StartFuture).Return(nil)
+				s.GetLongRunningOperationState("my-managedcluster", serviceName, infrav1.StopFuture).Return(stopFuture).Times(2)
+				stop.InvokeAsync(gomockinternal.AContext(), fakeManagedClusterSpec, `{"method":"POST","url":"https://management.azure.com"}`).Return(nil, errors.New("internal server error"))
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, gomock.Any())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+			credsGetterMock := mock_managedclusters.NewMockCredentialGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			starterMock := mock_async.NewMockInvoker[armcontainerservice.ManagedClustersClientStartResponse](mockCtrl)
+			stopperMock := mock_async.NewMockInvoker[armcontainerservice.ManagedClustersClientStopResponse](mockCtrl)
+
+			tc.expect(credsGetterMock.EXPECT(), scopeMock.EXPECT(), reconcilerMock.EXPECT(), starterMock.EXPECT(), stopperMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				CredentialGetter: credsGetterMock,
+				Reconciler:       reconcilerMock,
+				Starter:          starterMock,
+				Stopper:          stopperMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAdoptManagedCluster(t *testing.T) {
+	existingManagedCluster := armcontainerservice.ManagedCluster{
+		Properties: &armcontainerservice.ManagedClusterProperties{
+			ProvisioningState: ptr.To("Succeeded"),
+			AgentPoolProfiles: []*armcontainerservice.ManagedClusterAgentPoolProfile{
+				{
+					Name:                ptr.To("pool0"),
+					Mode:                ptr.To(armcontainerservice.AgentPoolModeSystem),
+					VMSize:              ptr.To("Standard_D2s_v3"),
+					Count:               ptr.To[int32](2),
+					OrchestratorVersion: ptr.To("1.27.3"),
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		drift         []string
+		expectedError string
+		expect        func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, g *mock_async.MockGetterMockRecorder, spec *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:          "managed cluster and its agent pools are adopted when the spec matches",
+			drift:         []string{},
+			expectedError: "",
+			expect: func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, g *mock_async.MockGetterMockRecorder, spec *mock_azure.MockResourceSpecGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(existingManagedCluster, nil)
+				spec.Parameters(gomockinternal.AContext(), existingManagedCluster).Return(nil, nil)
+				s.AdoptAgentPools(gomockinternal.AContext(), []azure.AdoptedAgentPool{
+					{
+						Spec: infrav1.AzureManagedMachinePoolSpec{
+							Name: ptr.To("pool0"),
+							Mode: "System",
+							SKU:  "Standard_D2s_v3",
+						},
+						Replicas: 2,
+						Version:  ptr.To("v1.27.3"),
+					},
+				}).Return(nil)
+				s.SetConditionTrue(infrav1.AdoptedCondition)
+			},
+		},
+		{
+			name:          "managed cluster is not updated while the spec differs",
+			drift:         []string{"Properties.KubernetesVersion"},
+			expectedError: "managed cluster my-rg/my-managedcluster is pending adoption: the spec does not match the existing managed cluster in Properties.KubernetesVersion, which is not updated until it does. Object will be requeued after 1m0s",
+			expect: func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, g *mock_async.MockGetterMockRecorder, spec *mock_azure.MockResourceSpecGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(existingManagedCluster, nil)
+				spec.Parameters(gomockinternal.AContext(), existingManagedCluster).Return(armcontainerservice.ManagedCluster{}, nil)
+				s.SetConditionFalse(infrav1.AdoptedCondition, infrav1.AdoptionPendingReason, clusterv1.ConditionSeverityWarning,
+					"the spec does not match the existing managed cluster in Properties.KubernetesVersion, which is not updated until it does")
+			},
+		},
+		{
+			name:          "managed cluster is pending adoption when the spec differs without parameters",
+			drift:         []string{"Properties.KubernetesVersion"},
+			expectedError: "managed cluster my-rg/my-managedcluster is pending adoption: the spec does not match the existing managed cluster in Properties.KubernetesVersion, which is not updated until it does. Object will be requeued after 1m0s",
+			expect: func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, g *mock_async.MockGetterMockRecorder, spec *mock_azure.MockResourceSpecGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(existingManagedCluster, nil)
+				// No parameters are returned for a stopped managed cluster or when drift is only reported.
+				spec.Parameters(gomockinternal.AContext(), existingManagedCluster).Return(nil, nil)
+				s.SetConditionFalse(infrav1.AdoptedCondition, infrav1.AdoptionPendingReason, clusterv1.ConditionSeverityWarning,
+					"the spec does not match the existing managed cluster in Properties.KubernetesVersion, which is not updated until it does")
+			},
+		},
+		{
+			name:          "managed cluster is not adopted when the spec was not compared to it",
+			drift:         nil,
+			expectedError: "failed to compare the spec to the existing managed cluster my-rg/my-managedcluster",
+			expect: func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, g *mock_async.MockGetterMockRecorder, spec *mock_azure.MockResourceSpecGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(existingManagedCluster, nil)
+				spec.Parameters(gomockinternal.AContext(), existingManagedCluster).Return(nil, nil)
+			},
+		},
+		{
+			name:          "managed cluster which does not exist cannot be adopted",
+			expectedError: "reconcile error that cannot be recovered occurred: managed cluster my-rg/my-managedcluster does not exist and cannot be adopted. Object will not be requeued",
+			expect: func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, g *mock_async.MockGetterMockRecorder, spec *mock_azure.MockResourceSpecGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound})
+				s.SetConditionFalse(infrav1.AdoptedCondition, infrav1.AdoptionFailedReason, clusterv1.ConditionSeverityError,
+					"managed cluster my-rg/my-managedcluster does not exist and cannot be adopted")
+			},
+		},
+		{
+			name:          "fail to get managed cluster to adopt",
+			expectedError: "failed to get managed cluster to adopt: internal server error",
+			expect: func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, g *mock_async.MockGetterMockRecorder, spec *mock_azure.MockResourceSpecGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), gomock.Any()).Return(nil, errors.New("internal server error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+			specMock.EXPECT().ResourceName().Return("my-managedcluster").AnyTimes()
+			specMock.EXPECT().ResourceGroupName().Return("my-rg").AnyTimes()
+
+			tc.expect(scopeMock.EXPECT(), getterMock.EXPECT(), specMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				Getter: getterMock,
+			}
+
+			err := s.adoptManagedCluster(context.TODO(), &fakeDriftReporter{MockResourceSpecGetter: specMock, drift: tc.drift})
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+// fakeTokenCredential is an azcore.TokenCredential returning a fixed token.
+type fakeTokenCredential struct {
+	token     string
+	expiresOn time.Time
+	err       error
+}
+
+func (f fakeTokenCredential) GetToken(_ context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if len(opts.Scopes) != 1 || opts.Scopes[0] != aksAADServerAppID+"/.default" {
+		return azcore.AccessToken{}, errors.New("unexpected token scopes")
+	}
+	return azcore.AccessToken{Token: f.token, ExpiresOn: f.expiresOn}, f.err
+}
+
+func TestReconcileKubeconfigs(t *testing.T) {
+	userKubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: my-managedcluster
+  cluster:
+    server: https://my-managedcluster-fqdn:443
+contexts:
+- name: my-managedcluster
+  context:
+    cluster: my-managedcluster
+    user: clusterUser_my-rg_my-managedcluster
+current-context: my-managedcluster
+users:
+- name: clusterUser_my-rg_my-managedcluster
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: kubelogin
+      args:
+      - get-token
+`)
+
+	tokenExpiry := time.Date(2023, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(g *WithT, m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder)
+	}{
+		{
+			name:          "admin kubeconfig is fetched for clusters without AAD",
+			expectedError: "",
+			expect: func(g *WithT, m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.IsAADEnabled().Return(false)
+				s.AreLocalAccountsDisabled().Return(false)
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
+				s.SetKubeConfigData([]byte("credentials"))
+			},
+		},
+		{
+			name:          "user kubeconfig is fetched for AAD clusters",
+			expectedError: "",
+			expect: func(g *WithT, m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.IsAADEnabled().Return(true)
+				m.GetUserCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(userKubeconfig, nil)
+				s.SetUserKubeConfigData(userKubeconfig)
+				s.AreLocalAccountsDisabled().Return(false)
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
+				s.SetKubeConfigData([]byte("credentials"))
+			},
+		},
+		{
+			name:          "kubeconfig authenticates with a token of the cluster identity when local accounts are disabled",
+			expectedError: "",
+			expect: func(g *WithT, m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.IsAADEnabled().Return(true)
+				m.GetUserCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(userKubeconfig, nil)
+				s.SetUserKubeConfigData(userKubeconfig)
+				s.AreLocalAccountsDisabled().Return(true)
+				s.Token().Return(fakeTokenCredential{token: "my-token", expiresOn: tokenExpiry})
+				s.SetKubeConfigData(gomock.Any()).Do(func(data []byte) {
+					config, err := clientcmd.Load(data)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(config.Clusters).To(HaveKey("my-managedcluster"))
+					g.Expect(config.AuthInfos).To(HaveLen(1))
+					g.Expect(config.AuthInfos["clusterUser_my-rg_my-managedcluster"].Token).To(Equal("my-token"))
+					g.Expect(config.AuthInfos["clusterUser_my-rg_my-managedcluster"].Exec).To(BeNil())
+				})
+				s.SetKubeConfigTokenExpiry(tokenExpiry)
+			},
+		},
+		{
+			name:          "fail to get a token of the cluster identity",
+			expectedError: "failed to get token for the managed cluster: authentication failed",
+			expect: func(g *WithT, m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.IsAADEnabled().Return(true)
+				m.GetUserCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(userKubeconfig, nil)
+				s.SetUserKubeConfigData(userKubeconfig)
+				s.AreLocalAccountsDisabled().Return(true)
+				s.Token().Return(fakeTokenCredential{err: errors.New("authentication failed")})
+			},
+		},
+		{
+			name:          "fail to get the user kubeconfig",
+			expectedError: "internal server error",
+			expect: func(g *WithT, m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.IsAADEnabled().Return(true)
+				m.GetUserCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(nil, errors.New("internal server error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+			credsGetterMock := mock_managedclusters.NewMockCredentialGetter(mockCtrl)
+
+			tc.expect(g, credsGetterMock.EXPECT(), scopeMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				CredentialGetter: credsGetterMock,
+			}
+
+			err := s.reconcileKubeconfigs(context.TODO(), fakeManagedClusterSpec)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestMonitoringStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		managedCluster armcontainerservice.ManagedCluster
+		expected       *infrav1.ManagedClusterMonitoringStatus
+	}{
+		{
+			name:           "managed cluster without monitoring",
+			managedCluster: armcontainerservice.ManagedCluster{Properties: &armcontainerservice.ManagedClusterProperties{}},
+			expected:       nil,
+		},
+		{
+			name: "managed cluster with a disabled omsagent add-on",
+			managedCluster: armcontainerservice.ManagedCluster{Properties: &armcontainerservice.ManagedClusterProperties{
+				AddonProfiles: map[string]*armcontainerservice.ManagedClusterAddonProfile{
+					omsAgentAddonName: {
+						Enabled: ptr.To(false),
+						Config:  map[string]*string{omsAgentWorkspaceConfigKey: ptr.To("my-workspace")},
+					},
+				},
+			}},
+			expected: nil,
+		},
+		{
+			name: "managed cluster with Container Insights and managed Prometheus",
+			managedCluster: armcontainerservice.ManagedCluster{Properties: &armcontainerservice.ManagedClusterProperties{
+				AddonProfiles: map[string]*armcontainerservice.ManagedClusterAddonProfile{
+					omsAgentAddonName: {
+						Enabled: ptr.To(true),
+						Config:  map[string]*string{"logAnalyticsWorkspaceResourceId": ptr.To("my-workspace")},
+					},
+				},
+				AzureMonitorProfile: &armcontainerservice.ManagedClusterAzureMonitorProfile{
+					Metrics: &armcontainerservice.ManagedClusterAzureMonitorProfileMetrics{Enabled: ptr.To(true)},
+				},
+			}},
+			expected: &infrav1.ManagedClusterMonitoringStatus{
+				LogAnalyticsWorkspaceResourceID: "my-workspace",
+				ManagedPrometheusEnabled:        true,
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			g.Expect(monitoringStatus(tc.managedCluster)).To(Equal(tc.expected))
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -200,3 +699,13 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+// fakeDriftReporter is a mocked resource spec which reports a fixed drift.
+type fakeDriftReporter struct {
+	*mock_azure.MockResourceSpecGetter
+	drift []string
+}
+
+func (f *fakeDriftReporter) Drift() []string {
+	return f.drift
+}
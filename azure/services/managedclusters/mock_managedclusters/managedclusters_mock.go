@@ -25,7 +25,9 @@ limitations under the License.
 package mock_managedclusters
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	autorest "github.com/Azure/go-autorest/autorest"
@@ -59,6 +61,34 @@ func (m *MockManagedClusterScope) EXPECT() *MockManagedClusterScopeMockRecorder
 	return m.recorder
 }
 
+// AdoptAgentPools mocks base method.
+func (m *MockManagedClusterScope) AdoptAgentPools(arg0 context.Context, arg1 []azure.AdoptedAgentPool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdoptAgentPools", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdoptAgentPools indicates an expected call of AdoptAgentPools.
+func (mr *MockManagedClusterScopeMockRecorder) AdoptAgentPools(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdoptAgentPools", reflect.TypeOf((*MockManagedClusterScope)(nil).AdoptAgentPools), arg0, arg1)
+}
+
+// AreLocalAccountsDisabled mocks base method.
+func (m *MockManagedClusterScope) AreLocalAccountsDisabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AreLocalAccountsDisabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// AreLocalAccountsDisabled indicates an expected call of AreLocalAccountsDisabled.
+func (mr *MockManagedClusterScopeMockRecorder) AreLocalAccountsDisabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreLocalAccountsDisabled", reflect.TypeOf((*MockManagedClusterScope)(nil).AreLocalAccountsDisabled))
+}
+
 // Authorizer mocks base method.
 func (m *MockManagedClusterScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -141,6 +171,20 @@ func (mr *MockManagedClusterScopeMockRecorder) DeleteLongRunningOperationState(a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockManagedClusterScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DesiredPowerState mocks base method.
+func (m *MockManagedClusterScope) DesiredPowerState() *v1beta1.PowerState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DesiredPowerState")
+	ret0, _ := ret[0].(*v1beta1.PowerState)
+	return ret0
+}
+
+// DesiredPowerState indicates an expected call of DesiredPowerState.
+func (mr *MockManagedClusterScopeMockRecorder) DesiredPowerState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DesiredPowerState", reflect.TypeOf((*MockManagedClusterScope)(nil).DesiredPowerState))
+}
+
 // GetKubeConfigData mocks base method.
 func (m *MockManagedClusterScope) GetKubeConfigData() []byte {
 	m.ctrl.T.Helper()
@@ -169,6 +213,20 @@ func (mr *MockManagedClusterScopeMockRecorder) GetLongRunningOperationState(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLongRunningOperationState", reflect.TypeOf((*MockManagedClusterScope)(nil).GetLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// GetUserKubeConfigData mocks base method.
+func (m *MockManagedClusterScope) GetUserKubeConfigData() []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserKubeConfigData")
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// GetUserKubeConfigData indicates an expected call of GetUserKubeConfigData.
+func (mr *MockManagedClusterScopeMockRecorder) GetUserKubeConfigData() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserKubeConfigData", reflect.TypeOf((*MockManagedClusterScope)(nil).GetUserKubeConfigData))
+}
+
 // HashKey mocks base method.
 func (m *MockManagedClusterScope) HashKey() string {
 	m.ctrl.T.Helper()
@@ -183,6 +241,34 @@ func (mr *MockManagedClusterScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockManagedClusterScope)(nil).HashKey))
 }
 
+// IsAADEnabled mocks base method.
+func (m *MockManagedClusterScope) IsAADEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAADEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAADEnabled indicates an expected call of IsAADEnabled.
+func (mr *MockManagedClusterScopeMockRecorder) IsAADEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAADEnabled", reflect.TypeOf((*MockManagedClusterScope)(nil).IsAADEnabled))
+}
+
+// IsAdoptingManagedCluster mocks base method.
+func (m *MockManagedClusterScope) IsAdoptingManagedCluster() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdoptingManagedCluster")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAdoptingManagedCluster indicates an expected call of IsAdoptingManagedCluster.
+func (mr *MockManagedClusterScopeMockRecorder) IsAdoptingManagedCluster() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdoptingManagedCluster", reflect.TypeOf((*MockManagedClusterScope)(nil).IsAdoptingManagedCluster))
+}
+
 // MakeEmptyKubeConfigSecret mocks base method.
 func (m *MockManagedClusterScope) MakeEmptyKubeConfigSecret() v1.Secret {
 	m.ctrl.T.Helper()
@@ -197,6 +283,20 @@ func (mr *MockManagedClusterScopeMockRecorder) MakeEmptyKubeConfigSecret() *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeEmptyKubeConfigSecret", reflect.TypeOf((*MockManagedClusterScope)(nil).MakeEmptyKubeConfigSecret))
 }
 
+// MakeEmptyUserKubeConfigSecret mocks base method.
+func (m *MockManagedClusterScope) MakeEmptyUserKubeConfigSecret() v1.Secret {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeEmptyUserKubeConfigSecret")
+	ret0, _ := ret[0].(v1.Secret)
+	return ret0
+}
+
+// MakeEmptyUserKubeConfigSecret indicates an expected call of MakeEmptyUserKubeConfigSecret.
+func (mr *MockManagedClusterScopeMockRecorder) MakeEmptyUserKubeConfigSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeEmptyUserKubeConfigSecret", reflect.TypeOf((*MockManagedClusterScope)(nil).MakeEmptyUserKubeConfigSecret))
+}
+
 // ManagedClusterSpec mocks base method.
 func (m *MockManagedClusterScope) ManagedClusterSpec() azure.ResourceSpecGetter {
 	m.ctrl.T.Helper()
@@ -211,6 +311,42 @@ func (mr *MockManagedClusterScopeMockRecorder) ManagedClusterSpec() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManagedClusterSpec", reflect.TypeOf((*MockManagedClusterScope)(nil).ManagedClusterSpec))
 }
 
+// SetAutoUpgradeVersionStatus mocks base method.
+func (m *MockManagedClusterScope) SetAutoUpgradeVersionStatus(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAutoUpgradeVersionStatus", arg0)
+}
+
+// SetAutoUpgradeVersionStatus indicates an expected call of SetAutoUpgradeVersionStatus.
+func (mr *MockManagedClusterScopeMockRecorder) SetAutoUpgradeVersionStatus(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoUpgradeVersionStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).SetAutoUpgradeVersionStatus), arg0)
+}
+
+// SetConditionFalse mocks base method.
+func (m *MockManagedClusterScope) SetConditionFalse(arg0 v1beta10.ConditionType, arg1 string, arg2 v1beta10.ConditionSeverity, arg3 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetConditionFalse", arg0, arg1, arg2, arg3)
+}
+
+// SetConditionFalse indicates an expected call of SetConditionFalse.
+func (mr *MockManagedClusterScopeMockRecorder) SetConditionFalse(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConditionFalse", reflect.TypeOf((*MockManagedClusterScope)(nil).SetConditionFalse), arg0, arg1, arg2, arg3)
+}
+
+// SetConditionTrue mocks base method.
+func (m *MockManagedClusterScope) SetConditionTrue(arg0 v1beta10.ConditionType) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetConditionTrue", arg0)
+}
+
+// SetConditionTrue indicates an expected call of SetConditionTrue.
+func (mr *MockManagedClusterScopeMockRecorder) SetConditionTrue(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConditionTrue", reflect.TypeOf((*MockManagedClusterScope)(nil).SetConditionTrue), arg0)
+}
+
 // SetControlPlaneEndpoint mocks base method.
 func (m *MockManagedClusterScope) SetControlPlaneEndpoint(arg0 v1beta10.APIEndpoint) {
 	m.ctrl.T.Helper()
@@ -223,6 +359,18 @@ func (mr *MockManagedClusterScopeMockRecorder) SetControlPlaneEndpoint(arg0 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetControlPlaneEndpoint", reflect.TypeOf((*MockManagedClusterScope)(nil).SetControlPlaneEndpoint), arg0)
 }
 
+// SetDriftDetected mocks base method.
+func (m *MockManagedClusterScope) SetDriftDetected(arg0 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDriftDetected", arg0)
+}
+
+// SetDriftDetected indicates an expected call of SetDriftDetected.
+func (mr *MockManagedClusterScopeMockRecorder) SetDriftDetected(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDriftDetected", reflect.TypeOf((*MockManagedClusterScope)(nil).SetDriftDetected), arg0)
+}
+
 // SetKubeConfigData mocks base method.
 func (m *MockManagedClusterScope) SetKubeConfigData(arg0 []byte) {
 	m.ctrl.T.Helper()
@@ -235,6 +383,18 @@ func (mr *MockManagedClusterScopeMockRecorder) SetKubeConfigData(arg0 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKubeConfigData", reflect.TypeOf((*MockManagedClusterScope)(nil).SetKubeConfigData), arg0)
 }
 
+// SetKubeConfigTokenExpiry mocks base method.
+func (m *MockManagedClusterScope) SetKubeConfigTokenExpiry(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetKubeConfigTokenExpiry", arg0)
+}
+
+// SetKubeConfigTokenExpiry indicates an expected call of SetKubeConfigTokenExpiry.
+func (mr *MockManagedClusterScopeMockRecorder) SetKubeConfigTokenExpiry(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKubeConfigTokenExpiry", reflect.TypeOf((*MockManagedClusterScope)(nil).SetKubeConfigTokenExpiry), arg0)
+}
+
 // SetKubeletIdentity mocks base method.
 func (m *MockManagedClusterScope) SetKubeletIdentity(arg0 string) {
 	m.ctrl.T.Helper()
@@ -259,6 +419,18 @@ func (mr *MockManagedClusterScopeMockRecorder) SetLongRunningOperationState(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockManagedClusterScope)(nil).SetLongRunningOperationState), arg0)
 }
 
+// SetMonitoringStatus mocks base method.
+func (m *MockManagedClusterScope) SetMonitoringStatus(arg0 *v1beta1.ManagedClusterMonitoringStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMonitoringStatus", arg0)
+}
+
+// SetMonitoringStatus indicates an expected call of SetMonitoringStatus.
+func (mr *MockManagedClusterScopeMockRecorder) SetMonitoringStatus(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMonitoringStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).SetMonitoringStatus), arg0)
+}
+
 // SetOIDCIssuerProfileStatus mocks base method.
 func (m *MockManagedClusterScope) SetOIDCIssuerProfileStatus(arg0 *v1beta1.OIDCIssuerProfileStatus) {
 	m.ctrl.T.Helper()
@@ -271,6 +443,30 @@ func (mr *MockManagedClusterScopeMockRecorder) SetOIDCIssuerProfileStatus(arg0 a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOIDCIssuerProfileStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).SetOIDCIssuerProfileStatus), arg0)
 }
 
+// SetPowerStateStatus mocks base method.
+func (m *MockManagedClusterScope) SetPowerStateStatus(arg0 v1beta1.PowerState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPowerStateStatus", arg0)
+}
+
+// SetPowerStateStatus indicates an expected call of SetPowerStateStatus.
+func (mr *MockManagedClusterScopeMockRecorder) SetPowerStateStatus(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPowerStateStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).SetPowerStateStatus), arg0)
+}
+
+// SetUserKubeConfigData mocks base method.
+func (m *MockManagedClusterScope) SetUserKubeConfigData(arg0 []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUserKubeConfigData", arg0)
+}
+
+// SetUserKubeConfigData indicates an expected call of SetUserKubeConfigData.
+func (mr *MockManagedClusterScopeMockRecorder) SetUserKubeConfigData(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserKubeConfigData", reflect.TypeOf((*MockManagedClusterScope)(nil).SetUserKubeConfigData), arg0)
+}
+
 // SubscriptionID mocks base method.
 func (m *MockManagedClusterScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -22,18 +22,28 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/diff"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+const (
+	// omsAgentAddonName is the name of the Container Insights add-on.
+	omsAgentAddonName = "omsagent"
+	// omsAgentWorkspaceConfigKey is the omsagent add-on config key holding the Log Analytics workspace resource ID.
+	omsAgentWorkspaceConfigKey = "logAnalyticsWorkspaceResourceID"
+)
+
 // ManagedClusterSpec contains properties to create a managed cluster.
 type ManagedClusterSpec struct {
 	// Name is the name of this AKS Cluster.
@@ -69,9 +79,12 @@ type ManagedClusterSpec struct {
 	// NetworkPluginMode is the mode the network plugin should use.
 	NetworkPluginMode *infrav1.NetworkPluginMode
 
-	// NetworkPolicy used for building Kubernetes network. Possible values include: 'calico', 'azure'.
+	// NetworkPolicy used for building Kubernetes network. Possible values include: 'calico', 'azure', 'cilium'.
 	NetworkPolicy string
 
+	// NetworkDataplane used for building Kubernetes network. Possible values include: 'azure', 'cilium'.
+	NetworkDataplane *infrav1.NetworkDataplaneType
+
 	// OutboundType used for building Kubernetes network. Possible values include: 'loadBalancer', 'managedNATGateway', 'userAssignedNATGateway', 'userDefinedRouting'.
 	OutboundType *infrav1.ManagedControlPlaneOutboundType
 
@@ -87,6 +100,15 @@ type ManagedClusterSpec struct {
 	// ServiceCIDR is the CIDR block for IP addresses distributed to services
 	ServiceCIDR string
 
+	// PodCIDRs are the CIDR blocks for IP addresses distributed to pods, one per IP family, for dual-stack clusters.
+	PodCIDRs []string
+
+	// ServiceCIDRs are the CIDR blocks for IP addresses distributed to services, one per IP family, for dual-stack clusters.
+	ServiceCIDRs []string
+
+	// IPFamilies are the IP families used by the cluster.
+	IPFamilies []infrav1.IPFamily
+
 	// DNSServiceIP is an IP address assigned to the Kubernetes DNS service
 	DNSServiceIP *string
 
@@ -122,6 +144,24 @@ type ManagedClusterSpec struct {
 
 	// OIDCIssuerProfile is the OIDC issuer profile of the Managed Cluster.
 	OIDCIssuerProfile *OIDCIssuerProfile
+
+	// AutoUpgradeProfile is the auto-upgrade configuration of the Managed Cluster.
+	AutoUpgradeProfile *AutoUpgradeProfile
+
+	// SecurityProfile is the security profile of the Managed Cluster.
+	SecurityProfile *infrav1.ManagedClusterSecurityProfile
+
+	// DisableLocalAccounts disables the static local accounts of the cluster.
+	DisableLocalAccounts *bool
+
+	// Monitoring is the Azure Monitor integration of the Managed Cluster.
+	Monitoring *infrav1.ManagedClusterMonitoring
+
+	// ReportDriftOnly leaves an existing Managed Cluster which differs from the spec as it is.
+	ReportDriftOnly bool
+
+	// drift is the fields of the existing Managed Cluster which differ from the spec, as found by Parameters.
+	drift []string
 }
 
 // HTTPProxyConfig is the HTTP proxy configuration for the cluster.
@@ -243,6 +283,14 @@ type OIDCIssuerProfile struct {
 	Enabled *bool
 }
 
+// AutoUpgradeProfile is the auto-upgrade configuration of the Managed Cluster.
+type AutoUpgradeProfile struct {
+	// UpgradeChannel is the channel used to automatically upgrade the Kubernetes version of the cluster.
+	UpgradeChannel *infrav1.UpgradeChannel
+	// NodeOSUpgradeChannel is the manner in which the OS on the nodes is updated.
+	NodeOSUpgradeChannel *infrav1.NodeOSUpgradeChannel
+}
+
 var _ azure.ResourceSpecGetterWithHeaders = (*ManagedClusterSpec)(nil)
 
 // ResourceName returns the name of the AKS cluster.
@@ -265,6 +313,12 @@ func (s *ManagedClusterSpec) CustomHeaders() map[string]string {
 	return s.Headers
 }
 
+// Drift returns the fields of the existing managed cluster which differ from the spec. It is nil until Parameters
+// compares the spec to an existing managed cluster.
+func (s *ManagedClusterSpec) Drift() []string {
+	return s.drift
+}
+
 // buildAutoScalerProfile builds the AutoScalerProfile for the ManagedClusterProperties.
 func buildAutoScalerProfile(autoScalerProfile *AutoScalerProfile) *armcontainerservice.ManagedClusterPropertiesAutoScalerProfile {
 	if autoScalerProfile == nil {
@@ -358,10 +412,29 @@ func (s *ManagedClusterSpec) Parameters(ctx context.Context, existing interface{
 		managedCluster.Properties.NetworkProfile.NetworkPluginMode = ptr.To(armcontainerservice.NetworkPluginMode(*s.NetworkPluginMode))
 	}
 
+	if s.NetworkDataplane != nil {
+		managedCluster.Properties.NetworkProfile.NetworkDataplane = ptr.To(armcontainerservice.NetworkDataplane(*s.NetworkDataplane))
+	}
+
+	if len(s.IPFamilies) > 0 {
+		managedCluster.Properties.NetworkProfile.IPFamilies = make([]*armcontainerservice.IPFamily, 0, len(s.IPFamilies))
+		for _, family := range s.IPFamilies {
+			managedCluster.Properties.NetworkProfile.IPFamilies = append(managedCluster.Properties.NetworkProfile.IPFamilies, ptr.To(armcontainerservice.IPFamily(family)))
+		}
+	}
+
 	if s.PodCIDR != "" {
 		managedCluster.Properties.NetworkProfile.PodCidr = &s.PodCIDR
 	}
 
+	if len(s.PodCIDRs) > 0 {
+		managedCluster.Properties.NetworkProfile.PodCidrs = azure.PtrSlice(&s.PodCIDRs)
+	}
+
+	if len(s.ServiceCIDRs) > 0 {
+		managedCluster.Properties.NetworkProfile.ServiceCidrs = azure.PtrSlice(&s.ServiceCIDRs)
+	}
+
 	if s.ServiceCIDR != "" {
 		if s.DNSServiceIP == nil {
 			managedCluster.Properties.NetworkProfile.ServiceCidr = &s.ServiceCIDR
@@ -466,6 +539,48 @@ func (s *ManagedClusterSpec) Parameters(ctx context.Context, existing interface{
 		}
 	}
 
+	if s.DisableLocalAccounts != nil {
+		managedCluster.Properties.DisableLocalAccounts = s.DisableLocalAccounts
+	}
+
+	if s.Monitoring != nil {
+		if s.Monitoring.LogAnalyticsWorkspaceResourceID != nil {
+			if managedCluster.Properties.AddonProfiles == nil {
+				managedCluster.Properties.AddonProfiles = map[string]*armcontainerservice.ManagedClusterAddonProfile{}
+			}
+			managedCluster.Properties.AddonProfiles[omsAgentAddonName] = &armcontainerservice.ManagedClusterAddonProfile{
+				Enabled: ptr.To(true),
+				Config: map[string]*string{
+					omsAgentWorkspaceConfigKey: s.Monitoring.LogAnalyticsWorkspaceResourceID,
+				},
+			}
+		}
+		if prometheus := s.Monitoring.ManagedPrometheus; prometheus != nil {
+			managedCluster.Properties.AzureMonitorProfile = &armcontainerservice.ManagedClusterAzureMonitorProfile{
+				Metrics: &armcontainerservice.ManagedClusterAzureMonitorProfileMetrics{
+					Enabled: ptr.To(prometheus.Enabled),
+				},
+			}
+			if prometheus.MetricLabelsAllowlist != nil || prometheus.MetricAnnotationsAllowList != nil {
+				managedCluster.Properties.AzureMonitorProfile.Metrics.KubeStateMetrics = &armcontainerservice.ManagedClusterAzureMonitorProfileKubeStateMetrics{
+					MetricLabelsAllowlist:      prometheus.MetricLabelsAllowlist,
+					MetricAnnotationsAllowList: prometheus.MetricAnnotationsAllowList,
+				}
+			}
+		}
+	}
+
+	if s.SecurityProfile != nil {
+		managedCluster.Properties.SecurityProfile = getSecurityProfile(s.SecurityProfile)
+	}
+
+	if s.AutoUpgradeProfile != nil {
+		managedCluster.Properties.AutoUpgradeProfile = &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+			UpgradeChannel:       azure.AliasOrNil[armcontainerservice.UpgradeChannel]((*string)(s.AutoUpgradeProfile.UpgradeChannel)),
+			NodeOSUpgradeChannel: azure.AliasOrNil[armcontainerservice.NodeOSUpgradeChannel]((*string)(s.AutoUpgradeProfile.NodeOSUpgradeChannel)),
+		}
+	}
+
 	if existing != nil {
 		existingMC, ok := existing.(armcontainerservice.ManagedCluster)
 		if !ok {
@@ -490,6 +605,30 @@ func (s *ManagedClusterSpec) Parameters(ctx context.Context, existing interface{
 		// AgentPool changes are managed through AMMP.
 		managedCluster.Properties.AgentPoolProfiles = existingMC.Properties.AgentPoolProfiles
 
+		// Auto-upgrade channels which are not set in the spec are managed outside of CAPZ, so carry over
+		// their current values instead of resetting them.
+		if existingMC.Properties.AutoUpgradeProfile != nil {
+			if managedCluster.Properties.AutoUpgradeProfile == nil {
+				managedCluster.Properties.AutoUpgradeProfile = &armcontainerservice.ManagedClusterAutoUpgradeProfile{}
+			}
+			if managedCluster.Properties.AutoUpgradeProfile.UpgradeChannel == nil {
+				managedCluster.Properties.AutoUpgradeProfile.UpgradeChannel = existingMC.Properties.AutoUpgradeProfile.UpgradeChannel
+			}
+			if managedCluster.Properties.AutoUpgradeProfile.NodeOSUpgradeChannel == nil {
+				managedCluster.Properties.AutoUpgradeProfile.NodeOSUpgradeChannel = existingMC.Properties.AutoUpgradeProfile.NodeOSUpgradeChannel
+			}
+		}
+
+		// When AKS auto-upgrades the cluster it moves past the version in the spec. Downgrades are not
+		// supported, so keep the current version instead of fighting the upgrade.
+		if upgradesKubernetesVersion(managedCluster.Properties.AutoUpgradeProfile) &&
+			isNewerVersion(existingMC.Properties.KubernetesVersion, managedCluster.Properties.KubernetesVersion) {
+			log.V(4).Info("managed cluster was auto-upgraded past the desired version, keeping the current version",
+				"desired", ptr.Deref(managedCluster.Properties.KubernetesVersion, ""),
+				"current", ptr.Deref(existingMC.Properties.KubernetesVersion, ""))
+			managedCluster.Properties.KubernetesVersion = existingMC.Properties.KubernetesVersion
+		}
+
 		// if the AuthorizedIPRanges is nil in the user-updated spec, but not nil in the existing spec, then
 		// we need to set the AuthorizedIPRanges to empty array ([]*string{}) once so that the Azure API will
 		// update the existing authorized IP ranges to nil.
@@ -500,12 +639,22 @@ func (s *ManagedClusterSpec) Parameters(ctx context.Context, existing interface{
 			}
 		}
 
-		diff := computeDiffOfNormalizedClusters(managedCluster, existingMC)
-		if diff == "" {
+		difference, drift := computeDiffOfNormalizedClusters(managedCluster, existingMC)
+		s.drift = drift
+		if difference == "" {
 			log.V(4).Info("no changes found between user-updated spec and existing spec")
 			return nil, nil
 		}
-		log.V(4).Info("found a diff between the desired spec and the existing managed cluster", "difference", diff)
+		log.V(4).Info("found a diff between the desired spec and the existing managed cluster", "difference", difference)
+		// A stopped managed cluster cannot be updated until it is started again.
+		if existingMC.Properties.PowerState != nil && ptr.Deref(existingMC.Properties.PowerState.Code, "") == armcontainerservice.CodeStopped {
+			log.V(4).Info("managed cluster is stopped, skipping update")
+			return nil, nil
+		}
+		if s.ReportDriftOnly {
+			log.V(4).Info("drift is only reported, skipping update", "fields", drift)
+			return nil, nil
+		}
 	} else {
 		// Add all agent pools to cluster spec that will be submitted to the API
 		agentPoolSpecs, err := s.GetAllAgentPools()
@@ -562,7 +711,9 @@ func convertToResourceReferences(resources []string) []*armcontainerservice.Reso
 	return resourceReferences
 }
 
-func computeDiffOfNormalizedClusters(managedCluster armcontainerservice.ManagedCluster, existingMC armcontainerservice.ManagedCluster) string {
+// computeDiffOfNormalizedClusters returns the diff between the desired and existing managed cluster, along with the
+// fields which differ.
+func computeDiffOfNormalizedClusters(managedCluster armcontainerservice.ManagedCluster, existingMC armcontainerservice.ManagedCluster) (string, []string) {
 	// Normalize properties for the desired (CR spec) and existing managed
 	// cluster, so that we check only those fields that were specified in
 	// the initial CreateOrUpdate request and that can be modified.
@@ -737,8 +888,152 @@ func computeDiffOfNormalizedClusters(managedCluster armcontainerservice.ManagedC
 		}
 	}
 
-	diff := cmp.Diff(clusterNormalized, existingMCClusterNormalized)
-	return diff
+	if managedCluster.Properties.AutoUpgradeProfile != nil {
+		clusterNormalized.Properties.AutoUpgradeProfile = &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+			UpgradeChannel:       managedCluster.Properties.AutoUpgradeProfile.UpgradeChannel,
+			NodeOSUpgradeChannel: managedCluster.Properties.AutoUpgradeProfile.NodeOSUpgradeChannel,
+		}
+	}
+	if existingMC.Properties.AutoUpgradeProfile != nil {
+		existingMCClusterNormalized.Properties.AutoUpgradeProfile = &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+			UpgradeChannel:       existingMC.Properties.AutoUpgradeProfile.UpgradeChannel,
+			NodeOSUpgradeChannel: existingMC.Properties.AutoUpgradeProfile.NodeOSUpgradeChannel,
+		}
+	}
+
+	if managedCluster.Properties.DisableLocalAccounts != nil {
+		clusterNormalized.Properties.DisableLocalAccounts = managedCluster.Properties.DisableLocalAccounts
+		existingMCClusterNormalized.Properties.DisableLocalAccounts = ptr.To(ptr.Deref(existingMC.Properties.DisableLocalAccounts, false))
+	}
+
+	if oms := managedCluster.Properties.AddonProfiles[omsAgentAddonName]; oms != nil {
+		clusterNormalized.Properties.AddonProfiles = map[string]*armcontainerservice.ManagedClusterAddonProfile{
+			omsAgentAddonName: normalizeOMSAgentAddonProfile(oms),
+		}
+		existingMCClusterNormalized.Properties.AddonProfiles = map[string]*armcontainerservice.ManagedClusterAddonProfile{
+			omsAgentAddonName: normalizeOMSAgentAddonProfile(existingMC.Properties.AddonProfiles[omsAgentAddonName]),
+		}
+	}
+
+	if managedCluster.Properties.AzureMonitorProfile != nil {
+		clusterNormalized.Properties.AzureMonitorProfile, existingMCClusterNormalized.Properties.AzureMonitorProfile =
+			normalizeAzureMonitorProfiles(managedCluster.Properties.AzureMonitorProfile, existingMC.Properties.AzureMonitorProfile)
+	}
+
+	if managedCluster.Properties.SecurityProfile != nil {
+		clusterNormalized.Properties.SecurityProfile, existingMCClusterNormalized.Properties.SecurityProfile =
+			normalizeSecurityProfiles(managedCluster.Properties.SecurityProfile, existingMC.Properties.SecurityProfile)
+	}
+
+	return cmp.Diff(clusterNormalized, existingMCClusterNormalized), diff.Fields(clusterNormalized, existingMCClusterNormalized)
+}
+
+// normalizeOMSAgentAddonProfile returns the omsagent add-on profile reduced to the settings managed by CAPZ.
+// The workspace resource ID is compared case-insensitively as AKS may change its casing.
+func normalizeOMSAgentAddonProfile(profile *armcontainerservice.ManagedClusterAddonProfile) *armcontainerservice.ManagedClusterAddonProfile {
+	if profile == nil {
+		return &armcontainerservice.ManagedClusterAddonProfile{Enabled: ptr.To(false)}
+	}
+	normalized := &armcontainerservice.ManagedClusterAddonProfile{
+		Enabled: ptr.To(ptr.Deref(profile.Enabled, false)),
+	}
+	for key, value := range profile.Config {
+		if strings.EqualFold(key, omsAgentWorkspaceConfigKey) && value != nil {
+			normalized.Config = map[string]*string{omsAgentWorkspaceConfigKey: ptr.To(strings.ToLower(*value))}
+		}
+	}
+	return normalized
+}
+
+// normalizeAzureMonitorProfiles returns the desired and existing Azure Monitor profiles reduced to the settings
+// managed by CAPZ. The kube-state-metrics settings are taken from the existing profile when they are not set.
+func normalizeAzureMonitorProfiles(desired, existing *armcontainerservice.ManagedClusterAzureMonitorProfile) (desiredNormalized, existingNormalized *armcontainerservice.ManagedClusterAzureMonitorProfile) {
+	desiredMetrics := &armcontainerservice.ManagedClusterAzureMonitorProfileMetrics{}
+	if desired.Metrics != nil {
+		desiredMetrics.Enabled = ptr.To(ptr.Deref(desired.Metrics.Enabled, false))
+		desiredMetrics.KubeStateMetrics = desired.Metrics.KubeStateMetrics
+	}
+	existingMetrics := &armcontainerservice.ManagedClusterAzureMonitorProfileMetrics{Enabled: ptr.To(false)}
+	if existing != nil && existing.Metrics != nil {
+		existingMetrics.Enabled = ptr.To(ptr.Deref(existing.Metrics.Enabled, false))
+		if desiredMetrics.KubeStateMetrics != nil {
+			existingMetrics.KubeStateMetrics = existing.Metrics.KubeStateMetrics
+		}
+	}
+	return &armcontainerservice.ManagedClusterAzureMonitorProfile{Metrics: desiredMetrics},
+		&armcontainerservice.ManagedClusterAzureMonitorProfile{Metrics: existingMetrics}
+}
+
+// getSecurityProfile returns the AKS security profile for the given security profile.
+func getSecurityProfile(profile *infrav1.ManagedClusterSecurityProfile) *armcontainerservice.ManagedClusterSecurityProfile {
+	securityProfile := &armcontainerservice.ManagedClusterSecurityProfile{}
+	if kms := profile.AzureKeyVaultKms; kms != nil {
+		securityProfile.AzureKeyVaultKms = &armcontainerservice.AzureKeyVaultKms{
+			Enabled:               ptr.To(kms.Enabled),
+			KeyID:                 azure.AliasOrNil[string](&kms.KeyID),
+			KeyVaultNetworkAccess: azure.AliasOrNil[armcontainerservice.KeyVaultNetworkAccessTypes]((*string)(kms.KeyVaultNetworkAccess)),
+			KeyVaultResourceID:    kms.KeyVaultResourceID,
+		}
+	}
+	if defender := profile.Defender; defender != nil {
+		securityProfile.Defender = &armcontainerservice.ManagedClusterSecurityProfileDefender{
+			SecurityMonitoring: &armcontainerservice.ManagedClusterSecurityProfileDefenderSecurityMonitoring{
+				Enabled: ptr.To(defender.SecurityMonitoring.Enabled),
+			},
+		}
+		// AKS rejects a Log Analytics workspace when security monitoring is disabled.
+		if defender.SecurityMonitoring.Enabled {
+			securityProfile.Defender.LogAnalyticsWorkspaceResourceID = ptr.To(defender.LogAnalyticsWorkspaceResourceID)
+		}
+	}
+	if imageCleaner := profile.ImageCleaner; imageCleaner != nil {
+		securityProfile.ImageCleaner = &armcontainerservice.ManagedClusterSecurityProfileImageCleaner{
+			Enabled:       ptr.To(imageCleaner.Enabled),
+			IntervalHours: imageCleaner.IntervalHours,
+		}
+	}
+	if workloadIdentity := profile.WorkloadIdentity; workloadIdentity != nil {
+		securityProfile.WorkloadIdentity = &armcontainerservice.ManagedClusterSecurityProfileWorkloadIdentity{
+			Enabled: ptr.To(workloadIdentity.Enabled),
+		}
+	}
+	return securityProfile
+}
+
+// normalizeSecurityProfiles returns the desired and existing security profiles reduced to the features set in the
+// desired profile. Values defaulted by AKS are taken from the existing profile when they are not set.
+func normalizeSecurityProfiles(desired, existing *armcontainerservice.ManagedClusterSecurityProfile) (desiredNormalized, existingNormalized *armcontainerservice.ManagedClusterSecurityProfile) {
+	if existing == nil {
+		existing = &armcontainerservice.ManagedClusterSecurityProfile{}
+	}
+	desiredNormalized = &armcontainerservice.ManagedClusterSecurityProfile{}
+	existingNormalized = &armcontainerservice.ManagedClusterSecurityProfile{}
+
+	if desired.AzureKeyVaultKms != nil {
+		kms := *desired.AzureKeyVaultKms
+		if kms.KeyVaultNetworkAccess == nil && existing.AzureKeyVaultKms != nil {
+			kms.KeyVaultNetworkAccess = existing.AzureKeyVaultKms.KeyVaultNetworkAccess
+		}
+		desiredNormalized.AzureKeyVaultKms = &kms
+		existingNormalized.AzureKeyVaultKms = existing.AzureKeyVaultKms
+	}
+	if desired.Defender != nil {
+		desiredNormalized.Defender = desired.Defender
+		existingNormalized.Defender = existing.Defender
+	}
+	if desired.ImageCleaner != nil {
+		imageCleaner := *desired.ImageCleaner
+		if imageCleaner.IntervalHours == nil && existing.ImageCleaner != nil {
+			imageCleaner.IntervalHours = existing.ImageCleaner.IntervalHours
+		}
+		desiredNormalized.ImageCleaner = &imageCleaner
+		existingNormalized.ImageCleaner = existing.ImageCleaner
+	}
+	if desired.WorkloadIdentity != nil {
+		desiredNormalized.WorkloadIdentity = desired.WorkloadIdentity
+		existingNormalized.WorkloadIdentity = existing.WorkloadIdentity
+	}
+	return desiredNormalized, existingNormalized
 }
 
 func getIdentity(identity *infrav1.Identity) (managedClusterIdentity *armcontainerservice.ManagedClusterIdentity, err error) {
@@ -761,6 +1056,27 @@ func getIdentity(identity *infrav1.Identity) (managedClusterIdentity *armcontain
 	return
 }
 
+// upgradesKubernetesVersion returns true if the auto-upgrade profile lets AKS upgrade the Kubernetes version of the cluster.
+func upgradesKubernetesVersion(profile *armcontainerservice.ManagedClusterAutoUpgradeProfile) bool {
+	if profile == nil || profile.UpgradeChannel == nil {
+		return false
+	}
+	switch *profile.UpgradeChannel {
+	case armcontainerservice.UpgradeChannelPatch, armcontainerservice.UpgradeChannelRapid, armcontainerservice.UpgradeChannelStable:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNewerVersion returns true if version is a newer Kubernetes version than other.
+func isNewerVersion(version, other *string) bool {
+	if version == nil || other == nil {
+		return false
+	}
+	return semver.Compare("v"+strings.TrimPrefix(*version, "v"), "v"+strings.TrimPrefix(*other, "v")) > 0
+}
+
 // isAuthIPRangesNilOrEmpty returns true if the managed cluster's APIServerAccessProfile or AuthorizedIPRanges is nil or if AuthorizedIPRanges is empty.
 func isAuthIPRangesNilOrEmpty(managedCluster armcontainerservice.ManagedCluster) bool {
 	return managedCluster.Properties.APIServerAccessProfile == nil ||
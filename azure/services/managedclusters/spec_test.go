@@ -485,6 +485,339 @@ func TestParameters(t *testing.T) {
 				g.Expect(*result.(armcontainerservice.ManagedCluster).Properties.OidcIssuerProfile.Enabled).To(BeFalse())
 			},
 		},
+		{
+			name:     "update needed when auto-upgrade channels change",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+				AutoUpgradeProfile: &AutoUpgradeProfile{
+					UpgradeChannel:       ptr.To(infrav1.UpgradeChannelPatch),
+					NodeOSUpgradeChannel: ptr.To(infrav1.NodeOSUpgradeChannelNodeImage),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcontainerservice.ManagedCluster{}))
+				g.Expect(result.(armcontainerservice.ManagedCluster).Properties.AutoUpgradeProfile).To(Equal(&armcontainerservice.ManagedClusterAutoUpgradeProfile{
+					UpgradeChannel:       ptr.To(armcontainerservice.UpgradeChannelPatch),
+					NodeOSUpgradeChannel: ptr.To(armcontainerservice.NodeOSUpgradeChannelNodeImage),
+				}))
+			},
+		},
+		{
+			name:     "auto-upgrade channels not set in the spec are left unchanged",
+			existing: getExistingClusterWithAutoUpgradeProfile("v1.22.0"),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+				AutoUpgradeProfile: &AutoUpgradeProfile{
+					NodeOSUpgradeChannel: ptr.To(infrav1.NodeOSUpgradeChannelNodeImage),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "no update needed when AKS auto-upgraded the cluster past the desired version",
+			existing: getExistingClusterWithAutoUpgradeProfile("v1.22.5"),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "update keeps the auto-upgraded version of the cluster",
+			existing: getExistingClusterWithAutoUpgradeProfile("v1.22.5"),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(false),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcontainerservice.ManagedCluster{}))
+				g.Expect(result.(armcontainerservice.ManagedCluster).Properties.KubernetesVersion).To(Equal(ptr.To("v1.22.5")))
+			},
+		},
+		{
+			name:     "update to a newer version than the auto-upgraded version",
+			existing: getExistingClusterWithAutoUpgradeProfile("v1.22.5"),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.23.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcontainerservice.ManagedCluster{}))
+				g.Expect(result.(armcontainerservice.ManagedCluster).Properties.KubernetesVersion).To(Equal(ptr.To("v1.23.0")))
+			},
+		},
+		{
+			name:     "set network dataplane and dual-stack network profile",
+			existing: nil,
+			spec: &ManagedClusterSpec{
+				Name:              "test-managedcluster",
+				ResourceGroup:     "test-rg",
+				Location:          "test-location",
+				Version:           "v1.22.0",
+				LoadBalancerSKU:   "standard",
+				NetworkPlugin:     "azure",
+				NetworkPluginMode: ptr.To(infrav1.NetworkPluginModeOverlay),
+				NetworkPolicy:     "cilium",
+				NetworkDataplane:  ptr.To(infrav1.NetworkDataplaneTypeCilium),
+				IPFamilies:        []infrav1.IPFamily{infrav1.IPFamilyIPv4, infrav1.IPFamilyIPv6},
+				PodCIDR:           "10.244.0.0/16",
+				PodCIDRs:          []string{"10.244.0.0/16", "fd12:3456:789a::/64"},
+				ServiceCIDR:       "10.96.0.0/16",
+				ServiceCIDRs:      []string{"10.96.0.0/16", "fd12:3456:789a:1::/108"},
+				GetAllAgentPools: func() ([]azure.ResourceSpecGetter, error) {
+					return []azure.ResourceSpecGetter{}, nil
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcontainerservice.ManagedCluster{}))
+				networkProfile := result.(armcontainerservice.ManagedCluster).Properties.NetworkProfile
+				g.Expect(networkProfile.NetworkDataplane).To(Equal(ptr.To(armcontainerservice.NetworkDataplaneCilium)))
+				g.Expect(networkProfile.NetworkPolicy).To(Equal(ptr.To(armcontainerservice.NetworkPolicyCilium)))
+				g.Expect(networkProfile.IPFamilies).To(Equal([]*armcontainerservice.IPFamily{ptr.To(armcontainerservice.IPFamilyIPv4), ptr.To(armcontainerservice.IPFamilyIPv6)}))
+				g.Expect(networkProfile.PodCidr).To(Equal(ptr.To("10.244.0.0/16")))
+				g.Expect(networkProfile.PodCidrs).To(Equal([]*string{ptr.To("10.244.0.0/16"), ptr.To("fd12:3456:789a::/64")}))
+				g.Expect(networkProfile.ServiceCidr).To(Equal(ptr.To("10.96.0.0/16")))
+				g.Expect(networkProfile.ServiceCidrs).To(Equal([]*string{ptr.To("10.96.0.0/16"), ptr.To("fd12:3456:789a:1::/108")}))
+				g.Expect(networkProfile.DNSServiceIP).To(Equal(ptr.To("10.96.0.10")))
+			},
+		},
+		{
+			name:     "update needed when local accounts are disabled",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+				DisableLocalAccounts: ptr.To(true),
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcontainerservice.ManagedCluster{}))
+				g.Expect(result.(armcontainerservice.ManagedCluster).Properties.DisableLocalAccounts).To(Equal(ptr.To(true)))
+			},
+		},
+		{
+			name:     "no update needed when local accounts are explicitly enabled",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+				DisableLocalAccounts: ptr.To(false),
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "no update needed when monitoring matches the existing cluster",
+			existing: getExistingClusterWithMonitoring(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+				Monitoring: &infrav1.ManagedClusterMonitoring{
+					LogAnalyticsWorkspaceResourceID: ptr.To("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.OperationalInsights/workspaces/test-workspace"),
+					ManagedPrometheus: &infrav1.ManagedPrometheus{
+						Enabled: true,
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "update needed when monitoring changes",
+			existing: getExistingClusterWithMonitoring(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+				Monitoring: &infrav1.ManagedClusterMonitoring{
+					LogAnalyticsWorkspaceResourceID: ptr.To("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.OperationalInsights/workspaces/other-workspace"),
+					ManagedPrometheus: &infrav1.ManagedPrometheus{
+						Enabled:               true,
+						MetricLabelsAllowlist: ptr.To("pods=[app]"),
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcontainerservice.ManagedCluster{}))
+				properties := result.(armcontainerservice.ManagedCluster).Properties
+				g.Expect(properties.AddonProfiles).To(HaveKeyWithValue(omsAgentAddonName, &armcontainerservice.ManagedClusterAddonProfile{
+					Enabled: ptr.To(true),
+					Config: map[string]*string{
+						omsAgentWorkspaceConfigKey: ptr.To("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.OperationalInsights/workspaces/other-workspace"),
+					},
+				}))
+				g.Expect(properties.AzureMonitorProfile).To(Equal(&armcontainerservice.ManagedClusterAzureMonitorProfile{
+					Metrics: &armcontainerservice.ManagedClusterAzureMonitorProfileMetrics{
+						Enabled: ptr.To(true),
+						KubeStateMetrics: &armcontainerservice.ManagedClusterAzureMonitorProfileKubeStateMetrics{
+							MetricLabelsAllowlist: ptr.To("pods=[app]"),
+						},
+					},
+				}))
+			},
+		},
+		{
+			name:     "no update needed when security profile matches the values defaulted by AKS",
+			existing: getExistingClusterWithSecurityProfile(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+				SecurityProfile: &infrav1.ManagedClusterSecurityProfile{
+					AzureKeyVaultKms: &infrav1.AzureKeyVaultKms{
+						Enabled: true,
+						KeyID:   "https://my-vault.vault.azure.net/keys/my-key/1",
+					},
+					ImageCleaner: &infrav1.ManagedClusterSecurityProfileImageCleaner{
+						Enabled: true,
+					},
+					WorkloadIdentity: &infrav1.ManagedClusterSecurityProfileWorkloadIdentity{
+						Enabled: true,
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "update needed when security profile changes",
+			existing: getExistingClusterWithSecurityProfile(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: ptr.To(true),
+				},
+				SecurityProfile: &infrav1.ManagedClusterSecurityProfile{
+					Defender: &infrav1.ManagedClusterSecurityProfileDefender{
+						LogAnalyticsWorkspaceResourceID: "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.OperationalInsights/workspaces/test-workspace",
+						SecurityMonitoring: infrav1.ManagedClusterSecurityProfileDefenderSecurityMonitoring{
+							Enabled: true,
+						},
+					},
+					WorkloadIdentity: &infrav1.ManagedClusterSecurityProfileWorkloadIdentity{
+						Enabled: false,
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcontainerservice.ManagedCluster{}))
+				g.Expect(result.(armcontainerservice.ManagedCluster).Properties.SecurityProfile).To(Equal(&armcontainerservice.ManagedClusterSecurityProfile{
+					Defender: &armcontainerservice.ManagedClusterSecurityProfileDefender{
+						LogAnalyticsWorkspaceResourceID: ptr.To("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.OperationalInsights/workspaces/test-workspace"),
+						SecurityMonitoring: &armcontainerservice.ManagedClusterSecurityProfileDefenderSecurityMonitoring{
+							Enabled: ptr.To(true),
+						},
+					},
+					WorkloadIdentity: &armcontainerservice.ManagedClusterSecurityProfileWorkloadIdentity{
+						Enabled: ptr.To(false),
+					},
+				}))
+			},
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -569,6 +902,60 @@ func getExistingCluster() armcontainerservice.ManagedCluster {
 	return mc
 }
 
+func getExistingClusterWithAutoUpgradeProfile(version string) armcontainerservice.ManagedCluster {
+	mc := getExistingCluster()
+	mc.Properties.KubernetesVersion = ptr.To(version)
+	mc.Properties.AutoUpgradeProfile = &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+		UpgradeChannel:       ptr.To(armcontainerservice.UpgradeChannelPatch),
+		NodeOSUpgradeChannel: ptr.To(armcontainerservice.NodeOSUpgradeChannelNodeImage),
+	}
+	return mc
+}
+
+func getExistingClusterWithMonitoring() armcontainerservice.ManagedCluster {
+	mc := getExistingCluster()
+	mc.Properties.AddonProfiles = map[string]*armcontainerservice.ManagedClusterAddonProfile{
+		omsAgentAddonName: {
+			Enabled: ptr.To(true),
+			Config: map[string]*string{
+				omsAgentWorkspaceConfigKey: ptr.To("/subscriptions/123/resourcegroups/test-rg/providers/microsoft.operationalinsights/workspaces/test-workspace"),
+			},
+			Identity: &armcontainerservice.ManagedClusterAddonProfileIdentity{
+				ResourceID: ptr.To("/subscriptions/123/resourceGroups/test-node-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/omsagent-test-managedcluster"),
+			},
+		},
+	}
+	mc.Properties.AzureMonitorProfile = &armcontainerservice.ManagedClusterAzureMonitorProfile{
+		Metrics: &armcontainerservice.ManagedClusterAzureMonitorProfileMetrics{
+			Enabled: ptr.To(true),
+			KubeStateMetrics: &armcontainerservice.ManagedClusterAzureMonitorProfileKubeStateMetrics{
+				MetricLabelsAllowlist:      ptr.To(""),
+				MetricAnnotationsAllowList: ptr.To(""),
+			},
+		},
+	}
+	return mc
+}
+
+func getExistingClusterWithSecurityProfile() armcontainerservice.ManagedCluster {
+	mc := getExistingCluster()
+	mc.Properties.SecurityProfile = &armcontainerservice.ManagedClusterSecurityProfile{
+		AzureKeyVaultKms: &armcontainerservice.AzureKeyVaultKms{
+			Enabled:               ptr.To(true),
+			KeyID:                 ptr.To("https://my-vault.vault.azure.net/keys/my-key/1"),
+			KeyVaultNetworkAccess: ptr.To(armcontainerservice.KeyVaultNetworkAccessTypesPublic),
+		},
+		ImageCleaner: &armcontainerservice.ManagedClusterSecurityProfileImageCleaner{
+			Enabled:       ptr.To(true),
+			IntervalHours: ptr.To[int32](168),
+		},
+		WorkloadIdentity: &armcontainerservice.ManagedClusterSecurityProfileWorkloadIdentity{
+			Enabled: ptr.To(true),
+		},
+	}
+	return mc
+}
+
 func getExistingClusterWithUserAssignedIdentity() armcontainerservice.ManagedCluster {
 	mc := getSampleManagedCluster()
 	mc.Properties.ProvisioningState = ptr.To("Succeeded")
@@ -663,3 +1050,74 @@ func getExistingClusterWithAuthorizedIPRanges() armcontainerservice.ManagedClust
 	}
 	return mc
 }
+
+func TestDrift(t *testing.T) {
+	managedClusterSpec := func(version string, reportDriftOnly bool) *ManagedClusterSpec {
+		return &ManagedClusterSpec{
+			Name:          "test-managedcluster",
+			ResourceGroup: "test-rg",
+			Location:      "test-location",
+			Tags: map[string]string{
+				"test-tag": "test-value",
+			},
+			Version:         version,
+			LoadBalancerSKU: "standard",
+			OIDCIssuerProfile: &OIDCIssuerProfile{
+				Enabled: ptr.To(true),
+			},
+			ReportDriftOnly: reportDriftOnly,
+			GetAllAgentPools: func() ([]azure.ResourceSpecGetter, error) {
+				return nil, nil
+			},
+		}
+	}
+
+	testcases := []struct {
+		name           string
+		spec           *ManagedClusterSpec
+		existing       interface{}
+		expectedParams bool
+		expectedDrift  []string
+	}{
+		{
+			name:           "no existing managed cluster",
+			spec:           managedClusterSpec("v1.22.0", false),
+			existing:       nil,
+			expectedParams: true,
+			expectedDrift:  nil,
+		},
+		{
+			name:           "existing managed cluster up to date",
+			spec:           managedClusterSpec("v1.22.0", false),
+			existing:       getExistingCluster(),
+			expectedParams: false,
+			expectedDrift:  []string{},
+		},
+		{
+			name:           "existing managed cluster is updated",
+			spec:           managedClusterSpec("v1.22.99", false),
+			existing:       getExistingCluster(),
+			expectedParams: true,
+			expectedDrift:  []string{"Properties.KubernetesVersion"},
+		},
+		{
+			name:           "existing managed cluster is not updated when drift is only reported",
+			spec:           managedClusterSpec("v1.22.99", true),
+			existing:       getExistingCluster(),
+			expectedParams: false,
+			expectedDrift:  []string{"Properties.KubernetesVersion"},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result != nil).To(Equal(tc.expectedParams))
+			g.Expect(tc.spec.Drift()).To(Equal(tc.expectedDrift))
+		})
+	}
+}
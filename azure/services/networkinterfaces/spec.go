@@ -55,6 +55,8 @@ type NICSpec struct {
 	AdditionalTags            infrav1.Tags
 	ClusterName               string
 	IPConfigs                 []IPConfig
+	ApplicationSecurityGroups []string
+	SecurityGroupID           string
 }
 
 // IPConfig defines the specification for an IP address configuration.
@@ -78,6 +80,20 @@ func (s *NICSpec) OwnerResourceName() string {
 	return ""
 }
 
+// applicationSecurityGroups returns references to the application security groups the network interface joins.
+func (s *NICSpec) applicationSecurityGroups() []*armnetwork.ApplicationSecurityGroup {
+	if len(s.ApplicationSecurityGroups) == 0 {
+		return nil
+	}
+	asgs := make([]*armnetwork.ApplicationSecurityGroup, 0, len(s.ApplicationSecurityGroups))
+	for _, name := range s.ApplicationSecurityGroups {
+		asgs = append(asgs, &armnetwork.ApplicationSecurityGroup{
+			ID: ptr.To(azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ResourceGroup, name)),
+		})
+	}
+	return asgs
+}
+
 // Parameters returns the parameters for the network interface.
 func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
 	if existing != nil {
@@ -97,6 +113,10 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 	}
 	primaryIPConfig.Subnet = subnet
 
+	// Azure requires every IP configuration of a network interface to join the same application security groups.
+	asgs := s.applicationSecurityGroups()
+	primaryIPConfig.ApplicationSecurityGroups = asgs
+
 	primaryIPConfig.PrivateIPAllocationMethod = ptr.To(armnetwork.IPAllocationMethodDynamic)
 	if s.StaticIPAddress != "" {
 		primaryIPConfig.PrivateIPAllocationMethod = ptr.To(armnetwork.IPAllocationMethodStatic)
@@ -160,6 +180,7 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 		c := s.IPConfigs[i]
 		newIPConfigPropertiesFormat := &armnetwork.InterfaceIPConfigurationPropertiesFormat{}
 		newIPConfigPropertiesFormat.Subnet = subnet
+		newIPConfigPropertiesFormat.ApplicationSecurityGroups = asgs
 		config := &armnetwork.InterfaceIPConfiguration{
 			Name:       ptr.To(s.Name + "-" + strconv.Itoa(i)),
 			Properties: newIPConfigPropertiesFormat,
@@ -192,15 +213,21 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 		ipv6Config := &armnetwork.InterfaceIPConfiguration{
 			Name: ptr.To("ipConfigv6"),
 			Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
-				PrivateIPAddressVersion: ptr.To(armnetwork.IPVersionIPv6),
-				Primary:                 ptr.To(false),
-				Subnet:                  &armnetwork.Subnet{ID: subnet.ID},
+				PrivateIPAddressVersion:   ptr.To(armnetwork.IPVersionIPv6),
+				Primary:                   ptr.To(false),
+				Subnet:                    &armnetwork.Subnet{ID: subnet.ID},
+				ApplicationSecurityGroups: asgs,
 			},
 		}
 
 		ipConfigurations = append(ipConfigurations, ipv6Config)
 	}
 
+	var securityGroup *armnetwork.SecurityGroup
+	if s.SecurityGroupID != "" {
+		securityGroup = &armnetwork.SecurityGroup{ID: ptr.To(s.SecurityGroupID)}
+	}
+
 	return armnetwork.Interface{
 		Location:         ptr.To(s.Location),
 		ExtendedLocation: converters.ExtendedLocationToNetworkSDK(s.ExtendedLocation),
@@ -209,6 +236,7 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 			IPConfigurations:            ipConfigurations,
 			DNSSettings:                 &dnsSettings,
 			EnableIPForwarding:          ptr.To(s.EnableIPForwarding),
+			NetworkSecurityGroup:        securityGroup,
 		},
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
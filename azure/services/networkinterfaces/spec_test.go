@@ -232,6 +232,33 @@ var (
 		IPConfigs:             []IPConfig{{}, {}},
 		ClusterName:           "my-cluster",
 	}
+	fakeApplicationSecurityGroupsNICSpec = NICSpec{
+		Name:                      "my-net-interface",
+		ResourceGroup:             "my-rg",
+		Location:                  "fake-location",
+		SubscriptionID:            "123",
+		MachineName:               "azure-test1",
+		SubnetName:                "my-subnet",
+		VNetName:                  "my-vnet",
+		VNetResourceGroup:         "my-rg",
+		AcceleratedNetworking:     ptr.To(false),
+		IPConfigs:                 []IPConfig{{}, {}},
+		ClusterName:               "my-cluster",
+		ApplicationSecurityGroups: []string{"web", "monitoring"},
+	}
+	fakeSecurityGroupNICSpec = NICSpec{
+		Name:                  "my-net-interface",
+		ResourceGroup:         "my-rg",
+		Location:              "fake-location",
+		SubscriptionID:        "123",
+		MachineName:           "azure-test1",
+		SubnetName:            "my-subnet",
+		VNetName:              "my-vnet",
+		VNetResourceGroup:     "my-rg",
+		AcceleratedNetworking: ptr.To(false),
+		ClusterName:           "my-cluster",
+		SecurityGroupID:       "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-net-interface-nsg",
+	}
 	fakeTwoIPconfigWithPublicNICSpec = NICSpec{
 		Name:                  "my-net-interface",
 		ResourceGroup:         "my-rg",
@@ -668,6 +695,89 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for network interface with application security groups",
+			spec:     &fakeApplicationSecurityGroupsNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.Interface{}))
+				g.Expect(result.(armnetwork.Interface)).To(Equal(armnetwork.Interface{
+					Tags: map[string]*string{
+						"Name": ptr.To("my-net-interface"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+					},
+					Location: ptr.To("fake-location"),
+					Properties: &armnetwork.InterfacePropertiesFormat{
+						EnableAcceleratedNetworking: ptr.To(false),
+						EnableIPForwarding:          ptr.To(false),
+						DNSSettings:                 &armnetwork.InterfaceDNSSettings{},
+						IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
+							{
+								Name: ptr.To("pipConfig"),
+								Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+									Primary:                         ptr.To(true),
+									Subnet:                          &armnetwork.Subnet{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									PrivateIPAllocationMethod:       ptr.To(armnetwork.IPAllocationMethodDynamic),
+									LoadBalancerBackendAddressPools: []*armnetwork.BackendAddressPool{},
+									ApplicationSecurityGroups: []*armnetwork.ApplicationSecurityGroup{
+										{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/web")},
+										{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/monitoring")},
+									},
+								},
+							},
+							{
+								Name: ptr.To("my-net-interface-1"),
+								Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+									Primary:                   ptr.To(false),
+									Subnet:                    &armnetwork.Subnet{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									PrivateIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodDynamic),
+									ApplicationSecurityGroups: []*armnetwork.ApplicationSecurityGroup{
+										{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/web")},
+										{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/monitoring")},
+									},
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "get parameters for network interface with a network security group",
+			spec:     &fakeSecurityGroupNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.Interface{}))
+				g.Expect(result.(armnetwork.Interface)).To(Equal(armnetwork.Interface{
+					Tags: map[string]*string{
+						"Name": ptr.To("my-net-interface"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+					},
+					Location: ptr.To("fake-location"),
+					Properties: &armnetwork.InterfacePropertiesFormat{
+						EnableAcceleratedNetworking: ptr.To(false),
+						EnableIPForwarding:          ptr.To(false),
+						DNSSettings:                 &armnetwork.InterfaceDNSSettings{},
+						NetworkSecurityGroup: &armnetwork.SecurityGroup{
+							ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-net-interface-nsg"),
+						},
+						IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
+							{
+								Name: ptr.To("pipConfig"),
+								Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+									Primary:                         ptr.To(true),
+									Subnet:                          &armnetwork.Subnet{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									PrivateIPAllocationMethod:       ptr.To(armnetwork.IPAllocationMethodDynamic),
+									LoadBalancerBackendAddressPools: []*armnetwork.BackendAddressPool{},
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
 	}
 	format.MaxLength = 10000
 	for _, tc := range testcases {
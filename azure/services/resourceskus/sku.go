@@ -59,6 +59,8 @@ const (
 	VCPUs = "vCPUs"
 	// MemoryGB identifies the capability for memory Size.
 	MemoryGB = "MemoryGB"
+	// GPUs identifies the capability for the number of GPUs.
+	GPUs = "GPUs"
 	// MinimumVCPUS is the minimum vCPUS allowed.
 	MinimumVCPUS = 2
 	// MinimumMemory is the minimum memory allowed.
@@ -39,6 +39,13 @@ var (
 		AdditionalTags: map[string]string{
 			"foo": "bar",
 		},
+		Routes: infrav1.Routes{fakeRoute},
+	}
+	fakeRoute = infrav1.Route{
+		Name:             "to-firewall",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+		NextHopIPAddress: "10.0.0.4",
 	}
 	fakeRT2 = RouteTableSpec{
 		Name:          "test-rt-2",
@@ -73,6 +80,7 @@ func TestReconcileRouteTables(t *testing.T) {
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, nil)
+				s.UpdateAnnotationJSON(azure.RoutesLastAppliedAnnotation, map[string]interface{}{fakeRT.Name: map[string]string{fakeRoute.Name: fakeRoute.AddressPrefix}})
 				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
 			},
 		},
@@ -84,6 +92,7 @@ func TestReconcileRouteTables(t *testing.T) {
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, nil)
+				s.UpdateAnnotationJSON(azure.RoutesLastAppliedAnnotation, map[string]interface{}{fakeRT.Name: map[string]string{fakeRoute.Name: fakeRoute.AddressPrefix}})
 				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
 			},
 		},
@@ -95,6 +104,7 @@ func TestReconcileRouteTables(t *testing.T) {
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, notDoneError)
+				s.UpdateAnnotationJSON(azure.RoutesLastAppliedAnnotation, map[string]interface{}{fakeRT.Name: map[string]string{fakeRoute.Name: fakeRoute.AddressPrefix}})
 				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
 			},
 		},
@@ -105,6 +115,16 @@ func TestReconcileRouteTables(t *testing.T) {
 				s.IsVnetManaged().Return(false)
 			},
 		},
+		{
+			name:          "fail to update last applied routes annotation",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, nil)
+				s.UpdateAnnotationJSON(azure.RoutesLastAppliedAnnotation, map[string]interface{}{fakeRT.Name: map[string]string{fakeRoute.Name: fakeRoute.AddressPrefix}}).Return(errFake)
+			},
+		},
 	}
 
 	for _, tc := range testcases {
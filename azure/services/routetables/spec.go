@@ -18,6 +18,7 @@ package routetables
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/pkg/errors"
@@ -28,11 +29,13 @@ import (
 
 // RouteTableSpec defines the specification for a route table.
 type RouteTableSpec struct {
-	Name           string
-	ResourceGroup  string
-	Location       string
-	ClusterName    string
-	AdditionalTags infrav1.Tags
+	Name              string
+	ResourceGroup     string
+	Location          string
+	ClusterName       string
+	AdditionalTags    infrav1.Tags
+	Routes            infrav1.Routes
+	LastAppliedRoutes map[string]interface{}
 }
 
 // ResourceName returns the name of the route table.
@@ -52,22 +55,102 @@ func (s *RouteTableSpec) OwnerResourceName() string {
 
 // Parameters returns the parameters for the route table.
 func (s *RouteTableSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	routes := make([]*armnetwork.Route, 0, len(s.Routes))
+	for _, route := range s.Routes {
+		routes = append(routes, converters.RouteToSDK(route))
+	}
+
+	tags := converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        ptr.To(s.Name),
+		Additional:  s.AdditionalTags,
+	}))
+
+	var etag *string
+	var disableBGPRoutePropagation *bool
 	if existing != nil {
-		if _, ok := existing.(armnetwork.RouteTable); !ok {
+		existingRT, ok := existing.(armnetwork.RouteTable)
+		if !ok {
 			return nil, errors.Errorf("%T is not an armnetwork.RouteTable", existing)
 		}
 		// route table already exists
-		// currently don't support specifying your own routes via spec.
-		return nil, nil
+		// We append the existing route table etag to the header to ensure we only apply the updates if the route table
+		// has not been modified.
+		etag = existingRT.Etag
+
+		// Keep the tags which were added to the route table outside of CAPZ, as the update replaces all of them.
+		for k, v := range existingRT.Tags {
+			if _, ok := tags[k]; !ok {
+				tags[k] = v
+			}
+		}
+
+		var existingRoutes []*armnetwork.Route
+		if existingRT.Properties != nil {
+			existingRoutes = existingRT.Properties.Routes
+			disableBGPRoutePropagation = existingRT.Properties.DisableBgpRoutePropagation
+		}
+
+		// Check if the expected routes are present
+		update := false
+		for _, route := range routes {
+			if !routeExists(existingRoutes, route) {
+				update = true
+			}
+		}
+
+		for _, oldRoute := range existingRoutes {
+			name := ptr.Deref(oldRoute.Name, "")
+			if s.hasRoute(name) {
+				continue
+			}
+			// If the route was applied by CAPZ and is not found in the spec anymore, then it has been deleted
+			if _, tracked := s.LastAppliedRoutes[name]; tracked {
+				update = true
+				continue
+			}
+
+			// Keep the routes which were not added by CAPZ
+			routes = append(routes, oldRoute)
+		}
+
+		if !update {
+			// Skip update for the route table as the routes in the spec are present
+			return nil, nil
+		}
 	}
+
 	return armnetwork.RouteTable{
-		Location:   ptr.To(s.Location),
-		Properties: &armnetwork.RouteTablePropertiesFormat{},
-		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.ClusterName,
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        ptr.To(s.Name),
-			Additional:  s.AdditionalTags,
-		})),
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.RouteTablePropertiesFormat{
+			Routes:                     routes,
+			DisableBgpRoutePropagation: disableBGPRoutePropagation,
+		},
+		Etag: etag,
+		Tags: tags,
 	}, nil
 }
+
+// hasRoute returns true if the route with the given name is part of the spec.
+func (s *RouteTableSpec) hasRoute(name string) bool {
+	for _, route := range s.Routes {
+		if strings.EqualFold(route.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeExists returns true if an existing route matches the given route.
+func routeExists(routes []*armnetwork.Route, route *armnetwork.Route) bool {
+	for _, existingRoute := range routes {
+		if !strings.EqualFold(ptr.Deref(existingRoute.Name, ""), ptr.Deref(route.Name, "")) || existingRoute.Properties == nil {
+			continue
+		}
+		return ptr.Deref(existingRoute.Properties.AddressPrefix, "") == ptr.Deref(route.Properties.AddressPrefix, "") &&
+			ptr.Deref(existingRoute.Properties.NextHopType, "") == ptr.Deref(route.Properties.NextHopType, "") &&
+			ptr.Deref(existingRoute.Properties.NextHopIPAddress, "") == ptr.Deref(route.Properties.NextHopIPAddress, "")
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routetables
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+var (
+	firewallRoute = infrav1.Route{
+		Name:             "to-firewall",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+		NextHopIPAddress: "10.0.0.4",
+	}
+	onPremRoute = infrav1.Route{
+		Name:          "to-on-prem",
+		AddressPrefix: "192.168.0.0/16",
+		NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+	}
+	externalRoute = armnetwork.Route{
+		Name: ptr.To("added-by-another-tool"),
+		Properties: &armnetwork.RoutePropertiesFormat{
+			AddressPrefix: ptr.To("172.16.0.0/12"),
+			NextHopType:   ptr.To(armnetwork.RouteNextHopTypeNone),
+		},
+	}
+	rtTags = map[string]*string{
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+		"Name": ptr.To("test-rt"),
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *RouteTableSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "route table does not exist",
+			spec: &RouteTableSpec{
+				Name:        "test-rt",
+				Location:    "test-location",
+				ClusterName: "my-cluster",
+				Routes:      infrav1.Routes{firewallRoute},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armnetwork.RouteTable{
+					Location: ptr.To("test-location"),
+					Properties: &armnetwork.RouteTablePropertiesFormat{
+						Routes: []*armnetwork.Route{converters.RouteToSDK(firewallRoute)},
+					},
+					Tags: rtTags,
+				}))
+			},
+		},
+		{
+			name: "route table already exists with all routes present",
+			spec: &RouteTableSpec{
+				Name:        "test-rt",
+				Location:    "test-location",
+				ClusterName: "my-cluster",
+				Routes:      infrav1.Routes{firewallRoute},
+			},
+			existing: armnetwork.RouteTable{
+				Name: ptr.To("test-rt"),
+				Properties: &armnetwork.RouteTablePropertiesFormat{
+					Routes: []*armnetwork.Route{converters.RouteToSDK(firewallRoute), &externalRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "route table already exists but missing a route",
+			spec: &RouteTableSpec{
+				Name:        "test-rt",
+				Location:    "test-location",
+				ClusterName: "my-cluster",
+				Routes:      infrav1.Routes{firewallRoute, onPremRoute},
+			},
+			existing: armnetwork.RouteTable{
+				Name: ptr.To("test-rt"),
+				Etag: ptr.To("fake-etag"),
+				Properties: &armnetwork.RouteTablePropertiesFormat{
+					Routes:                     []*armnetwork.Route{converters.RouteToSDK(firewallRoute), &externalRoute},
+					DisableBgpRoutePropagation: ptr.To(true),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armnetwork.RouteTable{
+					Location: ptr.To("test-location"),
+					Etag:     ptr.To("fake-etag"),
+					Properties: &armnetwork.RouteTablePropertiesFormat{
+						Routes: []*armnetwork.Route{
+							converters.RouteToSDK(firewallRoute),
+							converters.RouteToSDK(onPremRoute),
+							&externalRoute,
+						},
+						DisableBgpRoutePropagation: ptr.To(true),
+					},
+					Tags: rtTags,
+				}))
+			},
+		},
+		{
+			name: "route table already exists with tags added outside of CAPZ",
+			spec: &RouteTableSpec{
+				Name:        "test-rt",
+				Location:    "test-location",
+				ClusterName: "my-cluster",
+				Routes:      infrav1.Routes{firewallRoute},
+			},
+			existing: armnetwork.RouteTable{
+				Name: ptr.To("test-rt"),
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+					"Name":       ptr.To("test-rt"),
+					"costCenter": ptr.To("networking"),
+				},
+				Properties: &armnetwork.RouteTablePropertiesFormat{},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armnetwork.RouteTable{
+					Location: ptr.To("test-location"),
+					Properties: &armnetwork.RouteTablePropertiesFormat{
+						Routes: []*armnetwork.Route{converters.RouteToSDK(firewallRoute)},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+						"Name":       ptr.To("test-rt"),
+						"costCenter": ptr.To("networking"),
+					},
+				}))
+			},
+		},
+		{
+			name: "route table already exists with an outdated route",
+			spec: &RouteTableSpec{
+				Name:        "test-rt",
+				Location:    "test-location",
+				ClusterName: "my-cluster",
+				Routes:      infrav1.Routes{firewallRoute},
+			},
+			existing: armnetwork.RouteTable{
+				Name: ptr.To("test-rt"),
+				Etag: ptr.To("fake-etag"),
+				Properties: &armnetwork.RouteTablePropertiesFormat{
+					Routes: []*armnetwork.Route{
+						converters.RouteToSDK(infrav1.Route{
+							Name:             firewallRoute.Name,
+							AddressPrefix:    firewallRoute.AddressPrefix,
+							NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+							NextHopIPAddress: "10.0.0.5",
+						}),
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armnetwork.RouteTable{
+					Location: ptr.To("test-location"),
+					Etag:     ptr.To("fake-etag"),
+					Properties: &armnetwork.RouteTablePropertiesFormat{
+						Routes: []*armnetwork.Route{converters.RouteToSDK(firewallRoute)},
+					},
+					Tags: rtTags,
+				}))
+			},
+		},
+		{
+			name: "route table already exists and a route applied by CAPZ is deleted",
+			spec: &RouteTableSpec{
+				Name:        "test-rt",
+				Location:    "test-location",
+				ClusterName: "my-cluster",
+				Routes:      infrav1.Routes{firewallRoute},
+				LastAppliedRoutes: map[string]interface{}{
+					firewallRoute.Name: firewallRoute.AddressPrefix,
+					onPremRoute.Name:   onPremRoute.AddressPrefix,
+				},
+			},
+			existing: armnetwork.RouteTable{
+				Name: ptr.To("test-rt"),
+				Etag: ptr.To("fake-etag"),
+				Properties: &armnetwork.RouteTablePropertiesFormat{
+					Routes: []*armnetwork.Route{
+						converters.RouteToSDK(firewallRoute),
+						converters.RouteToSDK(onPremRoute),
+						&externalRoute,
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armnetwork.RouteTable{
+					Location: ptr.To("test-location"),
+					Etag:     ptr.To("fake-etag"),
+					Properties: &armnetwork.RouteTablePropertiesFormat{
+						Routes: []*armnetwork.Route{
+							converters.RouteToSDK(firewallRoute),
+							&externalRoute,
+						},
+					},
+					Tags: rtTags,
+				}))
+			},
+		},
+		{
+			name:          "existing is not a route table",
+			spec:          &RouteTableSpec{},
+			existing:      struct{}{},
+			expectedError: "struct {} is not an armnetwork.RouteTable",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
@@ -320,6 +320,21 @@ func (s *ScaleSetSpec) getVirtualMachineScaleSetNetworkConfiguration() *[]armcom
 			nicConfig.Properties.EnableAcceleratedNetworking = s.AcceleratedNetworking
 		}
 
+		if n.SecurityGroup != nil {
+			nsgID := n.SecurityGroup.ID
+			if nsgID == "" {
+				nsgID = azure.SecurityGroupID(s.SubscriptionID, s.ResourceGroup, azure.GenerateNICSecurityGroupName(*nicConfig.Name))
+			}
+			nicConfig.Properties.NetworkSecurityGroup = &armcompute.SubResource{ID: ptr.To(nsgID)}
+		}
+
+		var asgs []*armcompute.SubResource
+		for _, name := range n.ApplicationSecurityGroups {
+			asgs = append(asgs, &armcompute.SubResource{
+				ID: ptr.To(azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ResourceGroup, name)),
+			})
+		}
+
 		// Create IPConfigs
 		ipconfigs := []armcompute.VirtualMachineScaleSetIPConfiguration{}
 		for j := 0; j < n.PrivateIPConfigs; j++ {
@@ -330,6 +345,7 @@ func (s *ScaleSetSpec) getVirtualMachineScaleSetNetworkConfiguration() *[]armcom
 					Subnet: &armcompute.APIEntityReference{
 						ID: ptr.To(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, n.SubnetName)),
 					},
+					ApplicationSecurityGroups: asgs,
 				},
 			}
 
@@ -348,6 +364,7 @@ func (s *ScaleSetSpec) getVirtualMachineScaleSetNetworkConfiguration() *[]armcom
 					Subnet: &armcompute.APIEntityReference{
 						ID: ptr.To(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, n.SubnetName)),
 					},
+					ApplicationSecurityGroups: asgs,
 				},
 			}
 			ipconfigs = append(ipconfigs, ipv6Config)
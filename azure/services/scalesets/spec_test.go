@@ -37,6 +37,8 @@ var (
 	acceleratedNetworkingSpec, acceleratedNetworkingVMSS                               = getAcceleratedNetworkingVMSS()
 	customSubnetSpec, customSubnetVMSS                                                 = getCustomSubnetVMSS()
 	customNetworkingSpec, customNetworkingVMSS                                         = getCustomNetworkingVMSS()
+	applicationSecurityGroupsSpec, applicationSecurityGroupsVMSS                       = getApplicationSecurityGroupsVMSS()
+	securityGroupSpec, securityGroupVMSS                                               = getSecurityGroupVMSS()
 	spotVMSpec, spotVMVMSS                                                             = getSpotVMVMSS()
 	ephemeralSpec, ephemeralVMSS                                                       = getEPHVMSSS()
 	evictionSpec, evictionVMSS                                                         = getEvictionPolicyVMSS()
@@ -125,6 +127,29 @@ func getCustomSubnetVMSS() (ScaleSetSpec, armcompute.VirtualMachineScaleSet) {
 	return spec, customSubnetVMSS
 }
 
+func getApplicationSecurityGroupsVMSS() (ScaleSetSpec, armcompute.VirtualMachineScaleSet) {
+	spec, vmss := getCustomSubnetVMSS()
+	spec.NetworkInterfaces[0].ApplicationSecurityGroups = []string{"web"}
+	ipConfigs := vmss.Properties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations[0].Properties.IPConfigurations
+	ipConfigs[0].Properties.ApplicationSecurityGroups = []*armcompute.SubResource{
+		{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/web")},
+	}
+
+	return spec, vmss
+}
+
+func getSecurityGroupVMSS() (ScaleSetSpec, armcompute.VirtualMachineScaleSet) {
+	spec, vmss := getCustomSubnetVMSS()
+	spec.NetworkInterfaces[0].SecurityGroup = &infrav1.NetworkInterfaceSecurityGroup{
+		SecurityRules: infrav1.SecurityRules{{Name: "allow_ssh", Priority: 2200}},
+	}
+	vmss.Properties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations[0].Properties.NetworkSecurityGroup = &armcompute.SubResource{
+		ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-vmss-nic-0-nsg"),
+	}
+
+	return spec, vmss
+}
+
 func getCustomNetworkingVMSS() (ScaleSetSpec, armcompute.VirtualMachineScaleSet) {
 	spec := newDefaultVMSSSpec()
 	spec.NetworkInterfaces = []infrav1.NetworkInterface{
@@ -565,6 +590,20 @@ func TestScaleSetParameters(t *testing.T) {
 			expected:      customSubnetVMSS,
 			expectedError: "",
 		},
+		{
+			name:          "vmss with application security groups",
+			spec:          applicationSecurityGroupsSpec,
+			existing:      nil,
+			expected:      applicationSecurityGroupsVMSS,
+			expectedError: "",
+		},
+		{
+			name:          "vmss with a network interface security group",
+			spec:          securityGroupSpec,
+			existing:      nil,
+			expected:      securityGroupVMSS,
+			expectedError: "",
+		},
 		{
 			name:          "custom networking vmss",
 			spec:          customNetworkingSpec,
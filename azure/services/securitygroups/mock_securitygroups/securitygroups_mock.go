@@ -168,18 +168,18 @@ func (mr *MockNSGScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockNSGScope)(nil).HashKey))
 }
 
-// IsVnetManaged mocks base method.
-func (m *MockNSGScope) IsVnetManaged() bool {
+// IsNSGManaged mocks base method.
+func (m *MockNSGScope) IsNSGManaged() bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsVnetManaged")
+	ret := m.ctrl.Call(m, "IsNSGManaged")
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-// IsVnetManaged indicates an expected call of IsVnetManaged.
-func (mr *MockNSGScopeMockRecorder) IsVnetManaged() *gomock.Call {
+// IsNSGManaged indicates an expected call of IsNSGManaged.
+func (mr *MockNSGScopeMockRecorder) IsNSGManaged() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsVnetManaged", reflect.TypeOf((*MockNSGScope)(nil).IsVnetManaged))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNSGManaged", reflect.TypeOf((*MockNSGScope)(nil).IsNSGManaged))
 }
 
 // NSGSpecs mocks base method.
@@ -35,7 +35,7 @@ type NSGScope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
 	NSGSpecs() []azure.ResourceSpecGetter
-	IsVnetManaged() bool
+	IsNSGManaged() bool
 	UpdateAnnotationJSON(string, map[string]interface{}) error
 }
 
@@ -161,5 +161,5 @@ func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	_, _, done := tele.StartSpanWithLogger(ctx, "securitygroups.Service.IsManaged")
 	defer done()
 
-	return s.Scope.IsVnetManaged(), nil
+	return s.Scope.IsNSGManaged(), nil
 }
@@ -98,7 +98,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			name:          "create single security group with single rule succeeds, should return no error",
 			expectedError: "",
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG})
 				s.UpdateAnnotationJSON(annotation, map[string]interface{}{fakeNSG.Name: map[string]string{securityRule1.Name: securityRule1.Description}}).Times(1)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, nil)
@@ -109,7 +109,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			name:          "create single security group with multiple rules succeeds, should return no error",
 			expectedError: "",
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&multipleRulesNSG})
 				s.UpdateAnnotationJSON(annotation, map[string]interface{}{multipleRulesNSG.Name: map[string]string{securityRule1.Name: securityRule1.Description, securityRule2.Name: securityRule2.Description}}).Times(1)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &multipleRulesNSG, serviceName).Return(nil, nil)
@@ -120,7 +120,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			name:          "create multiple security groups, should return no error",
 			expectedError: "",
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &noRulesNSG})
 				s.UpdateAnnotationJSON(annotation, map[string]interface{}{fakeNSG.Name: map[string]string{securityRule1.Name: securityRule1.Description}}).Times(1)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, nil)
@@ -132,7 +132,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			name:          "first security groups create fails, should return error",
 			expectedError: errFake.Error(),
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &noRulesNSG})
 				s.UpdateAnnotationJSON(annotation, map[string]interface{}{fakeNSG.Name: map[string]string{securityRule1.Name: securityRule1.Description}}).Times(1)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, errFake)
@@ -144,7 +144,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			name:          "first sg create fails, second sg create not done, should return create error",
 			expectedError: errFake.Error(),
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &noRulesNSG})
 				s.UpdateAnnotationJSON(annotation, map[string]interface{}{fakeNSG.Name: map[string]string{securityRule1.Name: securityRule1.Description}}).Times(1)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, errFake)
@@ -156,7 +156,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			name:          "security groups create not done, should return not done error",
 			expectedError: notDoneError.Error(),
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG})
 				s.UpdateAnnotationJSON(annotation, map[string]interface{}{fakeNSG.Name: map[string]string{securityRule1.Name: securityRule1.Description}})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, notDoneError)
@@ -167,7 +167,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			name:          "vnet is not managed, should skip reconcile",
 			expectedError: "",
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(false)
+				s.IsNSGManaged().Return(false)
 			},
 		},
 	}
@@ -210,7 +210,7 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			name:          "delete multiple security groups succeeds, should return no error",
 			expectedError: "",
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &noRulesNSG})
 				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil)
 				r.DeleteResource(gomockinternal.AContext(), &noRulesNSG, serviceName).Return(nil)
@@ -221,7 +221,7 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			name:          "first security groups delete fails, should return an error",
 			expectedError: errFake.Error(),
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &noRulesNSG})
 				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(errFake)
 				r.DeleteResource(gomockinternal.AContext(), &noRulesNSG, serviceName).Return(nil)
@@ -232,7 +232,7 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			name:          "first security groups delete fails and second security groups create not done, should return an error",
 			expectedError: errFake.Error(),
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &noRulesNSG})
 				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(errFake)
 				r.DeleteResource(gomockinternal.AContext(), &noRulesNSG, serviceName).Return(notDoneError)
@@ -243,7 +243,7 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			name:          "security groups delete not done, should return not done error",
 			expectedError: notDoneError.Error(),
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(true)
+				s.IsNSGManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG})
 				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(notDoneError)
 				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, notDoneError)
@@ -253,7 +253,7 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			name:          "vnet is not managed, should skip delete",
 			expectedError: "",
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.IsVnetManaged().Return(false)
+				s.IsNSGManaged().Return(false)
 			},
 		},
 	}
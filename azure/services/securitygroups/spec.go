@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
@@ -36,6 +37,7 @@ type NSGSpec struct {
 	ResourceGroup            string
 	AdditionalTags           infrav1.Tags
 	LastAppliedSecurityRules map[string]interface{}
+	SubscriptionID           string
 }
 
 // ResourceName returns the name of the security group.
@@ -71,7 +73,7 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 		update := false
 
 		for _, rule := range s.SecurityRules {
-			sdkRule := converters.SecurityRuleToSDK(rule)
+			sdkRule := s.securityRuleToSDK(rule)
 			if !ruleExists(existingNSG.Properties.SecurityRules, sdkRule) {
 				update = true
 				securityRules = append(securityRules, sdkRule)
@@ -99,7 +101,7 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 	} else {
 		// new security group
 		for _, rule := range s.SecurityRules {
-			securityRules = append(securityRules, converters.SecurityRuleToSDK(rule))
+			securityRules = append(securityRules, s.securityRuleToSDK(rule))
 		}
 	}
 
@@ -118,6 +120,28 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 	}, nil
 }
 
+// securityRuleToSDK converts a CAPZ security rule to an Azure network security rule, resolving the
+// application security groups it references to their IDs in the NSG resource group.
+func (s *NSGSpec) securityRuleToSDK(rule infrav1.SecurityRule) *armnetwork.SecurityRule {
+	sdkRule := converters.SecurityRuleToSDK(rule)
+	sdkRule.Properties.SourceApplicationSecurityGroups = s.applicationSecurityGroups(rule.SourceApplicationSecurityGroups)
+	sdkRule.Properties.DestinationApplicationSecurityGroups = s.applicationSecurityGroups(rule.DestinationApplicationSecurityGroups)
+	return sdkRule
+}
+
+func (s *NSGSpec) applicationSecurityGroups(names []string) []*armnetwork.ApplicationSecurityGroup {
+	if len(names) == 0 {
+		return nil
+	}
+	asgs := make([]*armnetwork.ApplicationSecurityGroup, 0, len(names))
+	for _, name := range names {
+		asgs = append(asgs, &armnetwork.ApplicationSecurityGroup{
+			ID: ptr.To(azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ResourceGroup, name)),
+		})
+	}
+	return asgs
+}
+
 // TODO: review this logic and make sure it is what we want. It seems incorrect to skip rules that don't have a certain protocol, etc.
 func ruleExists(rules []*armnetwork.SecurityRule, rule *armnetwork.SecurityRule) bool {
 	for _, existingRule := range rules {
@@ -127,6 +151,10 @@ func ruleExists(rules []*armnetwork.SecurityRule, rule *armnetwork.SecurityRule)
 		if !strings.EqualFold(ptr.Deref(existingRule.Properties.DestinationPortRange, ""), ptr.Deref(rule.Properties.DestinationPortRange, "")) {
 			continue
 		}
+		if !sameApplicationSecurityGroups(existingRule.Properties.SourceApplicationSecurityGroups, rule.Properties.SourceApplicationSecurityGroups) ||
+			!sameApplicationSecurityGroups(existingRule.Properties.DestinationApplicationSecurityGroups, rule.Properties.DestinationApplicationSecurityGroups) {
+			continue
+		}
 		if ptr.Deref(existingRule.Properties.Protocol, "") != armnetwork.SecurityRuleProtocolTCP &&
 			ptr.Deref(existingRule.Properties.Access, "") != armnetwork.SecurityRuleAccessAllow &&
 			ptr.Deref(existingRule.Properties.Direction, "") != armnetwork.SecurityRuleDirectionInbound {
@@ -141,3 +169,22 @@ func ruleExists(rules []*armnetwork.SecurityRule, rule *armnetwork.SecurityRule)
 	}
 	return false
 }
+
+// sameApplicationSecurityGroups returns true if both lists reference the same application security groups, regardless of order.
+func sameApplicationSecurityGroups(a, b []*armnetwork.ApplicationSecurityGroup) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := make(map[string]int, len(a))
+	for _, asg := range a {
+		ids[strings.ToLower(ptr.Deref(asg.ID, ""))]++
+	}
+	for _, asg := range b {
+		id := strings.ToLower(ptr.Deref(asg.ID, ""))
+		if ids[id] == 0 {
+			return false
+		}
+		ids[id]--
+	}
+	return true
+}
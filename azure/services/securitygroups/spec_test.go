@@ -76,6 +76,18 @@ var (
 		DestinationPorts: ptr.To("80"),
 		Action:           infrav1.SecurityRuleActionDeny,
 	}
+	asgRule = infrav1.SecurityRule{
+		Name:                                 "web_to_db",
+		Description:                          "Allow web to db",
+		Priority:                             520,
+		Protocol:                             infrav1.SecurityGroupProtocolTCP,
+		Direction:                            infrav1.SecurityRuleDirectionInbound,
+		SourcePorts:                          ptr.To("*"),
+		DestinationPorts:                     ptr.To("5432"),
+		SourceApplicationSecurityGroups:      []string{"web"},
+		DestinationApplicationSecurityGroups: []string{"db"},
+		Action:                               infrav1.SecurityRuleActionAllow,
+	}
 )
 
 func TestParameters(t *testing.T) {
@@ -351,6 +363,53 @@ func TestParameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "NSG already exists and a rule references application security groups",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					sshRule,
+					asgRule,
+				},
+				ResourceGroup:  "test-group",
+				ClusterName:    "my-cluster",
+				SubscriptionID: "123",
+			},
+			existing: armnetwork.SecurityGroup{
+				Name:     ptr.To("test-nsg"),
+				Location: ptr.To("test-location"),
+				Etag:     ptr.To("fake-etag"),
+				Properties: &armnetwork.SecurityGroupPropertiesFormat{
+					SecurityRules: []*armnetwork.SecurityRule{
+						converters.SecurityRuleToSDK(sshRule),
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				sdkASGRule := converters.SecurityRuleToSDK(asgRule)
+				sdkASGRule.Properties.SourceApplicationSecurityGroups = []*armnetwork.ApplicationSecurityGroup{
+					{ID: ptr.To("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/web")},
+				}
+				sdkASGRule.Properties.DestinationApplicationSecurityGroups = []*armnetwork.ApplicationSecurityGroup{
+					{ID: ptr.To("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/db")},
+				}
+				g.Expect(result).To(Equal(armnetwork.SecurityGroup{
+					Location: ptr.To("test-location"),
+					Etag:     ptr.To("fake-etag"),
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						SecurityRules: []*armnetwork.SecurityRule{
+							sdkASGRule,
+							converters.SecurityRuleToSDK(sshRule),
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+						"Name": ptr.To("test-nsg"),
+					},
+				}))
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -396,6 +455,24 @@ func TestRuleExists(t *testing.T) {
 			rule:     ruleBModified,
 			expected: false,
 		},
+		{
+			name:  "rule exists but its application security groups have changed",
+			rules: []*armnetwork.SecurityRule{ruleA, ruleB},
+			rule: &armnetwork.SecurityRule{
+				Name: ruleB.Name,
+				Properties: &armnetwork.SecurityRulePropertiesFormat{
+					Protocol:             ruleB.Properties.Protocol,
+					DestinationPortRange: ruleB.Properties.DestinationPortRange,
+					SourcePortRange:      ruleB.Properties.SourcePortRange,
+					Direction:            ruleB.Properties.Direction,
+					Access:               ruleB.Properties.Access,
+					SourceApplicationSecurityGroups: []*armnetwork.ApplicationSecurityGroup{
+						{ID: ptr.To("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/web")},
+					},
+				},
+			},
+			expected: false,
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
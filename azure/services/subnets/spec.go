@@ -18,6 +18,7 @@ package subnets
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/google/go-cmp/cmp"
@@ -41,6 +42,9 @@ type SubnetSpec struct {
 	Role              infrav1.SubnetRole
 	NatGatewayName    string
 	ServiceEndpoints  infrav1.ServiceEndpoints
+	// Delegations are the services the subnet is delegated to. When empty, delegations of an existing subnet are kept.
+	Delegations             infrav1.SubnetDelegations
+	ServiceEndpointPolicies []string
 }
 
 // ResourceName returns the name of the subnet.
@@ -60,15 +64,17 @@ func (s *SubnetSpec) OwnerResourceName() string {
 
 // Parameters returns the parameters for the subnet.
 func (s *SubnetSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
+	var existingSubnet *armnetwork.Subnet
 	if existing != nil {
-		existingSubnet, ok := existing.(armnetwork.Subnet)
+		subnet, ok := existing.(armnetwork.Subnet)
 		if !ok {
 			return nil, errors.Errorf("%T is not an armnetwork.Subnet", existing)
 		}
 
-		if !s.shouldUpdate(existingSubnet) {
+		if !s.shouldUpdate(subnet) {
 			return nil, nil
 		}
+		existingSubnet = &subnet
 	}
 
 	if !s.IsVNetManaged {
@@ -111,6 +117,20 @@ func (s *SubnetSpec) Parameters(ctx context.Context, existing interface{}) (para
 	}
 	subnetProperties.ServiceEndpoints = azure.PtrSlice(&serviceEndpoints)
 
+	if len(s.Delegations) > 0 {
+		subnetProperties.Delegations = s.delegations()
+	} else if existingSubnet != nil && existingSubnet.Properties != nil {
+		// Keep delegations that were added to the subnet outside of CAPZ, removing them would fail while the delegated service is using the subnet.
+		subnetProperties.Delegations = existingSubnet.Properties.Delegations
+	}
+
+	if len(s.ServiceEndpointPolicies) > 0 {
+		subnetProperties.ServiceEndpointPolicies = s.serviceEndpointPolicies()
+	} else if existingSubnet != nil && existingSubnet.Properties != nil {
+		// Keep service endpoint policies that were added to the subnet outside of CAPZ.
+		subnetProperties.ServiceEndpointPolicies = existingSubnet.Properties.ServiceEndpointPolicies
+	}
+
 	return armnetwork.Subnet{
 		Properties: &subnetProperties,
 	}, nil
@@ -142,8 +162,72 @@ func (s *SubnetSpec) shouldUpdate(existingSubnet armnetwork.Subnet) bool {
 			newServiceEndpoints = append(newServiceEndpoints, armnetwork.ServiceEndpointPropertiesFormat{Service: ptr.To(se.Service), Locations: azure.PtrSlice(&se.Locations)})
 		}
 
-		diff := cmp.Diff(newServiceEndpoints, existingServiceEndpoints)
-		return diff != ""
+		if diff := cmp.Diff(newServiceEndpoints, existingServiceEndpoints); diff != "" {
+			return true
+		}
+	}
+
+	// Update the subnet if the delegations changed. Delegations are only compared when specified, as existing ones are kept otherwise.
+	if len(s.Delegations) > 0 {
+		existingDelegations := make(map[string]string)
+		for _, d := range existingSubnet.Properties.Delegations {
+			if d == nil || d.Properties == nil {
+				continue
+			}
+			existingDelegations[ptr.Deref(d.Name, "")] = ptr.Deref(d.Properties.ServiceName, "")
+		}
+		if len(existingDelegations) != len(s.Delegations) {
+			return true
+		}
+		for _, d := range s.Delegations {
+			if serviceName, ok := existingDelegations[d.Name]; !ok || !strings.EqualFold(serviceName, d.ServiceName) {
+				return true
+			}
+		}
+	}
+
+	// Update the subnet if the service endpoint policies changed. Policies are only compared when specified, as existing ones are kept otherwise.
+	if len(s.ServiceEndpointPolicies) > 0 {
+		existingPolicies := make(map[string]bool)
+		for _, policy := range existingSubnet.Properties.ServiceEndpointPolicies {
+			if policy != nil && policy.ID != nil {
+				existingPolicies[strings.ToLower(*policy.ID)] = true
+			}
+		}
+		if len(existingPolicies) != len(s.ServiceEndpointPolicies) {
+			return true
+		}
+		for _, id := range s.ServiceEndpointPolicies {
+			if !existingPolicies[strings.ToLower(id)] {
+				return true
+			}
+		}
 	}
+
 	return false
 }
+
+// delegations returns the delegations of the subnet.
+func (s *SubnetSpec) delegations() []*armnetwork.Delegation {
+	delegations := make([]*armnetwork.Delegation, 0, len(s.Delegations))
+	for _, d := range s.Delegations {
+		delegations = append(delegations, &armnetwork.Delegation{
+			Name: ptr.To(d.Name),
+			Properties: &armnetwork.ServiceDelegationPropertiesFormat{
+				ServiceName: ptr.To(d.ServiceName),
+			},
+		})
+	}
+	return delegations
+}
+
+// serviceEndpointPolicies returns the service endpoint policies of the subnet.
+func (s *SubnetSpec) serviceEndpointPolicies() []*armnetwork.ServiceEndpointPolicy {
+	policies := make([]*armnetwork.ServiceEndpointPolicy, 0, len(s.ServiceEndpointPolicies))
+	for _, id := range s.ServiceEndpointPolicies {
+		policies = append(policies, &armnetwork.ServiceEndpointPolicy{
+			ID: ptr.To(id),
+		})
+	}
+	return policies
+}
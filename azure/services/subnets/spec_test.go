@@ -77,6 +77,21 @@ var (
 		},
 	}
 
+	fakeSubnetWithDelegationsSpec = SubnetSpec{
+		Name:              "my-subnet-1",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "123",
+		CIDRs:             []string{"10.0.0.0/16"},
+		IsVNetManaged:     true,
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-rg",
+		Role:              infrav1.SubnetNode,
+		Delegations: infrav1.SubnetDelegations{
+			{Name: "aci", ServiceName: "Microsoft.ContainerInstance/containerGroups"},
+		},
+		ServiceEndpointPolicies: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/my-policy"},
+	}
+
 	fakeIpv6SubnetSpecNotManaged = SubnetSpec{
 		Name:              "my-ipv6-subnet",
 		ResourceGroup:     "my-rg",
@@ -148,6 +163,71 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for subnet with delegations and service endpoint policies",
+			spec:     &fakeSubnetWithDelegationsSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.Subnet{}))
+				props := result.(armnetwork.Subnet).Properties
+				g.Expect(props.Delegations).To(Equal([]*armnetwork.Delegation{{
+					Name: ptr.To("aci"),
+					Properties: &armnetwork.ServiceDelegationPropertiesFormat{
+						ServiceName: ptr.To("Microsoft.ContainerInstance/containerGroups"),
+					},
+				}}))
+				g.Expect(props.ServiceEndpointPolicies).To(Equal([]*armnetwork.ServiceEndpointPolicy{{
+					ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/my-policy"),
+				}}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing delegations are kept when updating a managed subnet without delegations",
+			spec: &fakeSubnetOneCidrSpec,
+			existing: armnetwork.Subnet{
+				Name: ptr.To("my-subnet-1"),
+				Properties: &armnetwork.SubnetPropertiesFormat{
+					AddressPrefix: ptr.To("10.0.0.0/16"),
+					Delegations: []*armnetwork.Delegation{{
+						Name: ptr.To("netapp"),
+						Properties: &armnetwork.ServiceDelegationPropertiesFormat{
+							ServiceName: ptr.To("Microsoft.Netapp/volumes"),
+						},
+					}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.Subnet{}))
+				g.Expect(result.(armnetwork.Subnet).Properties.Delegations).To(Equal([]*armnetwork.Delegation{{
+					Name: ptr.To("netapp"),
+					Properties: &armnetwork.ServiceDelegationPropertiesFormat{
+						ServiceName: ptr.To("Microsoft.Netapp/volumes"),
+					},
+				}}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing service endpoint policies are kept when updating a managed subnet without service endpoint policies",
+			spec: &fakeSubnetOneCidrSpec,
+			existing: armnetwork.Subnet{
+				Name: ptr.To("my-subnet-1"),
+				Properties: &armnetwork.SubnetPropertiesFormat{
+					AddressPrefix: ptr.To("10.0.0.0/16"),
+					ServiceEndpointPolicies: []*armnetwork.ServiceEndpointPolicy{{
+						ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/other-policy"),
+					}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.Subnet{}))
+				g.Expect(result.(armnetwork.Subnet).Properties.ServiceEndpointPolicies).To(Equal([]*armnetwork.ServiceEndpointPolicy{{
+					ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/other-policy"),
+				}}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "error vnet is not managed but ipv6 subnet is missing",
 			spec:     &fakeIpv6SubnetSpecNotManaged,
@@ -199,6 +279,8 @@ func TestSubnetSpec_shouldUpdate(t *testing.T) {
 		Role              infrav1.SubnetRole
 		NatGatewayName    string
 		ServiceEndpoints  infrav1.ServiceEndpoints
+		Delegations       infrav1.SubnetDelegations
+		Policies          []string
 	}
 	type args struct {
 		existingSubnet armnetwork.Subnet
@@ -266,6 +348,115 @@ func TestSubnetSpec_shouldUpdate(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "subnet should be updated if delegations changed",
+			fields: fields{
+				Name:           "my-subnet",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				IsVNetManaged:  true,
+				Delegations: infrav1.SubnetDelegations{
+					{Name: "aci", ServiceName: "Microsoft.ContainerInstance/containerGroups"},
+				},
+			},
+			args: args{
+				existingSubnet: armnetwork.Subnet{
+					Name:       ptr.To("my-subnet"),
+					Properties: &armnetwork.SubnetPropertiesFormat{},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "subnet should not be updated if delegations are unchanged",
+			fields: fields{
+				Name:           "my-subnet",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				IsVNetManaged:  true,
+				Delegations: infrav1.SubnetDelegations{
+					{Name: "aci", ServiceName: "Microsoft.ContainerInstance/containerGroups"},
+				},
+			},
+			args: args{
+				existingSubnet: armnetwork.Subnet{
+					Name: ptr.To("my-subnet"),
+					Properties: &armnetwork.SubnetPropertiesFormat{
+						Delegations: []*armnetwork.Delegation{{
+							Name: ptr.To("aci"),
+							Properties: &armnetwork.ServiceDelegationPropertiesFormat{
+								ServiceName: ptr.To("Microsoft.ContainerInstance/containerGroups"),
+							},
+						}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "subnet should not be updated if delegations are not specified",
+			fields: fields{
+				Name:           "my-subnet",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				IsVNetManaged:  true,
+			},
+			args: args{
+				existingSubnet: armnetwork.Subnet{
+					Name: ptr.To("my-subnet"),
+					Properties: &armnetwork.SubnetPropertiesFormat{
+						Delegations: []*armnetwork.Delegation{{
+							Name: ptr.To("netapp"),
+							Properties: &armnetwork.ServiceDelegationPropertiesFormat{
+								ServiceName: ptr.To("Microsoft.Netapp/volumes"),
+							},
+						}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "subnet should be updated if service endpoint policies changed",
+			fields: fields{
+				Name:           "my-subnet",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				IsVNetManaged:  true,
+				Policies:       []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/my-policy"},
+			},
+			args: args{
+				existingSubnet: armnetwork.Subnet{
+					Name: ptr.To("my-subnet"),
+					Properties: &armnetwork.SubnetPropertiesFormat{
+						ServiceEndpointPolicies: []*armnetwork.ServiceEndpointPolicy{{
+							ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/old-policy"),
+						}},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "subnet should not be updated if service endpoint policies were added outside of CAPZ and none are specified",
+			fields: fields{
+				Name:           "my-subnet",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				IsVNetManaged:  true,
+			},
+			args: args{
+				existingSubnet: armnetwork.Subnet{
+					Name: ptr.To("my-subnet"),
+					Properties: &armnetwork.SubnetPropertiesFormat{
+						ServiceEndpointPolicies: []*armnetwork.ServiceEndpointPolicy{{
+							ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/serviceEndpointPolicies/other-policy"),
+						}},
+					},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "subnet should not be updated if other properties change",
 			fields: fields{
@@ -289,18 +480,20 @@ func TestSubnetSpec_shouldUpdate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &SubnetSpec{
-				Name:              tt.fields.Name,
-				ResourceGroup:     tt.fields.ResourceGroup,
-				SubscriptionID:    tt.fields.SubscriptionID,
-				CIDRs:             tt.fields.CIDRs,
-				VNetName:          tt.fields.VNetName,
-				VNetResourceGroup: tt.fields.VNetResourceGroup,
-				IsVNetManaged:     tt.fields.IsVNetManaged,
-				RouteTableName:    tt.fields.RouteTableName,
-				SecurityGroupName: tt.fields.SecurityGroupName,
-				Role:              tt.fields.Role,
-				NatGatewayName:    tt.fields.NatGatewayName,
-				ServiceEndpoints:  tt.fields.ServiceEndpoints,
+				Name:                    tt.fields.Name,
+				ResourceGroup:           tt.fields.ResourceGroup,
+				SubscriptionID:          tt.fields.SubscriptionID,
+				CIDRs:                   tt.fields.CIDRs,
+				VNetName:                tt.fields.VNetName,
+				VNetResourceGroup:       tt.fields.VNetResourceGroup,
+				IsVNetManaged:           tt.fields.IsVNetManaged,
+				RouteTableName:          tt.fields.RouteTableName,
+				SecurityGroupName:       tt.fields.SecurityGroupName,
+				Role:                    tt.fields.Role,
+				NatGatewayName:          tt.fields.NatGatewayName,
+				ServiceEndpoints:        tt.fields.ServiceEndpoints,
+				Delegations:             tt.fields.Delegations,
+				ServiceEndpointPolicies: tt.fields.Policies,
 			}
 			if got := s.shouldUpdate(tt.args.existingSubnet); got != tt.want {
 				t.Errorf("SubnetSpec.shouldUpdate() = %v, want %v", got, tt.want)
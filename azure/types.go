@@ -93,6 +93,13 @@ type ExtensionSpec struct {
 	ProtectedSettings map[string]string
 }
 
+// AdoptedAgentPool is an agent pool of an existing managed cluster which is imported into CAPZ.
+type AdoptedAgentPool struct {
+	Spec     infrav1.AzureManagedMachinePoolSpec
+	Replicas int32
+	Version  *string
+}
+
 type (
 	// VMSSVM defines a VM in a virtual machine scale set.
 	VMSSVM struct {
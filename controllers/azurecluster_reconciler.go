@@ -22,7 +22,9 @@ import (
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
@@ -54,10 +56,18 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating a NewCache")
 	}
+	asgsSvc, err := applicationsecuritygroups.New(scope)
+	if err != nil {
+		return nil, err
+	}
 	securityGroupsSvc, err := securitygroups.New(scope)
 	if err != nil {
 		return nil, err
 	}
+	flowLogsSvc, err := flowlogs.New(scope)
+	if err != nil {
+		return nil, err
+	}
 	routeTablesSvc, err := routetables.New(scope)
 	if err != nil {
 		return nil, err
@@ -103,7 +113,9 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 		services: []azure.ServiceReconciler{
 			groups.New(scope),
 			virtualNetworksSvc,
+			asgsSvc,
 			securityGroupsSvc,
+			flowLogsSvc,
 			routeTablesSvc,
 			publicIPsSvc,
 			natGatewaysSvc,
@@ -165,6 +177,15 @@ func (s *azureClusterService) Delete(ctx context.Context) error {
 
 	if !ShouldDeleteIndividualResources(ctx, s.scope) {
 		// If the resource group is managed, delete it.
+		// We need to explicitly delete flow logs, as they are created in the resource group of the Network Watcher.
+		flowLogsSvc, err := s.getService(flowlogs.ServiceName)
+		if err != nil {
+			return errors.Wrap(err, "failed to get flow logs service")
+		}
+		if err := flowLogsSvc.Delete(ctx); err != nil {
+			return errors.Wrap(err, "failed to delete flow logs")
+		}
+
 		// We need to explicitly delete vnet peerings, as it is not part of the resource group.
 		vnetPeeringsSvc, err := s.getService(vnetpeerings.ServiceName)
 		if err != nil {
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
@@ -183,7 +184,7 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 	cases := map[string]struct {
 		expectedError string
 		clientBuilder func(g Gomega) client.Client
-		expect        func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
+		expect        func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, fl *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
 	}{
 		"Resource Group is deleted successfully": {
 			expectedError: "",
@@ -211,8 +212,12 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 
 				return c
 			},
-			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, fl *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					fl.Name().Return(flowlogs.ServiceName),
+					fl.Delete(gomockinternal.AContext()).Return(nil),
 					grp.Name().Return(groups.ServiceName),
 					vpr.Name().Return(vnetpeerings.ServiceName),
 					vpr.Delete(gomockinternal.AContext()).Return(nil),
@@ -246,8 +251,12 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 
 				return c
 			},
-			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, fl *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					fl.Name().Return(flowlogs.ServiceName),
+					fl.Delete(gomockinternal.AContext()).Return(nil),
 					grp.Name().Return(groups.ServiceName),
 					vpr.Name().Return(vnetpeerings.ServiceName),
 					vpr.Delete(gomockinternal.AContext()).Return(nil),
@@ -278,11 +287,12 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 
 				return c
 			},
-			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, fl *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
 					three.Delete(gomockinternal.AContext()).Return(nil),
 					two.Delete(gomockinternal.AContext()).Return(nil),
 					one.Delete(gomockinternal.AContext()).Return(nil),
+					fl.Delete(gomockinternal.AContext()).Return(nil),
 					vpr.Delete(gomockinternal.AContext()).Return(nil),
 					grp.Delete(gomockinternal.AContext()).Return(nil))
 			},
@@ -310,7 +320,7 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 
 				return c
 			},
-			expect: func(_ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(_ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, _ *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
 					three.Delete(gomockinternal.AContext()).Return(nil),
 					two.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
@@ -329,11 +339,12 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 			defer mockCtrl.Finish()
 			groupsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			vnetpeeringsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+			flowlogsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			svcOneMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			svcTwoMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
-			tc.expect(groupsMock.EXPECT(), vnetpeeringsMock.EXPECT(), svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			tc.expect(groupsMock.EXPECT(), vnetpeeringsMock.EXPECT(), flowlogsMock.EXPECT(), svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
 			c := tc.clientBuilder(g)
 
 			s := &azureClusterService{
@@ -355,6 +366,7 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 				services: []azure.ServiceReconciler{
 					groupsMock,
 					vnetpeeringsMock,
+					flowlogsMock,
 					svcOneMock,
 					svcTwoMock,
 					svcThreeMock,
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
@@ -73,6 +74,10 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating roleassignments service")
 	}
+	securityGroupsSvc, err := securitygroups.New(machineScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating securitygroups service")
+	}
 	tagsSvc, err := tags.New(machineScope)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating tags service")
@@ -94,6 +99,7 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 		services: []azure.ServiceReconciler{
 			publicIPsSvc,
 			inboundnatrulesSvc,
+			securityGroupsSvc,
 			networkInterfacesSvc,
 			availabilitySetsSvc,
 			disksSvc,
@@ -45,6 +45,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+const (
+	// kubeconfigTokenRefreshMargin is how long before the expiry of its token a kubeconfig is refreshed.
+	kubeconfigTokenRefreshMargin = 10 * time.Minute
+	// minKubeconfigTokenRefreshInterval bounds how often a kubeconfig with an expiring token is refreshed.
+	minKubeconfigTokenRefreshInterval = 1 * time.Minute
+)
+
 // AzureManagedControlPlaneReconciler reconciles an AzureManagedControlPlane object.
 type AzureManagedControlPlaneReconciler struct {
 	client.Client
@@ -266,9 +273,24 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 
 	log.Info("Successfully reconciled")
 
+	// A kubeconfig authenticating with an AAD token can't be used once the token expires, so refresh it beforehand.
+	if expiry := scope.KubeConfigTokenExpiry(); !expiry.IsZero() {
+		return reconcile.Result{RequeueAfter: kubeconfigTokenRefreshInterval(expiry)}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// kubeconfigTokenRefreshInterval returns how long to wait before refreshing a kubeconfig authenticating with a token
+// expiring at the given time.
+func kubeconfigTokenRefreshInterval(expiry time.Time) time.Duration {
+	interval := time.Until(expiry) - kubeconfigTokenRefreshMargin
+	if interval < minKubeconfigTokenRefreshInterval {
+		return minKubeconfigTokenRefreshInterval
+	}
+	return interval
+}
+
 func (amcpr *AzureManagedControlPlaneReconciler) reconcilePause(ctx context.Context, scope *scope.ManagedControlPlaneScope) (reconcile.Result, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureManagedControlPlane.reconcilePause")
 	defer done()
@@ -18,6 +18,7 @@ package controllers
 import (
 	"context"
 	"testing"
+	"time"
 
 	asoresourcesv1 "github.com/Azure/azure-service-operator/v2/api/resources/v1api20200601"
 	. "github.com/onsi/gomega"
@@ -163,3 +164,11 @@ func TestAzureManagedControlPlaneReconcilePaused(t *testing.T) {
 	g.Expect(err).To(BeNil())
 	g.Expect(result.RequeueAfter).To(BeZero())
 }
+
+func TestKubeconfigTokenRefreshInterval(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(kubeconfigTokenRefreshInterval(time.Now().Add(time.Hour))).To(BeNumerically("~", 50*time.Minute, time.Minute))
+	g.Expect(kubeconfigTokenRefreshInterval(time.Now().Add(5 * time.Minute))).To(Equal(minKubeconfigTokenRefreshInterval))
+	g.Expect(kubeconfigTokenRefreshInterval(time.Now().Add(-time.Minute))).To(Equal(minKubeconfigTokenRefreshInterval))
+}
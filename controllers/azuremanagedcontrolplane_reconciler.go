@@ -20,9 +20,12 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/aksextensions"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourcehealth"
@@ -40,6 +43,9 @@ type azureManagedControlPlaneService struct {
 	kubeclient client.Client
 	scope      managedclusters.ManagedClusterScope
 	services   []azure.ServiceReconciler
+	// networkServices are skipped while the existing managed cluster is pending adoption, so the network resources
+	// it uses are only changed once it matches its spec.
+	networkServices []azure.ServiceReconciler
 }
 
 // newAzureManagedControlPlaneReconciler populates all the services based on input scope.
@@ -48,6 +54,14 @@ func newAzureManagedControlPlaneReconciler(scope *scope.ManagedControlPlaneScope
 	if err != nil {
 		return nil, err
 	}
+	maintenanceConfigurationsSvc, err := maintenanceconfigurations.New(scope)
+	if err != nil {
+		return nil, err
+	}
+	aksExtensionsSvc, err := aksextensions.New(scope)
+	if err != nil {
+		return nil, err
+	}
 	privateEndpointsSvc, err := privateendpoints.New(scope)
 	if err != nil {
 		return nil, err
@@ -68,27 +82,40 @@ func newAzureManagedControlPlaneReconciler(scope *scope.ManagedControlPlaneScope
 	if err != nil {
 		return nil, err
 	}
+	groupsSvc := groups.New(scope)
 	return &azureManagedControlPlaneService{
 		kubeclient: scope.Client,
 		scope:      scope,
 		services: []azure.ServiceReconciler{
-			groups.New(scope),
+			groupsSvc,
 			virtualNetworksSvc,
 			subnetsSvc,
 			managedClustersSvc,
+			maintenanceConfigurationsSvc,
+			aksExtensionsSvc,
 			privateEndpointsSvc,
 			tagsSvc,
 			resourceHealthSvc,
 		},
+		networkServices: []azure.ServiceReconciler{
+			groupsSvc,
+			virtualNetworksSvc,
+			subnetsSvc,
+		},
 	}, nil
 }
 
 // Reconcile reconciles all the services in a predetermined order.
 func (r *azureManagedControlPlaneService) Reconcile(ctx context.Context) error {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.Reconcile")
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.Reconcile")
 	defer done()
 
+	adopting := r.scope.IsAdoptingManagedCluster()
 	for _, service := range r.services {
+		if adopting && r.isNetworkService(service) {
+			log.V(4).Info("skipping network service until the managed cluster is adopted", "service", service.Name())
+			continue
+		}
 		if err := service.Reconcile(ctx); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureManagedControlPlane service %s", service.Name())
 		}
@@ -101,6 +128,16 @@ func (r *azureManagedControlPlaneService) Reconcile(ctx context.Context) error {
 	return nil
 }
 
+// isNetworkService returns true if the service reconciles the network resources used by the managed cluster.
+func (r *azureManagedControlPlaneService) isNetworkService(service azure.ServiceReconciler) bool {
+	for _, networkService := range r.networkServices {
+		if networkService == service {
+			return true
+		}
+	}
+	return false
+}
+
 // Pause pauses all components making up the cluster.
 func (r *azureManagedControlPlaneService) Pause(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.Pause")
@@ -138,20 +175,28 @@ func (r *azureManagedControlPlaneService) reconcileKubeconfig(ctx context.Contex
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.reconcileKubeconfig")
 	defer done()
 
-	kubeConfigData := r.scope.GetKubeConfigData()
-	if kubeConfigData == nil {
-		return nil
+	kubeConfigs := []struct {
+		data   []byte
+		secret corev1.Secret
+	}{
+		{data: r.scope.GetKubeConfigData(), secret: r.scope.MakeEmptyKubeConfigSecret()},
+		{data: r.scope.GetUserKubeConfigData(), secret: r.scope.MakeEmptyUserKubeConfigSecret()},
 	}
-	kubeConfigSecret := r.scope.MakeEmptyKubeConfigSecret()
-
-	// Always update credentials in case of rotation
-	if _, err := controllerutil.CreateOrUpdate(ctx, r.kubeclient, &kubeConfigSecret, func() error {
-		kubeConfigSecret.Data = map[string][]byte{
-			secret.KubeconfigDataName: kubeConfigData,
+	for _, kubeConfig := range kubeConfigs {
+		if kubeConfig.data == nil {
+			continue
+		}
+		kubeConfigSecret := kubeConfig.secret
+
+		// Always update credentials in case of rotation
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.kubeclient, &kubeConfigSecret, func() error {
+			kubeConfigSecret.Data = map[string][]byte{
+				secret.KubeconfigDataName: kubeConfig.data,
+			}
+			return nil
+		}); err != nil {
+			return errors.Wrapf(err, "failed to reconcile kubeconfig secret %s for cluster", kubeConfigSecret.Name)
 		}
-		return nil
-	}); err != nil {
-		return errors.Wrap(err, "failed to kubeconfig secret for cluster")
 	}
 
 	return nil
@@ -24,9 +24,89 @@ import (
 	"go.uber.org/mock/gomock"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters/mock_managedclusters"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 )
 
+func TestAzureManagedControlPlaneServiceReconcile(t *testing.T) {
+	cases := map[string]struct {
+		expectedError string
+		expect        func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, network *mock_azure.MockServiceReconcilerMockRecorder, other *mock_azure.MockServiceReconcilerMockRecorder)
+	}{
+		"all services are reconciled in order": {
+			expectedError: "",
+			expect: func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, network *mock_azure.MockServiceReconcilerMockRecorder, other *mock_azure.MockServiceReconcilerMockRecorder) {
+				s.IsAdoptingManagedCluster().Return(false)
+				gomock.InOrder(
+					network.Reconcile(gomockinternal.AContext()).Return(nil),
+					other.Reconcile(gomockinternal.AContext()).Return(nil))
+				s.GetKubeConfigData().Return(nil)
+				s.GetUserKubeConfigData().Return(nil)
+				s.MakeEmptyKubeConfigSecret()
+				s.MakeEmptyUserKubeConfigSecret()
+			},
+		},
+		"network services are skipped while the managed cluster is pending adoption": {
+			expectedError: "",
+			expect: func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, network *mock_azure.MockServiceReconcilerMockRecorder, other *mock_azure.MockServiceReconcilerMockRecorder) {
+				s.IsAdoptingManagedCluster().Return(true)
+				network.Name().Return("network")
+				other.Reconcile(gomockinternal.AContext()).Return(nil)
+				s.GetKubeConfigData().Return(nil)
+				s.GetUserKubeConfigData().Return(nil)
+				s.MakeEmptyKubeConfigSecret()
+				s.MakeEmptyUserKubeConfigSecret()
+			},
+		},
+		"service reconcile fails": {
+			expectedError: "failed to reconcile AzureManagedControlPlane service other: some error happened",
+			expect: func(s *mock_managedclusters.MockManagedClusterScopeMockRecorder, network *mock_azure.MockServiceReconcilerMockRecorder, other *mock_azure.MockServiceReconcilerMockRecorder) {
+				s.IsAdoptingManagedCluster().Return(false)
+				gomock.InOrder(
+					network.Reconcile(gomockinternal.AContext()).Return(nil),
+					other.Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened")),
+					other.Name().Return("other"))
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+			networkSvcMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+			otherSvcMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), networkSvcMock.EXPECT(), otherSvcMock.EXPECT())
+
+			s := &azureManagedControlPlaneService{
+				scope: scopeMock,
+				services: []azure.ServiceReconciler{
+					networkSvcMock,
+					otherSvcMock,
+				},
+				networkServices: []azure.ServiceReconciler{
+					networkSvcMock,
+				},
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestAzureManagedControlPlaneServicePause(t *testing.T) {
 	type pausingServiceReconciler struct {
 		*mock_azure.MockServiceReconciler
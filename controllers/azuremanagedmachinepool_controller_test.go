@@ -72,6 +72,7 @@ func TestAzureManagedMachinePoolReconcile(t *testing.T) {
 
 				reconciler.MockReconciler.EXPECT().Reconcile(gomock2.AContext()).Return(nil)
 				agentpools.SetSubnetName()
+				agentpools.IsManagedClusterStopped().Return(false)
 				agentpools.AgentPoolSpec().Return(&fakeAgentPoolSpec)
 				agentpools.NodeResourceGroup().Return("fake-rg")
 				agentpools.SetAgentPoolProviderIDList(providerIDs)
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -75,7 +76,11 @@ func (a *AgentPoolVMSSNotFoundError) Is(target error) bool {
 
 // newAzureManagedMachinePoolService populates all the services based on input scope.
 func newAzureManagedMachinePoolService(scope *scope.ManagedMachinePoolScope) (*azureManagedMachinePoolService, error) {
-	agentPoolsSvc, err := agentpools.New(scope)
+	skuCache, err := resourceskus.GetCache(scope, scope.Location())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating a NewCache")
+	}
+	agentPoolsSvc, err := agentpools.New(scope, skuCache)
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +116,12 @@ func (s *azureManagedMachinePoolService) Reconcile(ctx context.Context) error {
 
 	s.scope.SetSubnetName()
 
+	// Agent pools cannot be updated while the managed cluster is stopped.
+	if s.scope.IsManagedClusterStopped() {
+		log.Info("skipping managed machine pool reconcile while the managed cluster is stopped")
+		return nil
+	}
+
 	log.Info("reconciling managed machine pool")
 	agentPoolName := s.scope.AgentPoolSpec().ResourceName()
 
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/onsi/gomega"
@@ -218,7 +219,7 @@ func TestAzureMachinePool_Validate(t *testing.T) {
 			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, capifeature.MachinePool, true)()
 			g := gomega.NewGomegaWithT(t)
 			amp := c.Factory(g)
-			actualErr := amp.Validate(nil, nil)
+			actualErr := amp.Validate(context.Background(), nil, nil)
 			c.Expect(g, actualErr)
 		})
 	}
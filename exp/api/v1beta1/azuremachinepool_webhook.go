@@ -32,6 +32,8 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	capifeature "sigs.k8s.io/cluster-api/feature"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -80,7 +82,7 @@ func (ampw *azureMachinePoolWebhook) ValidateCreate(ctx context.Context, obj run
 			"can be set only if the MachinePool feature flag is enabled",
 		)
 	}
-	return nil, amp.Validate(nil, ampw.Client)
+	return nil, amp.Validate(ctx, nil, ampw.Client)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -89,7 +91,7 @@ func (ampw *azureMachinePoolWebhook) ValidateUpdate(ctx context.Context, oldObj,
 	if !ok {
 		return nil, apierrors.NewBadRequest("expected an AzureMachinePool")
 	}
-	return nil, amp.Validate(oldObj, ampw.Client)
+	return nil, amp.Validate(ctx, oldObj, ampw.Client)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -98,7 +100,7 @@ func (ampw *azureMachinePoolWebhook) ValidateDelete(ctx context.Context, obj run
 }
 
 // Validate the Azure Machine Pool and return an aggregate error.
-func (amp *AzureMachinePool) Validate(old runtime.Object, client client.Client) error {
+func (amp *AzureMachinePool) Validate(ctx context.Context, old runtime.Object, client client.Client) error {
 	validators := []func() error{
 		amp.ValidateImage,
 		amp.ValidateTerminateNotificationTimeout,
@@ -110,6 +112,7 @@ func (amp *AzureMachinePool) Validate(old runtime.Object, client client.Client)
 		amp.ValidateSystemAssignedIdentity(old),
 		amp.ValidateSystemAssignedIdentityRole,
 		amp.ValidateNetwork,
+		amp.ValidateApplicationSecurityGroups(ctx, client),
 	}
 
 	var errs []error
@@ -127,9 +130,31 @@ func (amp *AzureMachinePool) ValidateNetwork() error {
 	if (amp.Spec.Template.NetworkInterfaces != nil) && len(amp.Spec.Template.NetworkInterfaces) > 0 && amp.Spec.Template.SubnetName != "" {
 		return errors.New("cannot set both NetworkInterfaces and machine SubnetName")
 	}
+
+	var allErrs field.ErrorList
+	for i, nic := range amp.Spec.Template.NetworkInterfaces {
+		allErrs = append(allErrs, infrav1.ValidateNetworkInterfaceSecurityGroup(nic.SecurityGroup,
+			field.NewPath("spec", "template", "networkInterfaces").Index(i).Child("securityGroup"))...)
+	}
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
 	return nil
 }
 
+// ValidateApplicationSecurityGroups validates that the application security groups joined by the network interfaces
+// are declared on the AzureCluster.
+func (amp *AzureMachinePool) ValidateApplicationSecurityGroups(ctx context.Context, c client.Client) func() error {
+	return func() error {
+		allErrs := infrav1.ValidateNetworkInterfaceApplicationSecurityGroups(ctx, c, amp.Namespace,
+			amp.Labels[clusterv1.ClusterNameLabel], amp.Spec.Template.NetworkInterfaces, field.NewPath("spec", "template", "networkInterfaces"))
+		if len(allErrs) > 0 {
+			return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+		}
+		return nil
+	}
+}
+
 // ValidateImage of an AzureMachinePool.
 func (amp *AzureMachinePool) ValidateImage() error {
 	if amp.Spec.Template.Image != nil {
@@ -303,19 +328,25 @@ func (amp *AzureMachinePool) ValidateOrchestrationMode(c client.Client) func() e
 			if err != nil {
 				return errors.Wrap(err, "failed to find parent MachinePool")
 			}
-			// Kubernetes must be >= 1.26.0 for cloud-provider-azure Helm chart support.
-			if parent.Spec.Template.Spec.Version == nil {
-				return errors.New("could not find Kubernetes version in MachinePool")
-			}
-			k8sVersion, err := semver.ParseTolerant(*parent.Spec.Template.Spec.Version)
-			if err != nil {
-				return errors.Wrap(err, "failed to parse Kubernetes version")
-			}
-			if k8sVersion.LT(semver.MustParse("1.26.0")) {
-				return errors.New(fmt.Sprintf("specified Kubernetes version %s must be >= 1.26.0 for Flexible orchestration mode", k8sVersion))
-			}
+			return validateFlexibleOrchestrationModeVersion(parent)
 		}
 
 		return nil
 	}
 }
+
+// validateFlexibleOrchestrationModeVersion validates that the Kubernetes version of the MachinePool supports the Flexible orchestration mode.
+func validateFlexibleOrchestrationModeVersion(mp *expv1.MachinePool) error {
+	// Kubernetes must be >= 1.26.0 for cloud-provider-azure Helm chart support.
+	if mp.Spec.Template.Spec.Version == nil {
+		return errors.New("could not find Kubernetes version in MachinePool")
+	}
+	k8sVersion, err := semver.ParseTolerant(*mp.Spec.Template.Spec.Version)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse Kubernetes version")
+	}
+	if k8sVersion.LT(semver.MustParse("1.26.0")) {
+		return errors.New(fmt.Sprintf("specified Kubernetes version %s must be >= 1.26.0 for Flexible orchestration mode", k8sVersion))
+	}
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 	"golang.org/x/crypto/ssh"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	utilfeature "k8s.io/component-base/featuregate/testing"
@@ -41,6 +42,7 @@ import (
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	capifeature "sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var (
@@ -220,6 +222,24 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			amp:     createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{SubnetName: "testSubnet"}}),
 			wantErr: false,
 		},
+		{
+			name: "azuremachinepool with network interface security group ID",
+			amp: createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{
+				SubnetName: "testSubnet",
+				SecurityGroup: &infrav1.NetworkInterfaceSecurityGroup{
+					ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-nsg",
+				},
+			}}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with empty network interface security group",
+			amp: createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{
+				SubnetName:    "testSubnet",
+				SecurityGroup: &infrav1.NetworkInterfaceSecurityGroup{},
+			}}),
+			wantErr: true,
+		},
 		{
 			name:    "azuremachinepool with Flexible orchestration mode",
 			amp:     createMachinePoolWithOrchestrationMode(armcompute.OrchestrationModeFlexible),
@@ -639,6 +659,71 @@ func createMachinePoolWithOrchestrationMode(mode armcompute.OrchestrationMode) *
 	}
 }
 
+func TestAzureMachinePool_ValidateApplicationSecurityGroups(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				Kind: "AzureCluster",
+				Name: "test-azure-cluster",
+			},
+		},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-azure-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: infrav1.AzureClusterSpec{
+			NetworkSpec: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					ApplicationSecurityGroups: []infrav1.ApplicationSecurityGroup{{Name: "web"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		asgs    []string
+		wantErr bool
+	}{
+		{
+			name:    "application security group declared on the cluster",
+			asgs:    []string{"web"},
+			wantErr: false,
+		},
+		{
+			name:    "application security group not declared on the cluster",
+			asgs:    []string{"web", "db"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cluster, azureCluster).Build()
+			amp := createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{
+				{SubnetName: "subnet", ApplicationSecurityGroups: tc.asgs},
+			})
+			amp.Namespace = metav1.NamespaceDefault
+			amp.Labels = map[string]string{clusterv1.ClusterNameLabel: "test-cluster"}
+			err := amp.ValidateApplicationSecurityGroups(context.Background(), fakeClient)()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestAzureMachinePool_ValidateCreateFailure(t *testing.T) {
 	g := NewWithT(t)
 
@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// SetDefaults sets the default values for an AzureMachinePoolTemplate.
+// Defaults which depend on the owner cluster, such as the scope of the system-assigned identity role, are
+// applied to each AzureMachinePool created from the template instead.
+func (ampt *AzureMachinePoolTemplate) SetDefaults() error {
+	var errs []error
+	amp := ampt.toAzureMachinePool()
+	if err := amp.SetDefaultSSHPublicKey(); err != nil {
+		errs = append(errs, errors.Wrap(err, "failed to set default SSH public key"))
+	}
+	amp.SetSpotEvictionPolicyDefaults()
+	amp.SetDiagnosticsDefaults()
+	amp.SetNetworkInterfacesDefaults()
+	ampt.Spec.Template.Spec = amp.Spec
+
+	return kerrors.NewAggregate(errs)
+}
+
+// toAzureMachinePool returns an AzureMachinePool carrying the spec of the template, so the AzureMachinePool
+// defaulting and validation can be applied to it.
+func (ampt *AzureMachinePoolTemplate) toAzureMachinePool() *AzureMachinePool {
+	return &AzureMachinePool{
+		ObjectMeta: ampt.ObjectMeta,
+		Spec:       *ampt.Spec.Template.Spec.DeepCopy(),
+	}
+}
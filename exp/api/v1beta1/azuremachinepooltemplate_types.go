@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// AzureMachinePoolTemplateSpec defines the desired state of AzureMachinePoolTemplate.
+	AzureMachinePoolTemplateSpec struct {
+		Template AzureMachinePoolTemplateResource `json:"template"`
+	}
+
+	// AzureMachinePoolTemplateResource describes the data needed to create an AzureMachinePool from a template.
+	AzureMachinePoolTemplateResource struct {
+		// Spec is the specification of the desired behavior of the machine pool.
+		// ProviderID and ProviderIDList are populated by the controller and cannot be set in a template.
+		Spec AzureMachinePoolSpec `json:"spec"`
+	}
+
+	// +kubebuilder:object:root=true
+	// +kubebuilder:resource:path=azuremachinepooltemplates,scope=Namespaced,categories=cluster-api,shortName=ampt
+	// +kubebuilder:storageversion
+
+	// AzureMachinePoolTemplate is the Schema for the azuremachinepooltemplates API.
+	AzureMachinePoolTemplate struct {
+		metav1.TypeMeta   `json:",inline"`
+		metav1.ObjectMeta `json:"metadata,omitempty"`
+
+		Spec AzureMachinePoolTemplateSpec `json:"spec,omitempty"`
+	}
+
+	// +kubebuilder:object:root=true
+
+	// AzureMachinePoolTemplateList contains a list of AzureMachinePoolTemplates.
+	AzureMachinePoolTemplateList struct {
+		metav1.TypeMeta `json:",inline"`
+		metav1.ListMeta `json:"metadata,omitempty"`
+		Items           []AzureMachinePoolTemplate `json:"items"`
+	}
+)
+
+func init() {
+	SchemeBuilder.Register(&AzureMachinePoolTemplate{}, &AzureMachinePoolTemplateList{})
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/topology"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AzureMachinePoolTemplateImmutableMsg ...
+const (
+	AzureMachinePoolTemplateImmutableMsg                      = "AzureMachinePoolTemplate spec.template.spec field is immutable. Please create new resource instead."
+	AzureMachinePoolTemplateProviderIDMsg                     = "AzureMachinePoolTemplate spec.template.spec.providerID and spec.template.spec.providerIDList fields can't be set"
+	AzureMachinePoolTemplateRoleAssignmentNameMsg             = "AzureMachinePoolTemplate spec.template.spec.roleAssignmentName field can't be set"
+	AzureMachinePoolTemplateSystemAssignedIdentityRoleNameMsg = "AzureMachinePoolTemplate spec.template.spec.systemAssignedIdentityRole.name field can't be set"
+)
+
+// SetupAzureMachinePoolTemplateWebhookWithManager sets up and registers the webhook with the manager.
+func SetupAzureMachinePoolTemplateWebhookWithManager(mgr ctrl.Manager) error {
+	amptw := &azureMachinePoolTemplateWebhook{Client: mgr.GetClient()}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&AzureMachinePoolTemplate{}).
+		WithDefaulter(amptw).
+		WithValidator(amptw).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachinepooltemplate,mutating=true,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=azuremachinepooltemplates,verbs=create;update,versions=v1beta1,name=default.azuremachinepooltemplate.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// azureMachinePoolTemplateWebhook implements a validating and defaulting webhook for AzureMachinePoolTemplate.
+type azureMachinePoolTemplateWebhook struct {
+	Client client.Client
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (amptw *azureMachinePoolTemplateWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	ampt, ok := obj.(*AzureMachinePoolTemplate)
+	if !ok {
+		return apierrors.NewBadRequest("expected an AzureMachinePoolTemplate")
+	}
+	return ampt.SetDefaults()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachinepooltemplate,mutating=false,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=azuremachinepooltemplates,versions=v1beta1,name=validation.azuremachinepooltemplate.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (amptw *azureMachinePoolTemplateWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ampt, ok := obj.(*AzureMachinePoolTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureMachinePoolTemplate")
+	}
+	// NOTE: AzureMachinePoolTemplate is behind MachinePool feature gate flag; the webhook
+	// must prevent creating new objects in case the feature flag is disabled.
+	if !feature.Gates.Enabled(capifeature.MachinePool) {
+		return nil, field.Forbidden(
+			field.NewPath("spec"),
+			"can be set only if the MachinePool feature flag is enabled",
+		)
+	}
+	return nil, ampt.Validate(amptw.Client)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (amptw *azureMachinePoolTemplateWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*AzureMachinePoolTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureMachinePoolTemplate")
+	}
+	ampt, ok := newObj.(*AzureMachinePoolTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureMachinePoolTemplate")
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a admission.Request inside context: %v", err))
+	}
+
+	var allErrs field.ErrorList
+	if !topology.ShouldSkipImmutabilityChecks(req, ampt) &&
+		!reflect.DeepEqual(ampt.Spec.Template.Spec, old.Spec.Template.Spec) {
+		// The old object may have been created before some defaults were introduced, so default it
+		// before comparing. The SSH key is copied over, otherwise defaulting would generate a new one.
+		if old.Spec.Template.Spec.Template.SSHPublicKey == "" {
+			old.Spec.Template.Spec.Template.SSHPublicKey = ampt.Spec.Template.Spec.Template.SSHPublicKey
+		}
+
+		if err := old.SetDefaults(); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("AzureMachinePoolTemplate"), ampt, fmt.Sprintf("Unable to apply defaults: %v", err)),
+			)
+		}
+
+		if !reflect.DeepEqual(ampt.Spec.Template.Spec, old.Spec.Template.Spec) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("AzureMachinePoolTemplate", "spec", "template", "spec"), ampt, AzureMachinePoolTemplateImmutableMsg),
+			)
+		}
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("AzureMachinePoolTemplate").GroupKind(), ampt.Name, allErrs)
+	}
+
+	return nil, ampt.Validate(amptw.Client)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (amptw *azureMachinePoolTemplateWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// Validate the Azure Machine Pool Template and return an aggregate error.
+func (ampt *AzureMachinePoolTemplate) Validate(c client.Client) error {
+	amp := ampt.toAzureMachinePool()
+	// The identity defaults depend on the subscription of the cluster, which is only known once the template
+	// is cloned. Apply them to the copy so that only the identity fields set in the template are validated.
+	amp.SetIdentityDefaults("")
+	validators := []func() error{
+		ampt.validateTemplateOnlyFields,
+		amp.ValidateImage,
+		amp.ValidateTerminateNotificationTimeout,
+		amp.ValidateSSHKey,
+		amp.ValidateUserAssignedIdentity,
+		amp.ValidateDiagnostics,
+		ampt.validateOrchestrationMode(c),
+		amp.ValidateStrategy(),
+		amp.ValidateSystemAssignedIdentityRole,
+		amp.ValidateNetwork,
+	}
+
+	var errs []error
+	for _, validator := range validators {
+		if err := validator(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// validateOrchestrationMode validates requirements for the VMSS orchestration mode.
+// The Kubernetes version is set on the MachinePools rather than on the template, so the requirements are
+// checked against the MachinePools of the AzureMachinePools that were cloned from the template.
+func (ampt *AzureMachinePoolTemplate) validateOrchestrationMode(c client.Client) func() error {
+	return func() error {
+		if ampt.Spec.Template.Spec.OrchestrationMode != infrav1.OrchestrationModeType(armcompute.OrchestrationModeFlexible) {
+			return nil
+		}
+
+		ampList := &AzureMachinePoolList{}
+		if err := c.List(context.Background(), ampList, client.InNamespace(ampt.Namespace)); err != nil {
+			return errors.Wrap(err, "failed to list AzureMachinePools")
+		}
+		groupKind := GroupVersion.WithKind("AzureMachinePoolTemplate").GroupKind().String()
+		for _, amp := range ampList.Items {
+			if amp.Annotations[clusterv1.TemplateClonedFromNameAnnotation] != ampt.Name ||
+				amp.Annotations[clusterv1.TemplateClonedFromGroupKindAnnotation] != groupKind {
+				continue
+			}
+			parent, err := azureutil.FindParentMachinePool(amp.Name, c)
+			if err != nil {
+				return errors.Wrap(err, "failed to find parent MachinePool")
+			}
+			if err := validateFlexibleOrchestrationModeVersion(parent); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// validateTemplateOnlyFields rejects fields which identify a single AzureMachinePool and so cannot be shared through a template.
+func (ampt *AzureMachinePoolTemplate) validateTemplateOnlyFields() error {
+	var allErrs field.ErrorList
+	spec := ampt.Spec.Template.Spec
+	if spec.ProviderID != "" || len(spec.ProviderIDList) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "providerID"), AzureMachinePoolTemplateProviderIDMsg))
+	}
+	if spec.RoleAssignmentName != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "roleAssignmentName"), AzureMachinePoolTemplateRoleAssignmentNameMsg))
+	}
+	if spec.SystemAssignedIdentityRole != nil && spec.SystemAssignedIdentityRole.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "systemAssignedIdentityRole", "name"), AzureMachinePoolTemplateSystemAssignedIdentityRoleNameMsg))
+	}
+
+	return allErrs.ToAggregate()
+}
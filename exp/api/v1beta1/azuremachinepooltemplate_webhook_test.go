@@ -0,0 +1,305 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestAzureMachinePoolTemplate_ValidateCreate(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, capifeature.MachinePool, true)()
+
+	tests := []struct {
+		name     string
+		template *AzureMachinePoolTemplate
+		wantErr  bool
+	}{
+		{
+			name:     "valid template",
+			template: getKnownValidAzureMachinePoolTemplate(),
+			wantErr:  false,
+		},
+		{
+			name: "template with providerIDList",
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.ProviderIDList = []string{"azure:///subscriptions/foo"}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "template with roleAssignmentName",
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.RoleAssignmentName = "c6e3443d-bc11-4335-8819-ab6637b10586"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "template with systemAssignedIdentityRole name",
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.SystemAssignedIdentityRole.Name = "c6e3443d-bc11-4335-8819-ab6637b10586"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "template with invalid SSH key",
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.Template.SSHPublicKey = generateSSHPublicKey(false)
+			}),
+			wantErr: true,
+		},
+		{
+			name: "template with invalid terminate notification timeout",
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.Template.TerminateNotificationTimeout = ptr.To(16)
+			}),
+			wantErr: true,
+		},
+		{
+			name: "template with systemAssignedIdentityRole without system-assigned identity",
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.Identity = infrav1.VMIdentityNone
+			}),
+			wantErr: true,
+		},
+		{
+			name: "template with system-assigned identity relying on role defaults",
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.SystemAssignedIdentityRole = nil
+			}),
+			wantErr: false,
+		},
+		{
+			name: "template with invalid network interfaces",
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.Template.SubnetName = "subnet"
+				ampt.Spec.Template.Spec.Template.NetworkInterfaces = []infrav1.NetworkInterface{{SubnetName: "subnet"}}
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			amptw := &azureMachinePoolTemplateWebhook{}
+			_, err := amptw.ValidateCreate(context.Background(), tc.template)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+type mockTemplateClient struct {
+	client.Client
+	Version string
+}
+
+func (m mockTemplateClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	switch l := list.(type) {
+	case *AzureMachinePoolList:
+		amp := AzureMachinePool{}
+		amp.Name = "my-amp"
+		amp.Annotations = map[string]string{
+			clusterv1.TemplateClonedFromNameAnnotation:      "my-template",
+			clusterv1.TemplateClonedFromGroupKindAnnotation: GroupVersion.WithKind("AzureMachinePoolTemplate").GroupKind().String(),
+		}
+		l.Items = []AzureMachinePool{amp}
+	case *expv1.MachinePoolList:
+		mp := expv1.MachinePool{}
+		mp.Spec.Template.Spec.InfrastructureRef.Name = "my-amp"
+		mp.Spec.Template.Spec.Version = &m.Version
+		l.Items = []expv1.MachinePool{mp}
+	}
+	return nil
+}
+
+func TestAzureMachinePoolTemplate_ValidateOrchestrationMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		templateName string
+		version      string
+		wantErr      bool
+	}{
+		{
+			name:         "flexible template used by a MachinePool with a supported version",
+			templateName: "my-template",
+			version:      "v1.26.0",
+			wantErr:      false,
+		},
+		{
+			name:         "flexible template used by a MachinePool with an unsupported version",
+			templateName: "my-template",
+			version:      "v1.25.6",
+			wantErr:      true,
+		},
+		{
+			name:         "flexible template not used by any MachinePool",
+			templateName: "other-template",
+			version:      "v1.25.6",
+			wantErr:      false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ampt := getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Name = tc.templateName
+				ampt.Spec.Template.Spec.OrchestrationMode = infrav1.FlexibleOrchestrationMode
+			})
+			err := ampt.Validate(mockTemplateClient{Version: tc.version})
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureMachinePoolTemplate_ValidateCreateFeatureGateDisabled(t *testing.T) {
+	g := NewWithT(t)
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, capifeature.MachinePool, false)()
+
+	amptw := &azureMachinePoolTemplateWebhook{}
+	_, err := amptw.ValidateCreate(context.Background(), getKnownValidAzureMachinePoolTemplate())
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAzureMachinePoolTemplate_ValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldTemplate *AzureMachinePoolTemplate
+		template    *AzureMachinePoolTemplate
+		dryRun      bool
+		wantErr     bool
+	}{
+		{
+			name:        "unchanged spec",
+			oldTemplate: getKnownValidAzureMachinePoolTemplate(),
+			template:    getKnownValidAzureMachinePoolTemplate(),
+			wantErr:     false,
+		},
+		{
+			name:        "mutable metadata",
+			oldTemplate: getKnownValidAzureMachinePoolTemplate(),
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Labels = map[string]string{"foo": "bar"}
+			}),
+			wantErr: false,
+		},
+		{
+			name:        "immutable spec",
+			oldTemplate: getKnownValidAzureMachinePoolTemplate(),
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.Template.VMSize = "Standard_D4s_v3"
+			}),
+			wantErr: true,
+		},
+		{
+			name:        "immutable spec change allowed for topology dry-run",
+			oldTemplate: getKnownValidAzureMachinePoolTemplate(),
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.Template.VMSize = "Standard_D4s_v3"
+				ampt.Annotations = map[string]string{"topology.cluster.x-k8s.io/dry-run": ""}
+			}),
+			dryRun:  true,
+			wantErr: false,
+		},
+		{
+			name: "old template missing defaults",
+			oldTemplate: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				ampt.Spec.Template.Spec.Template.SSHPublicKey = ""
+				ampt.Spec.Template.Spec.Template.Diagnostics = nil
+			}),
+			template: getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+				g := NewWithT(t)
+				g.Expect(ampt.SetDefaults()).To(Succeed())
+			}),
+			wantErr: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			amptw := &azureMachinePoolTemplateWebhook{}
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{DryRun: ptr.To(tc.dryRun)}})
+			_, err := amptw.ValidateUpdate(ctx, tc.oldTemplate, tc.template)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureMachinePoolTemplate_Default(t *testing.T) {
+	g := NewWithT(t)
+
+	ampt := getKnownValidAzureMachinePoolTemplate(func(ampt *AzureMachinePoolTemplate) {
+		ampt.Spec.Template.Spec.Template.SSHPublicKey = ""
+		ampt.Spec.Template.Spec.Template.Diagnostics = nil
+		ampt.Spec.Template.Spec.Template.SubnetName = "subnet"
+	})
+	amptw := &azureMachinePoolTemplateWebhook{}
+	g.Expect(amptw.Default(context.Background(), ampt)).To(Succeed())
+
+	spec := ampt.Spec.Template.Spec
+	g.Expect(spec.Template.SSHPublicKey).NotTo(BeEmpty())
+	g.Expect(spec.Template.Diagnostics).NotTo(BeNil())
+	g.Expect(spec.Template.Diagnostics.Boot.StorageAccountType).To(Equal(infrav1.ManagedDiagnosticsStorage))
+	g.Expect(spec.Template.SubnetName).To(BeEmpty())
+	g.Expect(spec.Template.NetworkInterfaces).To(HaveLen(1))
+	g.Expect(spec.Template.NetworkInterfaces[0].SubnetName).To(Equal("subnet"))
+	g.Expect(spec.SystemAssignedIdentityRole.Name).To(BeEmpty())
+}
+
+func getKnownValidAzureMachinePoolTemplate(changes ...func(*AzureMachinePoolTemplate)) *AzureMachinePoolTemplate {
+	amp := getKnownValidAzureMachinePool()
+	amp.Spec.SystemAssignedIdentityRole.Name = ""
+	amp.Spec.Template.Diagnostics = &infrav1.Diagnostics{
+		Boot: &infrav1.BootDiagnostics{
+			StorageAccountType: infrav1.ManagedDiagnosticsStorage,
+		},
+	}
+	ampt := &AzureMachinePoolTemplate{
+		Spec: AzureMachinePoolTemplateSpec{
+			Template: AzureMachinePoolTemplateResource{
+				Spec: amp.Spec,
+			},
+		},
+	}
+	for _, change := range changes {
+		change(ampt)
+	}
+	return ampt
+}
@@ -420,6 +420,96 @@ func (in *AzureMachinePoolStatus) DeepCopy() *AzureMachinePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolTemplate) DeepCopyInto(out *AzureMachinePoolTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolTemplate.
+func (in *AzureMachinePoolTemplate) DeepCopy() *AzureMachinePoolTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureMachinePoolTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolTemplateList) DeepCopyInto(out *AzureMachinePoolTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AzureMachinePoolTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolTemplateList.
+func (in *AzureMachinePoolTemplateList) DeepCopy() *AzureMachinePoolTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureMachinePoolTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolTemplateResource) DeepCopyInto(out *AzureMachinePoolTemplateResource) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolTemplateResource.
+func (in *AzureMachinePoolTemplateResource) DeepCopy() *AzureMachinePoolTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolTemplateSpec) DeepCopyInto(out *AzureMachinePoolTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolTemplateSpec.
+func (in *AzureMachinePoolTemplateSpec) DeepCopy() *AzureMachinePoolTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineRollingUpdateDeployment) DeepCopyInto(out *MachineRollingUpdateDeployment) {
 	*out = *in
@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -49,10 +50,15 @@ func newAzureMachinePoolService(machinePoolScope *scope.MachinePoolScope) (*azur
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create a scalesets service")
 	}
+	securityGroupsSvc, err := securitygroups.New(machinePoolScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a securitygroups service")
+	}
 
 	return &azureMachinePoolService{
 		scope: machinePoolScope,
 		services: []azure.ServiceReconciler{
+			securityGroupsSvc,
 			scaleSetsSvc,
 			roleAssignmentsSvc,
 		},
@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Fields returns the sorted paths of the struct fields which differ between x and y, e.g.
+// "Properties.KubernetesVersion". Map keys and slice indexes are left out of the paths.
+func Fields(x, y interface{}, opts ...cmp.Option) []string {
+	r := &fieldReporter{fields: map[string]bool{}}
+	cmp.Equal(x, y, append(opts, cmp.Reporter(r))...)
+
+	fields := make([]string, 0, len(r.fields))
+	for field := range r.fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// fieldReporter is a cmp.Reporter which records the paths of the values which differ.
+type fieldReporter struct {
+	path   cmp.Path
+	fields map[string]bool
+}
+
+func (r *fieldReporter) PushStep(step cmp.PathStep) {
+	r.path = append(r.path, step)
+}
+
+func (r *fieldReporter) Report(result cmp.Result) {
+	if result.Equal() {
+		return
+	}
+	if field := r.path.String(); field != "" {
+		r.fields[field] = true
+	}
+}
+
+func (r *fieldReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
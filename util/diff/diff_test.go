@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+type profile struct {
+	Enabled *bool
+	Config  map[string]*string
+}
+
+type cluster struct {
+	Version  *string
+	Profiles map[string]*profile
+	Zones    []*string
+	Profile  *profile
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		x        cluster
+		y        cluster
+		expected []string
+	}{
+		{
+			name:     "equal values have no differing fields",
+			x:        cluster{Version: ptr.To("1.27.3"), Zones: []*string{ptr.To("1")}},
+			y:        cluster{Version: ptr.To("1.27.3"), Zones: []*string{ptr.To("1")}},
+			expected: []string{},
+		},
+		{
+			name: "nested fields are listed once and sorted",
+			x: cluster{
+				Version:  ptr.To("1.27.3"),
+				Profiles: map[string]*profile{"a": {Enabled: ptr.To(true)}, "b": {Enabled: ptr.To(true)}},
+				Zones:    []*string{ptr.To("1")},
+			},
+			y: cluster{
+				Version:  ptr.To("1.26.6"),
+				Profiles: map[string]*profile{"a": {Enabled: ptr.To(false)}, "b": {Enabled: ptr.To(false)}},
+				Zones:    []*string{ptr.To("2")},
+			},
+			expected: []string{"Profiles.Enabled", "Version", "Zones"},
+		},
+		{
+			name:     "unset struct is reported as a whole",
+			x:        cluster{Profile: &profile{Enabled: ptr.To(true)}},
+			y:        cluster{},
+			expected: []string{"Profile"},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			g.Expect(Fields(tc.x, tc.y)).To(Equal(tc.expected))
+		})
+	}
+}